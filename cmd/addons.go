@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -14,36 +15,72 @@ import (
 
 var addonManager *addons.Manager
 
+var (
+	addonRetries     int
+	addonTimeout     time.Duration
+	addonToken       string
+	addonTokenHost   string
+	addonBackupGit   bool
+	addonsForceCheck bool
+)
+
 var addonsCmd = &cobra.Command{
 	Use:   "addons",
 	Short: "Manage WoW addons",
 	Long: `Manage World of Warcraft addons for Turtle WoW.
 
-When run without subcommands, opens an interactive TUI for managing addons.
+When run without subcommands, opens an interactive TUI for managing addons,
+or lists them when there's no terminal (or with --no-tui). The TUI shows the
+last update check right away and only fetches addons not checked in the
+last 30 minutes, use --force-check to fetch them all.
 
 Examples:
   turtlectl addons                    # Interactive TUI
+  turtlectl addons --force-check      # Interactive TUI, ignoring cached update checks
   turtlectl addons list               # List installed addons
-  turtlectl addons install <git-url>  # Install addon from git URL
+  turtlectl addons install <git-url>  # Install addon from git URL (optionally @ref)
   turtlectl addons remove <name>      # Remove addon
+  turtlectl addons restore <name>     # Restore addon from a backup
+  turtlectl addons reinstall <name>   # Re-clone addon, keeping SavedVariables
+  turtlectl addons disable <name>     # Disable addon without deleting it
+  turtlectl addons enable <name>      # Re-enable a disabled addon
   turtlectl addons update [name]      # Update specific or all addons
+  turtlectl addons pin <name>         # Freeze addon at its current commit
+  turtlectl addons unpin <name>       # Let a pinned addon update again
   turtlectl addons info <name>        # Show addon details
-  turtlectl addons repair             # Sync metadata and fix issues`,
+  turtlectl addons search <query>     # Search the addon registry
+  turtlectl addons repair             # Sync metadata and fix issues
+  turtlectl addons clean-git          # Shrink addon git histories
+  turtlectl addons export             # Write addon manifest
+  turtlectl addons import             # Install addons from manifest`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize manager
+		addons.SetRetries(addonRetries)
+		addons.SetTimeout(addonTimeout)
+		addons.SetAuthToken(addonToken, tokenHost(""))
+		addons.SetBackupGit(addonBackupGit)
 		l := launcher.New(getLogger())
-		manager := addons.NewManager(l.GameDir, l.DataDir, getLogger())
+		manager := newAddonManager(l)
 
 		if err := manager.Load(); err != nil {
 			logger.Warn("Failed to load addon store", "error", err)
 		}
 
-		if err := manager.EnsureAddonsDir(); err != nil {
-			return fmt.Errorf("failed to ensure addons directory: %w", err)
+		// Without the game there's nothing to manage yet, the TUI says so
+		// instead of creating its folders
+		if manager.GameDirExists() {
+			if err := manager.EnsureAddonsDir(); err != nil {
+				return fmt.Errorf("failed to ensure addons directory: %w", err)
+			}
+		}
+
+		// Without a terminal fall back to the plain list
+		if !useTUI(false) {
+			return addonsListCmd.RunE(addonsListCmd, nil)
 		}
 
 		// Start interactive TUI
-		model := addonsui.NewModel(manager)
+		model := addonsui.NewModel(cmd.Context(), manager, addonsForceCheck)
 		p := tea.NewProgram(model, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -54,14 +91,25 @@ Examples:
 	},
 }
 
+// newAddonManager creates an addon manager for the directories of l
+func newAddonManager(l *launcher.Launcher) *addons.Manager {
+	manager := addons.NewManager(l.GameDir, l.DataDir, getLogger())
+	manager.SetStateDir(l.StateDir)
+	return manager
+}
+
 // getAddonManager returns the shared addon manager, initializing it if needed
 func getAddonManager() (*addons.Manager, error) {
 	if addonManager != nil {
 		return addonManager, nil
 	}
 
+	addons.SetRetries(addonRetries)
+	addons.SetTimeout(addonTimeout)
+	addons.SetAuthToken(addonToken, tokenHost(""))
+	addons.SetBackupGit(addonBackupGit)
 	l := launcher.New(getLogger())
-	addonManager = addons.NewManager(l.GameDir, l.DataDir, getLogger())
+	addonManager = newAddonManager(l)
 
 	if err := addonManager.Load(); err != nil {
 		logger.Warn("Failed to load addon store", "error", err)
@@ -74,6 +122,18 @@ func getAddonManager() (*addons.Manager, error) {
 	return addonManager, nil
 }
 
+// tokenHost returns the host the --token is sent to: --token-host when set,
+// otherwise the host of gitURL, falling back to github.com
+func tokenHost(gitURL string) string {
+	if addonTokenHost != "" {
+		return addonTokenHost
+	}
+	if host := addons.GitURLHost(gitURL); host != "" {
+		return host
+	}
+	return "github.com"
+}
+
 // saveAddonManager saves the addon store
 func saveAddonManager() {
 	if addonManager != nil {
@@ -84,5 +144,11 @@ func saveAddonManager() {
 }
 
 func init() {
+	addonsCmd.PersistentFlags().IntVar(&addonRetries, "retries", addons.DefaultRetries, "Retries for clones and fetches interrupted by network errors")
+	addonsCmd.PersistentFlags().DurationVar(&addonTimeout, "timeout", addons.DefaultTimeout, "Deadline for each clone or fetch attempt (0 for none)")
+	addonsCmd.PersistentFlags().StringVar(&addonToken, "token", "", "Token for private HTTPS repositories (default: GITHUB_TOKEN/GH_TOKEN for github.com)")
+	addonsCmd.PersistentFlags().StringVar(&addonTokenHost, "token-host", "", "Host the --token is sent to (default: the host of the installed URL, otherwise github.com)")
+	addonsCmd.PersistentFlags().BoolVar(&addonBackupGit, "backup-git", false, "Keep .git directories in addon backups (left out by default, repositories can be cloned again)")
+	addonsCmd.Flags().BoolVar(&addonsForceCheck, "force-check", false, "Check every addon for updates, ignoring cached results")
 	rootCmd.AddCommand(addonsCmd)
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var addonsCleanGitCmd = &cobra.Command{
+	Use:   "clean-git [name...]",
+	Short: "Shrink the .git directories of addons",
+	Long: `Reclaim disk space used by the git history of addons.
+
+Full clones and repeated updates leave .git directories holding many packs
+and unreachable objects. This drops reflogs and sample hooks, prunes
+unreachable objects and repacks each repository into a single pack. Addon
+files are not touched.
+
+Shallow clones (the default since installs are shallow) hold no history to
+prune, only their reflogs and sample hooks are dropped.
+
+Without names, every tracked addon with a git repository is compacted.
+Addons from a multi-addon repository share one repository, compacted once.
+
+Examples:
+  turtlectl addons clean-git                # All tracked addons
+  turtlectl addons clean-git pfQuest        # A single addon
+  turtlectl addons clean-git pfQuest pfUI   # Several addons`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		names := args
+		if len(names) == 0 {
+			for _, name := range manager.GetTrackedAddons() {
+				if addons.IsGitRepo(manager.RepoPath(name)) {
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				fmt.Println("No addons with a git repository")
+				return nil
+			}
+		} else {
+			for _, name := range names {
+				if _, err := manager.GetInfo(name); err != nil {
+					return fmt.Errorf("addon not found: %s", name)
+				}
+			}
+		}
+
+		progress.PrintInProgress(fmt.Sprintf("Compacting %d repository(s)...", len(names)))
+
+		var reclaimed int64
+		var failed int
+		for _, result := range manager.CleanGit(cmd.Context(), names) {
+			switch {
+			case result.Error != nil:
+				failed++
+				progress.PrintError(fmt.Sprintf("%s: %v", result.Name, result.Error))
+			case result.Shared != "":
+				progress.PrintDetail(fmt.Sprintf("%s: shares the repository of %s", result.Name, result.Shared))
+			default:
+				reclaimed += result.Reclaimed()
+				progress.PrintSuccess(fmt.Sprintf("%s: reclaimed %s (%s -> %s)", result.Name,
+					progress.FormatBytes(result.Reclaimed()), progress.FormatBytes(result.Before), progress.FormatBytes(result.After)))
+			}
+		}
+		progress.PrintSummary("Reclaimed: %s, Failed: %d", progress.FormatBytes(reclaimed), failed)
+
+		if failed > 0 {
+			return fmt.Errorf("failed to compact %d repository(s)", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsCleanGitCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var addonsDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Disable an addon without deleting it",
+	Long: `Disable an addon by moving it out of Interface/AddOns.
+
+The addon folder is moved to Interface/DisabledAddOns, so the game stops
+loading it while its files and SavedVariables are kept. Disabled addons
+are skipped by update and update checks.
+
+Examples:
+  turtlectl addons disable pfQuest
+  turtlectl addons enable pfQuest   # Turn it back on`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if err := manager.Disable(addonName); err != nil {
+			return err
+		}
+
+		saveAddonManager()
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s disabled", addonName)))
+		return nil
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsDisableCmd)
+}
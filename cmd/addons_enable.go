@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var addonsEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Re-enable a disabled addon",
+	Long: `Re-enable an addon previously disabled with 'turtlectl addons disable'.
+
+The addon folder is moved back to Interface/AddOns.
+
+Examples:
+  turtlectl addons enable pfQuest`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if err := manager.Enable(addonName); err != nil {
+			return err
+		}
+
+		saveAddonManager()
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s enabled", addonName)))
+		return nil
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsEnableCmd)
+}
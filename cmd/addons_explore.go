@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -15,6 +19,9 @@ import (
 	"github.com/bnema/turtlectl/internal/wiki"
 )
 
+// registryURLs overrides the registry sources for explore and search
+var registryURLs []string
+
 var addonsExploreCmd = &cobra.Command{
 	Use:   "explore",
 	Short: "Browse and discover addons from the Turtle WoW wiki",
@@ -22,12 +29,32 @@ var addonsExploreCmd = &cobra.Command{
 
 The addon registry is maintained centrally and cached locally for 24 hours.
 New addons are marked with [NEW] for 7 days after being added to the registry.
+Addons without a commit in 2 years are marked as abandoned, read-only
+repositories as archived: they won't get updates.
+
+--max-age keeps only addons with a commit within the given age (e.g. 90d,
+6m, 1y, or a Go duration like 720h). Addons with an unknown last commit are
+left out when filtering.
+
+Additional or custom registries (e.g. a guild's curated list) can be given
+with --registry or TURTLECTL_REGISTRY_URL (comma-separated). When the same
+addon appears in several registries, the later one wins.
+
+Without a terminal (or with --no-tui) the plain list is printed instead of
+the TUI. The TUI remembers its sort order and filter between sessions.
+
+--format prints each addon with a Go template instead of the table.
+Fields: .Name .URL .Description .Author .Version .Stars .Category .Ref
+.LastCommit .AddedAt .Archived .IsInstalled .Source
 
 Examples:
   turtlectl addons explore              # Interactive TUI
   turtlectl addons explore --refresh    # Force refresh from registry
   turtlectl addons explore --list       # Plain text list
-  turtlectl addons explore --json       # JSON output for scripting`,
+  turtlectl addons explore --json       # JSON output for scripting
+  turtlectl addons explore --format '{{.Name}} {{.URL}}'
+  turtlectl addons explore -l --max-age 1y  # Only addons active in the last year
+  turtlectl addons explore --registry default --registry https://example.com/guild.json`,
 	RunE: runExplore,
 }
 
@@ -37,35 +64,69 @@ func init() {
 	addonsExploreCmd.Flags().BoolP("refresh", "r", false, "Force refresh the registry cache")
 	addonsExploreCmd.Flags().BoolP("list", "l", false, "Output as plain text list (non-interactive)")
 	addonsExploreCmd.Flags().Bool("json", false, "Output as JSON (non-interactive)")
+	addonsExploreCmd.Flags().StringSliceVar(&registryURLs, "registry", nil, "Registry URL to use, repeatable (\"default\" for the built-in registry)")
+	addonsExploreCmd.Flags().String("max-age", "", "Only list addons with a commit within this age, e.g. 90d, 6m, 1y (with --list/--json/--format)")
+	addonsExploreCmd.Flags().String("format", "", "Print each addon with a Go template, e.g. '{{.Name}} {{.URL}}' (non-interactive)")
+	addonsExploreCmd.MarkFlagsMutuallyExclusive("json", "format")
 }
 
 func runExplore(cmd *cobra.Command, args []string) error {
 	refresh, _ := cmd.Flags().GetBool("refresh")
 	listOutput, _ := cmd.Flags().GetBool("list")
 	jsonOutput, _ := cmd.Flags().GetBool("json")
+	maxAgeFlag, _ := cmd.Flags().GetString("max-age")
+	format, _ := cmd.Flags().GetString("format")
+
+	var tmpl *template.Template
+	if format != "" {
+		var err error
+		if tmpl, err = parseFormat(format); err != nil {
+			return err
+		}
+		listOutput = true
+	}
+
+	var maxAge time.Duration
+	if maxAgeFlag != "" {
+		var err error
+		if maxAge, err = parseMaxAge(maxAgeFlag); err != nil {
+			return err
+		}
+		if !listOutput && !jsonOutput && useTUI(false) {
+			return fmt.Errorf("--max-age requires --list, --json or --format")
+		}
+	}
 
 	// Get launcher for paths
 	l := launcher.New(getLogger())
 
 	// Initialize registry
-	registry := wiki.NewRegistry(l.CacheDir, getLogger())
+	registry := newRegistry(l.CacheDir)
 
 	// Non-interactive modes
-	if listOutput || jsonOutput {
-		return runExploreNonInteractive(registry, refresh, jsonOutput)
+	if listOutput || jsonOutput || !useTUI(false) {
+		return runExploreNonInteractive(registry, refresh, jsonOutput, tmpl, maxAge)
 	}
 
 	// Interactive TUI mode
-	return runExploreTUI(registry, refresh, l)
+	return runExploreTUI(cmd.Context(), registry, refresh, l)
 }
 
-// runExploreNonInteractive handles --list and --json output modes
-func runExploreNonInteractive(registry *wiki.Registry, refresh, jsonOutput bool) error {
+// runExploreNonInteractive handles --list, --json and --format output modes
+func runExploreNonInteractive(registry *wiki.Registry, refresh, jsonOutput bool, tmpl *template.Template, maxAge time.Duration) error {
 	addons, err := registry.GetAddons(refresh)
 	if err != nil {
 		return fmt.Errorf("failed to load addons: %w", err)
 	}
 
+	if maxAge > 0 {
+		addons = wiki.FilterByMaxAge(addons, maxAge)
+	}
+
+	if manager, err := getAddonManager(); err == nil {
+		wiki.MarkInstalled(addons, manager.InstalledURLs())
+	}
+
 	// Sort addons
 	wiki.SortAddons(addons)
 
@@ -74,6 +135,9 @@ func runExploreNonInteractive(registry *wiki.Registry, refresh, jsonOutput bool)
 	if jsonOutput {
 		return outputJSON(addons, info)
 	}
+	if tmpl != nil {
+		return printFormatted(os.Stdout, tmpl, addons)
+	}
 
 	return outputTable(addons, info)
 }
@@ -104,11 +168,10 @@ func outputJSON(addons []wiki.WikiAddon, info wiki.RegistryInfo) error {
 
 // outputTable outputs addons as a formatted table
 func outputTable(addons []wiki.WikiAddon, info wiki.RegistryInfo) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-
 	// Header
-	_, _ = fmt.Fprintln(w, "NAME\tAUTHOR\tSTARS\tSTATUS\tDESCRIPTION")
-	_, _ = fmt.Fprintln(w, "----\t------\t-----\t------\t-----------")
+	table := &styles.Table{}
+	table.AddRow("NAME", "AUTHOR", "STARS", "STATUS", "DESCRIPTION")
+	table.AddRow("----", "------", "-----", "------", "-----------")
 
 	// Rows
 	for _, addon := range addons {
@@ -122,12 +185,20 @@ func outputTable(addons []wiki.WikiAddon, info wiki.RegistryInfo) error {
 			}
 			status += "installed"
 		}
+		if addon.Archived {
+			if status != "" {
+				status += ", "
+			}
+			status += "archived"
+		} else if addon.IsAbandoned() {
+			if status != "" {
+				status += ", "
+			}
+			status += "abandoned"
+		}
 
 		// Truncate description
-		desc := addon.Description
-		if len(desc) > 50 {
-			desc = desc[:47] + "..."
-		}
+		desc := styles.Truncate(addon.Description, 50)
 
 		// Format stars
 		stars := ""
@@ -139,16 +210,10 @@ func outputTable(addons []wiki.WikiAddon, info wiki.RegistryInfo) error {
 			}
 		}
 
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			addon.Name,
-			addon.Author,
-			stars,
-			status,
-			desc,
-		)
+		table.AddRow(addon.Name, addon.Author, stars, status, desc)
 	}
 
-	_ = w.Flush()
+	_ = table.Render(os.Stdout)
 
 	// Summary
 	fmt.Println()
@@ -167,8 +232,45 @@ func outputTable(addons []wiki.WikiAddon, info wiki.RegistryInfo) error {
 	return nil
 }
 
+// newRegistry creates a registry from --registry, falling back to the environment/default
+func newRegistry(cacheDir string) *wiki.Registry {
+	var sources []string
+	for _, url := range registryURLs {
+		if url == "default" {
+			url = wiki.RegistryURL
+		}
+		sources = append(sources, url)
+	}
+	return wiki.NewRegistry(cacheDir, getLogger(), sources...)
+}
+
+// parseMaxAge parses an age like "90d", "2w", "6m", "1y", or a Go duration
+func parseMaxAge(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'm': 30 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) > 1 {
+		if unit, ok := units[value[len(value)-1]]; ok {
+			if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n > 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --max-age %q (use e.g. 90d, 6m, 1y)", value)
+	}
+	return d, nil
+}
+
 // runExploreTUI runs the interactive TUI
-func runExploreTUI(registry *wiki.Registry, refresh bool, l *launcher.Launcher) error {
+func runExploreTUI(ctx context.Context, registry *wiki.Registry, refresh bool, l *launcher.Launcher) error {
 	// Get addon manager for install functionality
 	manager, err := getAddonManager()
 	if err != nil {
@@ -176,7 +278,7 @@ func runExploreTUI(registry *wiki.Registry, refresh bool, l *launcher.Launcher)
 	}
 
 	// Create and run TUI
-	model := addonsui.NewExploreModel(manager, registry, refresh)
+	model := addonsui.NewExploreModel(ctx, manager, registry, l.CacheDir, refresh)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
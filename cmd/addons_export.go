@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+// defaultManifestFile is used by export/import when --file is not given
+const defaultManifestFile = "turtlectl-addons.json"
+
+var exportFile string
+
+var addonsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export installed addons to a manifest",
+	Long: `Export installed addons to a portable JSON manifest.
+
+The manifest lists each addon's name, git URL, and pinned ref so the
+same set can be recreated with 'turtlectl addons import'. Addons without
+a git URL are listed as unmanaged and will not be reinstalled.
+
+Examples:
+  turtlectl addons export                          # Write turtlectl-addons.json
+  turtlectl addons export --file ~/addons.json     # Custom path
+  turtlectl addons export --file - > addons.json   # Write to stdout`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		manifest, err := manager.Export()
+		if err != nil {
+			return fmt.Errorf("failed to export addons: %w", err)
+		}
+
+		if exportFile == "-" {
+			return addons.WriteManifest(os.Stdout, manifest)
+		}
+
+		file, err := os.Create(exportFile)
+		if err != nil {
+			return fmt.Errorf("failed to create manifest: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+
+		if err := addons.WriteManifest(file, manifest); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+
+		unmanaged := 0
+		for _, entry := range manifest.Addons {
+			if entry.Unmanaged {
+				unmanaged++
+			}
+		}
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Exported %d addon(s) to %s", len(manifest.Addons), exportFile)))
+		if unmanaged > 0 {
+			fmt.Println(styles.FormatWarning(fmt.Sprintf("%d unmanaged addon(s) have no git URL and won't be reinstalled", unmanaged)))
+		}
+		return nil
+	},
+}
+
+func init() {
+	addonsExportCmd.Flags().StringVar(&exportFile, "file", defaultManifestFile, "Manifest path (- for stdout)")
+	addonsCmd.AddCommand(addonsExportCmd)
+}
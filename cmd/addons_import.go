@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/offline"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var importFile string
+
+var addonsImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Install addons from a manifest",
+	Long: `Install every addon listed in a manifest created by 'turtlectl addons export'.
+
+Addons that are already installed are skipped. Unmanaged entries (no git
+URL) are reported but not installed. Pinned refs are preserved.
+
+Examples:
+  turtlectl addons import                              # Read turtlectl-addons.json
+  turtlectl addons import --file ~/addons.json         # Custom path
+  cat addons.json | turtlectl addons import --file -   # Read from stdin`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Manifest entries are all cloned from git
+		if offline.Enabled() {
+			return offline.ErrOffline
+		}
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader = os.Stdin
+		if importFile != "-" {
+			file, err := os.Open(importFile)
+			if err != nil {
+				return fmt.Errorf("failed to open manifest: %w", err)
+			}
+			defer func() { _ = file.Close() }()
+			r = file
+		}
+
+		manifest, err := addons.ReadManifest(r)
+		if err != nil {
+			return err
+		}
+
+		result := manager.Import(cmd.Context(), manifest, func(entry addons.ManifestEntry) {
+			progress.PrintInProgress(fmt.Sprintf("Installing %s...", entry.Name))
+		})
+
+		saveAddonManager()
+
+		fmt.Println()
+		for _, name := range result.Installed {
+			progress.PrintSuccess(fmt.Sprintf("Installed %s", name))
+		}
+		for _, name := range result.Skipped {
+			progress.PrintPending(fmt.Sprintf("%s already installed", name))
+		}
+		for _, name := range result.Unmanaged {
+			progress.PrintWarning(fmt.Sprintf("%s is unmanaged, install it manually", name))
+		}
+		for _, e := range result.Errors {
+			progress.PrintError(e)
+		}
+
+		fmt.Println()
+		summary := fmt.Sprintf("Installed %d, skipped %d, unmanaged %d, failed %d",
+			len(result.Installed), len(result.Skipped), len(result.Unmanaged), len(result.Failed))
+		if len(result.Failed) > 0 {
+			fmt.Println(styles.FormatWarning(summary))
+			return fmt.Errorf("failed to install: %s", strings.Join(result.Failed, ", "))
+		}
+		fmt.Println(styles.FormatSuccess(summary))
+		return nil
+	},
+}
+
+func init() {
+	addonsImportCmd.Flags().StringVar(&importFile, "file", defaultManifestFile, "Manifest path (- for stdin)")
+	addonsCmd.AddCommand(addonsImportCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
 	"github.com/bnema/turtlectl/internal/ui/styles"
 )
 
@@ -33,7 +34,9 @@ Examples:
 			return fmt.Errorf("addon not found: %s", addonName)
 		}
 
+		manager.LoadSizes([]*addons.Addon{addon})
 		printAddonInfo(addon)
+		printDependencies(addon.Dependencies, manager.MissingDependencies(addon.Dependencies))
 
 		// Check for backups
 		backups, err := manager.GetBackupManager().ListBackups(addonName)
@@ -41,9 +44,9 @@ Examples:
 			fmt.Printf("\nBackups: %d available (latest: %s)\n", len(backups), backups[0])
 		}
 
-		// Check git status
-		if addons.IsGitRepo(addon.Path) {
-			if commit, err := addons.GetCurrentCommit(addon.Path); err == nil {
+		// Check git status (pack members share their repository's clone)
+		if repoPath := manager.RepoPath(addonName); addons.IsGitRepo(repoPath) {
+			if commit, err := addons.GetCurrentCommit(repoPath); err == nil {
 				fmt.Printf("Commit:    %s\n", commit)
 			}
 		}
@@ -62,6 +65,11 @@ func printAddonInfo(addon *addons.Addon) {
 
 	// Basic info
 	printField("Path", addon.Path)
+	if addon.GitSize > 0 {
+		printField("Size", fmt.Sprintf("%s (+%s git history)", progress.FormatBytes(addon.Size), progress.FormatBytes(addon.GitSize)))
+	} else {
+		printField("Size", progress.FormatBytes(addon.Size))
+	}
 
 	if addon.Version != "" {
 		printField("Version", addon.Version)
@@ -75,12 +83,39 @@ func printAddonInfo(addon *addons.Addon) {
 		printField("Notes", addon.Notes)
 	}
 
+	if addon.Category != "" {
+		printField("Category", addon.Category)
+	}
+
+	if addon.Website != "" {
+		printField("Website", addon.Website)
+	}
+
+	if addon.Interface != "" {
+		printField("Interface", addon.Interface)
+	}
+	if addon.InterfaceWarning != "" {
+		fmt.Println(styles.FormatWarning(addon.InterfaceWarning))
+	}
+
 	// Git/tracking info
 	if addon.GitURL != "" {
 		printField("Git URL", addon.GitURL)
-		fmt.Printf("Status:    %s\n", styles.FormatAddonStatus(true))
-	} else {
-		fmt.Printf("Status:    %s\n", styles.FormatAddonStatus(false))
+		if addon.Ref != "" {
+			printField("Ref", addon.Ref)
+		}
+		if addon.Pack != "" {
+			printField("Pack", addon.Pack)
+		}
+		if addon.PinnedCommit != "" {
+			printField("Pinned", addons.ShortCommit(addon.PinnedCommit)+" (unpin to update)")
+		}
+	}
+	switch {
+	case addon.Disabled:
+		fmt.Printf("Status:    %s\n", styles.FormatAddonStatusEx(styles.AddonStatusDisabled))
+	default:
+		fmt.Printf("Status:    %s\n", styles.FormatAddonStatus(addon.GitURL != ""))
 	}
 
 	// Timestamps
@@ -93,6 +128,27 @@ func printAddonInfo(addon *addons.Addon) {
 	}
 }
 
+// printDependencies lists declared dependencies, flagging the ones not installed
+func printDependencies(deps, missing []string) {
+	if len(deps) == 0 {
+		return
+	}
+
+	isMissing := make(map[string]bool, len(missing))
+	for _, dep := range missing {
+		isMissing[dep] = true
+	}
+
+	fmt.Printf("\nDependencies:\n")
+	for _, dep := range deps {
+		if isMissing[dep] {
+			fmt.Printf("  %s %s\n", dep, styles.ErrorText.Render("(missing)"))
+		} else {
+			fmt.Printf("  %s\n", dep)
+		}
+	}
+}
+
 func printField(label, value string) {
 	fmt.Printf("%-10s %s\n", label+":", value)
 }
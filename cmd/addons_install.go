@@ -1,26 +1,71 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/addons"
 	uiaddons "github.com/bnema/turtlectl/internal/ui/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var (
+	installFull   bool
+	installRename bool
+	installQuiet  bool
+	installOnly   []string
 )
 
 var addonsInstallCmd = &cobra.Command{
-	Use:   "install <git-url>",
-	Short: "Install an addon from a git repository",
-	Long: `Install an addon from a git repository URL.
+	Use:   "install <git-url>[@ref] | <path>",
+	Short: "Install an addon from a git repository or local source",
+	Long: `Install an addon from a git repository URL, a local folder, or a zip file.
 
 The addon will be cloned to the Interface/AddOns directory.
 The folder name will be derived from the .toc file if present.
 
+Append @<ref> to the URL to pin a branch, tag, or commit. Pinned addons
+are updated to that ref instead of the remote default branch.
+
+Private repositories can be installed over SSH (git@ or ssh:// URLs, using
+your SSH agent or default key) or over HTTPS with a token from --token,
+GITHUB_TOKEN, or GH_TOKEN. Tokens are never stored with the addon.
+
+If a different repository already uses the addon's folder name, the
+install fails with a conflict. Use --rename to install into a folder
+suffixed with the repo owner. Note the game only loads folders named
+after their .toc file, so the renamed copy is mainly for side-by-side use.
+
+Repositories are cloned shallow (latest commit only) to save bandwidth.
+Use --full to clone the complete history.
+
+Repositories holding several addon folders (each with its own .toc, like
+UI packs) are cloned once to Interface/AddOnPacks and every addon folder
+is copied into Interface/AddOns. Use --only to pick some of them. The
+addons are tracked separately but update together.
+
+Local folders are copied and zip files are extracted (a single top-level
+wrapper directory is stripped). Local addons are untracked and can't be
+updated automatically.
+
+Without a terminal or with --quiet, plain lines are printed instead of the
+progress TUI, including periodic clone progress such as
+"Receiving objects: 60%".
+
 Examples:
   turtlectl addons install https://github.com/shagu/pfQuest
-  turtlectl addons install https://github.com/shagu/ShaguTweaks.git`,
+  turtlectl addons install https://github.com/shagu/ShaguTweaks.git
+  turtlectl addons install https://github.com/shagu/pfQuest@v4.0.0
+  turtlectl addons install https://github.com/shagu/pfQuest/tree/master  # Web URLs work too
+  turtlectl addons install --full https://github.com/shagu/pfQuest
+  turtlectl addons install --only AddonA,AddonB https://github.com/user/ui-pack
+  turtlectl addons install git@github.com:guild/PrivateAddon.git
+  turtlectl addons install ./MyAddon
+  turtlectl addons install ~/Downloads/MyAddon.zip`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		gitURL := args[0]
@@ -30,16 +75,28 @@ Examples:
 			return err
 		}
 
-		// Validate URL first
-		if err := addons.ValidateGitURL(gitURL); err != nil {
-			return fmt.Errorf("invalid URL: %w", err)
+		// Extract addon name for display, validating git URLs first
+		var addonName string
+		if addons.IsLocalSource(gitURL) {
+			addonName = addons.LocalSourceName(gitURL)
+		} else {
+			if err := addons.ValidateGitURL(gitURL); err != nil {
+				return fmt.Errorf("invalid URL: %w", err)
+			}
+			if gitURL, err = addons.NormalizeRepoURL(gitURL); err != nil {
+				return err
+			}
+			addonName = addons.ExtractRepoName(gitURL)
+			addons.SetAuthToken(addonToken, tokenHost(gitURL))
 		}
 
-		// Extract addon name for display
-		addonName := addons.ExtractRepoName(gitURL)
+		opts := addons.InstallOptions{Full: installFull, Rename: installRename, Only: installOnly}
+		if !useTUI(installQuiet) {
+			return installAddonPlain(cmd.Context(), manager, gitURL, addonName, opts)
+		}
 
 		// Run multi-step progress TUI
-		m := uiaddons.NewInstallModel(manager, gitURL, addonName)
+		m := uiaddons.NewInstallModel(cmd.Context(), manager, gitURL, addonName, opts)
 
 		p := tea.NewProgram(m)
 		finalModel, err := p.Run()
@@ -57,6 +114,32 @@ Examples:
 	},
 }
 
+// installAddonPlain installs an addon with line-based output
+func installAddonPlain(ctx context.Context, manager *addons.Manager, source, name string, opts addons.InstallOptions) error {
+	progress.PrintInProgress(fmt.Sprintf("Installing %s...", name))
+	result, err := manager.InstallWithOptions(ctx, source, opts, progress.NewLineProgressWriter())
+	if err != nil {
+		return err
+	}
+	saveAddonManager()
+
+	progress.PrintSuccess("Installed " + result.Title)
+	if len(result.Folders) > 1 {
+		progress.PrintDetail("Addons: " + strings.Join(result.Folders, ", "))
+	}
+	if len(result.MissingDependencies) > 0 {
+		progress.PrintWarning("Missing dependencies: " + strings.Join(result.MissingDependencies, ", "))
+	}
+	if result.InterfaceWarning != "" {
+		progress.PrintWarning(result.InterfaceWarning)
+	}
+	return nil
+}
+
 func init() {
+	addonsInstallCmd.Flags().BoolVarP(&installQuiet, "quiet", "q", false, "Plain line output instead of the progress TUI (automatic without a terminal)")
+	addonsInstallCmd.Flags().BoolVar(&installRename, "rename", false, "Install into a suffixed folder if another repo already uses the name")
+	addonsInstallCmd.Flags().BoolVar(&installFull, "full", false, "Clone the complete git history instead of a shallow clone")
+	addonsInstallCmd.Flags().StringSliceVar(&installOnly, "only", nil, "Addon folders to install from a multi-addon repository (default: all)")
 	addonsCmd.AddCommand(addonsInstallCmd)
 }
@@ -3,19 +3,48 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
 	"github.com/bnema/turtlectl/internal/ui/styles"
 )
 
+var (
+	listSize   bool
+	listFormat string
+)
+
 var addonsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List installed addons",
-	Long:  `List all installed addons in the Interface/AddOns directory.`,
+	Long: `List all installed addons in the Interface/AddOns directory.
+
+Use --size to show the disk space each addon uses. Git history (.git) is
+shown in its own column since full clones can be much larger than the
+addon itself.
+
+Use --format to print each addon with a Go template instead of the table.
+Fields: .Name .Title .Version .Author .Notes .Dependencies .Interface
+.Website .Category .GitURL .Ref .Pack .PinnedCommit .Path .InstalledAt
+.UpdatedAt .InterfaceWarning .Disabled, plus .Size and .GitSize (in bytes)
+with --size.
+
+Examples:
+  turtlectl addons list
+  turtlectl addons list --size
+  turtlectl addons list --format '{{.Name}} {{.GitURL}}'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var tmpl *template.Template
+		if listFormat != "" {
+			var err error
+			if tmpl, err = parseFormat(listFormat); err != nil {
+				return err
+			}
+		}
+
 		manager, err := getAddonManager()
 		if err != nil {
 			return err
@@ -26,21 +55,26 @@ var addonsListCmd = &cobra.Command{
 			return fmt.Errorf("failed to list addons: %w", err)
 		}
 
+		if tmpl != nil {
+			if listSize {
+				manager.LoadSizes(installedAddons)
+			}
+			return printFormatted(os.Stdout, tmpl, installedAddons)
+		}
+
 		if len(installedAddons) == 0 {
 			fmt.Println("No addons installed")
 			fmt.Println("\nInstall addons with: turtlectl addons install <git-url>")
 			return nil
 		}
 
-		// Use tabwriter for aligned output
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-			styles.Title.Render("NAME"),
-			styles.Title.Render("VERSION"),
-			styles.Title.Render("AUTHOR"),
-			styles.Title.Render("STATUS"),
-		)
+		headers := []string{"NAME", "VERSION", "AUTHOR", "STATUS"}
+		var totalSize, totalGitSize int64
+		if listSize {
+			manager.LoadSizes(installedAddons)
+			headers = append(headers, "SIZE", "GIT")
+		}
+		table := styles.NewTable(headers...)
 
 		for _, addon := range installedAddons {
 			name := addon.Name
@@ -53,9 +87,11 @@ var addonsListCmd = &cobra.Command{
 				author = "-"
 			}
 
-			// Determine status: default > tracked > untracked
+			// Determine status: disabled > default > tracked > untracked
 			var status string
-			if addons.IsDefaultAddon(addon.Name) {
+			if addon.Disabled {
+				status = styles.FormatAddonStatusEx(styles.AddonStatusDisabled)
+			} else if addons.IsDefaultAddon(addon.Name) {
 				status = styles.FormatAddonStatusEx(styles.AddonStatusDefault)
 			} else if addon.GitURL != "" {
 				status = styles.FormatAddonStatusEx(styles.AddonStatusTracked)
@@ -63,12 +99,25 @@ var addonsListCmd = &cobra.Command{
 				status = styles.FormatAddonStatusEx(styles.AddonStatusUntracked)
 			}
 
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, version, author, status)
+			row := []string{name, version, author, status}
+			if listSize {
+				gitSize := "-"
+				if addon.GitSize > 0 {
+					gitSize = progress.FormatBytes(addon.GitSize)
+				}
+				row = append(row, progress.FormatBytes(addon.Size), gitSize)
+				totalSize += addon.Size
+				totalGitSize += addon.GitSize
+			}
+			table.AddRow(row...)
 		}
 
-		_ = w.Flush()
+		_ = table.Render(os.Stdout)
 
 		fmt.Printf("\n%d addon(s) installed\n", len(installedAddons))
+		if listSize {
+			fmt.Printf("Disk usage: %s (+%s git history)\n", progress.FormatBytes(totalSize), progress.FormatBytes(totalGitSize))
+		}
 		fmt.Printf("Addons directory: %s\n", manager.GetAddonsDir())
 
 		return nil
@@ -76,5 +125,7 @@ var addonsListCmd = &cobra.Command{
 }
 
 func init() {
+	addonsListCmd.Flags().BoolVar(&listSize, "size", false, "Show disk usage per addon")
+	addonsListCmd.Flags().StringVar(&listFormat, "format", "", "Print each addon with a Go template, e.g. '{{.Name}} {{.GitURL}}'")
 	addonsCmd.AddCommand(addonsListCmd)
 }
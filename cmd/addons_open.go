@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var (
+	openPrint bool
+	openDir   bool
+)
+
+var addonsOpenCmd = &cobra.Command{
+	Use:   "open [name]",
+	Short: "Open an addon folder in the file manager",
+	Long: `Open the folder of an installed addon with xdg-open, to edit its config
+or drop in extra files. Disabled addons open in their disabled location.
+
+With --dir, the whole AddOns directory is opened instead. With --print, or
+without a graphical session, the path is printed rather than opened.
+
+Examples:
+  turtlectl addons open pfQuest            # Open pfQuest's folder
+  turtlectl addons open --dir              # Open Interface/AddOns
+  cd "$(turtlectl addons open pfQuest -p)" # Jump to the folder in a shell`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if openDir {
+			if len(args) > 0 {
+				return fmt.Errorf("--dir opens the AddOns directory and takes no addon name")
+			}
+			return nil
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		path := manager.GetAddonsDir()
+		if !openDir {
+			addon, err := manager.GetInfo(args[0])
+			if err != nil {
+				return err
+			}
+			path = addon.Path
+		}
+
+		if openPrint {
+			fmt.Println(path)
+			return nil
+		}
+		return openFolder(path)
+	},
+}
+
+// openFolder opens path in the file manager with xdg-open
+// Without a graphical session or xdg-open the path is printed instead
+func openFolder(path string) error {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		progress.PrintWarning("No graphical session, open it yourself:")
+		fmt.Println(path)
+		return nil
+	}
+	if _, err := exec.LookPath("xdg-open"); err != nil {
+		progress.PrintWarning("xdg-open not found, open it yourself:")
+		fmt.Println(path)
+		return nil
+	}
+
+	cmd := exec.Command("xdg-open", path)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// Don't wait for the file manager, xdg-open may stay attached to it
+	_ = cmd.Process.Release()
+	progress.PrintSuccess(fmt.Sprintf("Opened %s", path))
+	return nil
+}
+
+func init() {
+	addonsOpenCmd.Flags().BoolVarP(&openPrint, "print", "p", false, "Print the path instead of opening it")
+	addonsOpenCmd.Flags().BoolVar(&openDir, "dir", false, "Open the whole AddOns directory")
+	addonsOpenCmd.ValidArgsFunction = completeAddonNames(nil)
+	addonsCmd.AddCommand(addonsOpenCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var addonsPinCmd = &cobra.Command{
+	Use:   "pin <name>",
+	Short: "Freeze an addon at its current commit",
+	Long: `Pin an addon to the commit it is currently at.
+
+Pinned addons are skipped when updating all addons and by update checks, and
+updating one by name fails until it is unpinned. Useful to hold a known-good
+version while upstream is broken. Addons from a multi-addon pack update together
+and can't be pinned one by one.
+
+Examples:
+  turtlectl addons pin pfQuest
+  turtlectl addons unpin pfQuest   # Let it update again`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		commit, err := manager.Pin(addonName)
+		if err != nil {
+			return err
+		}
+
+		saveAddonManager()
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s pinned at %s", addonName, addons.ShortCommit(commit))))
+		return nil
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsPinCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var (
+	reinstallForce     bool
+	reinstallRestoreSV bool
+)
+
+var addonsReinstallCmd = &cobra.Command{
+	Use:   "reinstall <name>",
+	Short: "Replace an addon with a fresh clone",
+	Long: `Replace an addon with a fresh clone of its git repository.
+
+Use this for installs that are corrupted or have local changes that block
+updates (see 'turtlectl addons repair'). The addon is cloned again from its
+stored git URL, or from the origin remote of its folder when none is stored.
+Addons installed from a local source or without a remote can't be reinstalled.
+
+SavedVariables are backed up first so no settings are lost. Use --restore-sv
+to copy the backup back into WTF afterwards, e.g. when the game reset the
+settings while the addon was broken.
+
+Addons from a multi-addon repository are reinstalled together with the other
+addons of that repository.
+
+Examples:
+  turtlectl addons reinstall pfQuest
+  turtlectl addons reinstall pfQuest --restore-sv
+  turtlectl addons reinstall pfQuest --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		addon, err := manager.GetInfo(addonName)
+		if err != nil {
+			return fmt.Errorf("addon not found: %s", addonName)
+		}
+
+		if needsConfirm(reinstallForce) {
+			fmt.Printf("Reinstall addon %s?\n", styles.Highlighted.Render(addon.Name))
+			fmt.Println("  Local changes in the addon folder will be lost.")
+			if addon.Pack != "" {
+				fmt.Printf("  Every addon from %s will be reinstalled.\n", addon.Pack)
+			}
+
+			if !confirm() {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		progress.PrintInProgress(fmt.Sprintf("Reinstalling %s...", addonName))
+		result, err := manager.Reinstall(cmd.Context(), addonName, reinstallRestoreSV, nil)
+		if errors.Is(err, addons.ErrNoSourceURL) {
+			return fmt.Errorf("%w\nRemove it and install it again from its repository or source", err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reinstall addon: %w", err)
+		}
+
+		saveAddonManager()
+
+		progress.PrintSuccess(fmt.Sprintf("Reinstalled %s from %s", result.Install.Title, result.GitURL))
+		if len(result.Install.Folders) > 1 {
+			progress.PrintDetail("Addons: " + strings.Join(result.Install.Folders, ", "))
+		}
+		switch {
+		case result.SavedVariablesRestored:
+			progress.PrintDetail("SavedVariables restored from " + result.SavedVariablesBackup)
+		case result.SavedVariablesBackup != "":
+			progress.PrintDetail("SavedVariables backed up to " + result.SavedVariablesBackup)
+		}
+		if len(result.Install.MissingDependencies) > 0 {
+			progress.PrintWarning("Missing dependencies: " + strings.Join(result.Install.MissingDependencies, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	addonsReinstallCmd.Flags().BoolVarP(&reinstallForce, "force", "f", false, "Skip confirmation prompt")
+	addonsReinstallCmd.Flags().BoolVar(&reinstallRestoreSV, "restore-sv", false, "Copy the SavedVariables backup back into WTF after reinstalling")
+	addonsCmd.AddCommand(addonsReinstallCmd)
+}
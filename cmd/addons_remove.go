@@ -1,10 +1,7 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +11,7 @@ import (
 var (
 	removeForce    bool
 	removeNoBackup bool
+	removeBackupSV bool
 )
 
 var addonsRemoveCmd = &cobra.Command{
@@ -24,6 +22,7 @@ var addonsRemoveCmd = &cobra.Command{
 
 By default, a backup is created before removal.
 Use --no-backup to skip backup creation.
+SavedVariables are backed up too unless --backup-sv=false is passed.
 Use --force to skip confirmation prompt.
 
 Examples:
@@ -46,7 +45,7 @@ Examples:
 		}
 
 		// Confirm removal
-		if !removeForce {
+		if needsConfirm(removeForce) {
 			fmt.Printf("Remove addon %s?\n", styles.Highlighted.Render(addon.Name))
 			if addon.Title != "" && addon.Title != addon.Name {
 				fmt.Printf("  Title: %s\n", addon.Title)
@@ -60,12 +59,7 @@ Examples:
 				fmt.Println(styles.FormatWarning("No backup will be created!"))
 			}
 
-			fmt.Print("\nConfirm? [y/N] ")
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(strings.ToLower(response))
-
-			if response != "y" && response != "yes" {
+			if !confirm() {
 				fmt.Println("Cancelled.")
 				return nil
 			}
@@ -73,17 +67,22 @@ Examples:
 
 		// Remove addon
 		createBackup := !removeNoBackup
-		if err := manager.Remove(addonName, createBackup); err != nil {
+		manager.SetBackupSavedVariables(removeBackupSV)
+		result, err := manager.Remove(addonName, createBackup)
+		if err != nil {
 			return fmt.Errorf("failed to remove addon: %w", err)
 		}
 
 		saveAddonManager()
 
-		if createBackup {
+		if result.BackupPath != "" {
 			fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s removed (backup created)", addonName)))
 		} else {
 			fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s removed", addonName)))
 		}
+		if result.SavedVariablesBackup != "" {
+			fmt.Printf("SavedVariables backed up to %s\n", result.SavedVariablesBackup)
+		}
 
 		return nil
 	},
@@ -92,5 +91,6 @@ Examples:
 func init() {
 	addonsRemoveCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "Skip confirmation prompt")
 	addonsRemoveCmd.Flags().BoolVar(&removeNoBackup, "no-backup", false, "Skip backup creation")
+	addonsRemoveCmd.Flags().BoolVar(&removeBackupSV, "backup-sv", true, "Back up SavedVariables before removing")
 	addonsCmd.AddCommand(addonsRemoveCmd)
 }
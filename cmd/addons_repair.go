@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
+	"github.com/bnema/turtlectl/internal/addons"
 	"github.com/bnema/turtlectl/internal/ui/progress"
 	"github.com/bnema/turtlectl/internal/ui/styles"
 )
 
+var (
+	repairFix   bool
+	repairForce bool
+)
+
 var addonsRepairCmd = &cobra.Command{
 	Use:   "repair",
 	Short: "Repair addon database and fix issues",
@@ -19,10 +26,18 @@ This command will:
 - Detect untracked addons (folder exists but no metadata)
 - Verify git repository integrity
 - Check if folder names match .toc files
+- Detect folders claimed by more than one repository
 - Auto-track addons with git remotes
 
+With --fix, corrupted git repositories are re-cloned from their stored git
+URL (or the folder's origin remote) after asking for confirmation. Each
+addon folder and its SavedVariables are backed up first, see
+'turtlectl addons restore'. Use --force to skip the confirmation.
+
 Examples:
-  turtlectl addons repair`,
+  turtlectl addons repair
+  turtlectl addons repair --fix
+  turtlectl addons repair --fix --force`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		manager, err := getAddonManager()
 		if err != nil {
@@ -68,7 +83,10 @@ Examples:
 		if len(result.CorruptedRepos) > 0 {
 			fmt.Println(styles.ErrorText.Render("Corrupted git repositories:"))
 			for _, name := range result.CorruptedRepos {
-				fmt.Printf("  - %s (re-install recommended)\n", name)
+				fmt.Printf("  - %s\n", name)
+			}
+			if !repairFix {
+				fmt.Println(styles.MutedText.Render("  Run 'turtlectl addons repair --fix' to re-clone them, SavedVariables are kept"))
 			}
 			fmt.Println()
 		}
@@ -82,14 +100,65 @@ Examples:
 			fmt.Println()
 		}
 
+		// Folder conflicts
+		if len(result.FolderConflicts) > 0 {
+			fmt.Println(styles.ErrorText.Render("Folder conflicts between repositories:"))
+			for _, info := range result.FolderConflicts {
+				fmt.Printf("  - %s\n", info)
+			}
+			fmt.Println()
+		}
+
 		saveAddonManager()
 
+		if repairFix && len(result.CorruptedRepos) > 0 {
+			if err := fixCorruptedRepos(cmd.Context(), manager, result); err != nil {
+				return err
+			}
+		}
+
 		fmt.Println(styles.FormatSuccess("Repair complete"))
 
 		return nil
 	},
 }
 
+// fixCorruptedRepos confirms and re-clones the corrupted repositories found by repair
+func fixCorruptedRepos(ctx context.Context, manager *addons.Manager, result *addons.RepairResult) error {
+	if needsConfirm(repairForce) {
+		fmt.Printf("Re-clone corrupted repositories (%d)?\n", len(result.CorruptedRepos))
+		fmt.Println("  Addon folders and SavedVariables will be backed up first.")
+
+		if !confirm() {
+			fmt.Println("Skipped re-cloning.")
+			fmt.Println()
+			return nil
+		}
+	}
+
+	progress.PrintInProgress("Re-cloning corrupted repositories...")
+	failed := 0
+	for _, fix := range manager.FixCorruptedRepos(ctx, result) {
+		if fix.Err != nil {
+			failed++
+			progress.PrintError(fmt.Sprintf("%s: %v", fix.Repo, fix.Err))
+			continue
+		}
+		progress.PrintSuccess("Re-cloned " + fix.Repo)
+		for _, path := range fix.Backups {
+			progress.PrintDetail("Backup: " + path)
+		}
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("failed to fix %d of %d corrupted repositories", failed, len(result.CorruptedRepos))
+	}
+	return nil
+}
+
 func init() {
+	addonsRepairCmd.Flags().BoolVar(&repairFix, "fix", false, "Re-clone corrupted git repositories (backed up first)")
+	addonsRepairCmd.Flags().BoolVarP(&repairForce, "force", "f", false, "Skip confirmation prompt for --fix")
 	addonsCmd.AddCommand(addonsRepairCmd)
 }
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var (
+	restoreLatest bool
+	restoreForce  bool
+)
+
+var addonsRestoreCmd = &cobra.Command{
+	Use:   "restore <name> [timestamp]",
+	Short: "Restore an addon from a backup",
+	Long: `Restore an addon from one of its backups.
+
+Backups are created automatically when an addon is removed or re-cloned.
+Without a timestamp, the available backups are listed and you are asked
+to pick one. Use --latest to restore the most recent backup directly.
+
+An installed addon that is newer than the selected backup is not
+overwritten unless --force is passed.
+
+Backups leave out .git unless taken with --backup-git. A git addon restored
+from such a backup keeps its source URL and is re-cloned on its next update.
+
+Examples:
+  turtlectl addons restore pfQuest                    # Pick from a list
+  turtlectl addons restore pfQuest --latest           # Most recent backup
+  turtlectl addons restore pfQuest 20240101-120000    # Specific backup
+  turtlectl addons restore pfQuest --latest --force   # Overwrite newer install`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		backups, err := manager.GetBackupManager().ListBackups(addonName)
+		if err != nil {
+			return fmt.Errorf("failed to list backups: %w", err)
+		}
+		if len(backups) == 0 {
+			return fmt.Errorf("no backups found for %s", addonName)
+		}
+
+		var timestamp string
+		switch {
+		case len(args) == 2:
+			timestamp = args[1]
+		case restoreLatest:
+			timestamp = backups[0]
+		default:
+			timestamp, err = promptBackupChoice(addonName, backups)
+			if err != nil {
+				return err
+			}
+			if timestamp == "" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		result, err := manager.Restore(addonName, timestamp, restoreForce)
+		if errors.Is(err, addons.ErrNewerInstall) {
+			return fmt.Errorf("%w\nUse --force to overwrite it", err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore addon: %w", err)
+		}
+
+		saveAddonManager()
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s restored from backup %s", result.Name, result.Timestamp)))
+		return nil
+	},
+}
+
+// promptBackupChoice lists backups and asks the user to pick one
+// Returns an empty string if the user cancels
+func promptBackupChoice(addonName string, backups []string) (string, error) {
+	fmt.Printf("Backups for %s:\n", styles.Highlighted.Render(addonName))
+	for i, backup := range backups {
+		label := backup
+		if i == 0 {
+			label += styles.MutedText.Render(" (latest)")
+		}
+		fmt.Printf("  %d) %s\n", i+1, label)
+	}
+
+	fmt.Printf("\nSelect backup [1-%d, empty to cancel]: ", len(backups))
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(response)
+
+	if response == "" {
+		return "", nil
+	}
+
+	choice, err := strconv.Atoi(response)
+	if err != nil || choice < 1 || choice > len(backups) {
+		return "", fmt.Errorf("invalid selection: %s", response)
+	}
+
+	return backups[choice-1], nil
+}
+
+func init() {
+	addonsRestoreCmd.Flags().BoolVar(&restoreLatest, "latest", false, "Restore the most recent backup")
+	addonsRestoreCmd.Flags().BoolVarP(&restoreForce, "force", "f", false, "Overwrite an installed addon newer than the backup")
+	addonsCmd.AddCommand(addonsRestoreCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+	"github.com/bnema/turtlectl/internal/wiki"
+)
+
+var (
+	searchJSON     bool
+	searchCategory string
+	searchRefresh  bool
+)
+
+var addonsSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the addon registry",
+	Long: `Search the Turtle WoW addon registry by name, author, or description.
+
+Matching is case-insensitive. Addons whose name starts with the query are
+listed first, followed by name, author, and description matches, then
+fuzzy name matches (letters in order, e.g. "pfq" finds pfQuest).
+
+Examples:
+  turtlectl addons search quest              # Search everything
+  turtlectl addons search bag --category B   # Only the "B" wiki section
+  turtlectl addons search pfq --json         # JSON output for scripting`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := launcher.New(getLogger())
+		registry := newRegistry(l.CacheDir)
+
+		all, err := registry.GetAddons(searchRefresh)
+		if err != nil {
+			return fmt.Errorf("failed to load addons: %w", err)
+		}
+
+		results := wiki.SearchAddons(all, args[0], searchCategory)
+		info := registry.GetInfo()
+
+		// Summaries should describe the results, not the whole registry
+		info.NewAddons = 0
+		for i := range results {
+			if results[i].IsNew() {
+				info.NewAddons++
+			}
+		}
+
+		if searchJSON {
+			return outputJSON(results, info)
+		}
+
+		if len(results) == 0 {
+			fmt.Println(styles.FormatWarning(fmt.Sprintf("No addons matching %q", args[0])))
+			return nil
+		}
+
+		return outputTable(results, info)
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsSearchCmd)
+
+	addonsSearchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output as JSON")
+	addonsSearchCmd.Flags().StringVarP(&searchCategory, "category", "c", "", "Only search a wiki letter section (A-Z)")
+	addonsSearchCmd.Flags().StringSliceVar(&registryURLs, "registry", nil, "Registry URL to use, repeatable (\"default\" for the built-in registry)")
+	addonsSearchCmd.Flags().BoolVarP(&searchRefresh, "refresh", "r", false, "Force refresh the registry cache")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var addonsUnpinCmd = &cobra.Command{
+	Use:   "unpin <name>",
+	Short: "Let a pinned addon update again",
+	Long: `Unpin an addon frozen with 'addons pin' so update picks it up again.
+
+Examples:
+  turtlectl addons unpin pfQuest
+  turtlectl addons update pfQuest`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if err := manager.Unpin(addonName); err != nil {
+			return err
+		}
+
+		saveAddonManager()
+
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("Addon %s unpinned", addonName)))
+		return nil
+	},
+}
+
+func init() {
+	addonsCmd.AddCommand(addonsUnpinCmd)
+}
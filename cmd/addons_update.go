@@ -1,13 +1,47 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/offline"
 	uiaddons "github.com/bnema/turtlectl/internal/ui/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var (
+	updateJobs     int
+	updateBackupSV bool
+	updateDryRun   bool
+	updateQuiet    bool
+	updateCheck    bool
+	updateJSON     bool
 )
 
+// Exit codes for update --check
+const (
+	checkExitUpdates = 1 // Updates are available
+	checkExitFailed  = 2 // Some addons couldn't be checked, none have updates
+)
+
+// checkResultJSON is one addon in update --check --json output
+type checkResultJSON struct {
+	Name      string `json:"name"`
+	Current   string `json:"current,omitempty"`
+	Latest    string `json:"latest,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	HasUpdate bool   `json:"has_update"`
+	Error     string `json:"error,omitempty"`
+}
+
 var addonsUpdateCmd = &cobra.Command{
 	Use:   "update [name]",
 	Short: "Update addon(s)",
@@ -16,30 +50,77 @@ var addonsUpdateCmd = &cobra.Command{
 Uses git fast-forward to update addons. If local modifications exist,
 the update will fail (use remove + install to force).
 
+When updating all addons, several are updated in parallel. Use --jobs
+or TURTLECTL_UPDATE_JOBS to change how many (default 4). Disabled and
+pinned addons (see 'addons pin') are skipped.
+
+Use --dry-run to fetch and report what would change (current and target
+commit) without touching any addon.
+
+SavedVariables are backed up before each addon is changed unless
+--backup-sv=false is passed.
+
+Use --check to only report which addons have updates (current and latest
+commit) without applying anything, as a table or with --json. It exits
+with status 1 when updates are available and 2 when some checks failed,
+so scripts can send notifications.
+Checks fetch in parallel too (TURTLECTL_UPDATE_JOBS) and give up on an
+addon whose remote doesn't answer within 30 seconds.
+
+Without a terminal (cron, systemd timers) or with --quiet, plain lines are
+printed instead of the progress TUI. The exit status is non-zero if any
+addon failed to update.
+
 Examples:
-  turtlectl addons update          # Update all addons
-  turtlectl addons update pfQuest  # Update specific addon`,
+  turtlectl addons update            # Update all addons
+  turtlectl addons update pfQuest    # Update specific addon
+  turtlectl addons update -j 8       # Update all, 8 at a time
+  turtlectl addons update --dry-run  # Show what would be updated
+  turtlectl addons update --quiet    # Plain output, e.g. from cron
+  turtlectl addons update --check    # List addons with updates
+  turtlectl addons update --check --json`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Updating always needs to fetch
+		if offline.Enabled() {
+			return offline.ErrOffline
+		}
+
 		manager, err := getAddonManager()
 		if err != nil {
 			return err
 		}
+		manager.SetBackupSavedVariables(updateBackupSV)
 
 		var addonName string
 		if len(args) > 0 {
 			addonName = args[0]
 		}
 
+		if updateJSON && !updateCheck {
+			return fmt.Errorf("--json requires --check")
+		}
+		if updateCheck {
+			names := manager.GetUpdatableAddons()
+			if addonName != "" {
+				names = []string{addonName}
+			}
+			return checkAddonUpdates(cmd, manager, names)
+		}
+
 		if addonName == "" {
-			return updateAllAddons(manager)
+			return updateAllAddons(cmd.Context(), manager)
 		}
-		return updateSingleAddon(manager, addonName)
+		return updateSingleAddon(cmd.Context(), manager, addonName)
 	},
 }
 
-func updateSingleAddon(manager *addons.Manager, name string) error {
-	m := uiaddons.NewUpdateSingleModel(manager, name)
+func updateSingleAddon(ctx context.Context, manager *addons.Manager, name string) error {
+	if !useTUI(updateQuiet) {
+		return updateSingleAddonPlain(ctx, manager, name)
+	}
+
+	m := uiaddons.NewUpdateSingleModel(ctx, manager, name, updateDryRun)
 
 	p := tea.NewProgram(m)
 	finalModel, err := p.Run()
@@ -56,8 +137,17 @@ func updateSingleAddon(manager *addons.Manager, name string) error {
 	return nil
 }
 
-func updateAllAddons(manager *addons.Manager) error {
-	m := uiaddons.NewUpdateAllModel(manager)
+func updateAllAddons(ctx context.Context, manager *addons.Manager) error {
+	jobs := updateJobs
+	if jobs < 1 {
+		jobs = addons.UpdateConcurrency()
+	}
+
+	if !useTUI(updateQuiet) {
+		return updateAllAddonsPlain(ctx, manager, jobs)
+	}
+
+	m := uiaddons.NewUpdateAllModel(ctx, manager, jobs, updateDryRun)
 
 	p := tea.NewProgram(m)
 	finalModel, err := p.Run()
@@ -71,9 +161,177 @@ func updateAllAddons(manager *addons.Manager) error {
 	}
 
 	saveAddonManager()
+	if result := fm.GetResult(); result != nil && result.Failed > 0 {
+		return fmt.Errorf("failed to update %d addon(s)", result.Failed)
+	}
+	return nil
+}
+
+// checkAddonUpdates prints which addons have updates and exits non-zero if
+// any do, or if a check failed
+func checkAddonUpdates(cmd *cobra.Command, manager *addons.Manager, names []string) error {
+	ctx := cmd.Context()
+	results := manager.CheckUpdates(ctx, names)
+
+	var updates, failed int
+	for _, r := range results {
+		if r.HasUpdate {
+			updates++
+		}
+		if r.Error != nil {
+			failed++
+		}
+	}
+
+	if updateJSON {
+		out := make([]checkResultJSON, 0, len(results))
+		for _, r := range results {
+			entry := checkResultJSON{Name: r.Name, Current: r.Current, Latest: r.Latest, Ref: r.Ref, HasUpdate: r.HasUpdate}
+			if r.Error != nil {
+				entry.Error = r.Error.Error()
+			}
+			out = append(out, entry)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			return err
+		}
+	} else {
+		printCheckTable(results)
+		fmt.Printf("\n%d addon(s) checked, %d with updates, %d failed\n", len(results), updates, failed)
+	}
+
+	switch {
+	case updates > 0:
+		return exitWithCode(cmd, checkExitUpdates)
+	case failed > 0:
+		return exitWithCode(cmd, checkExitFailed)
+	}
 	return nil
 }
 
+// printCheckTable prints update check results as an aligned table
+func printCheckTable(results []addons.CheckUpdatesResult) {
+	table := styles.NewTable("NAME", "CURRENT", "LATEST", "STATUS")
+
+	for _, r := range results {
+		latest := r.Latest
+		if latest == "" {
+			latest = "-"
+		}
+
+		var status string
+		switch {
+		case r.Error != nil:
+			status = styles.FormatError(r.Error.Error())
+		case r.HasUpdate:
+			status = styles.FormatWarning("update available")
+		default:
+			status = styles.FormatSuccess("up to date")
+		}
+
+		table.AddRow(r.Name, r.Current, latest, status)
+	}
+
+	_ = table.Render(os.Stdout)
+}
+
+// updateSingleAddonPlain updates one addon with line-based output
+func updateSingleAddonPlain(ctx context.Context, manager *addons.Manager, name string) error {
+	if updateDryRun {
+		preview, err := manager.UpdateDryRun(ctx, name)
+		if err != nil {
+			return err
+		}
+		progress.PrintSuccess(uiaddons.DescribePreview(name, preview))
+		return nil
+	}
+
+	progress.PrintInProgress(fmt.Sprintf("Updating %s...", name))
+	result, err := manager.Update(ctx, name, nil)
+	saveAddonManager()
+	if err != nil {
+		return err
+	}
+
+	printUpdateResult(name, result)
+	return nil
+}
+
+// updateAllAddonsPlain updates every tracked addon with line-based output
+func updateAllAddonsPlain(ctx context.Context, manager *addons.Manager, jobs int) error {
+	if updateDryRun {
+		var failed int
+		for _, name := range manager.GetUpdatableAddons() {
+			preview, err := manager.UpdateDryRun(ctx, name)
+			switch {
+			case errors.Is(err, addons.ErrLocalSource):
+				// Local addons can't be updated, same as UpdateAll
+			case err != nil:
+				failed++
+				progress.PrintError(fmt.Sprintf("%s: %v", name, err))
+			default:
+				progress.PrintStep(previewState(preview), uiaddons.DescribePreview(name, preview))
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to check %d addon(s)", failed)
+		}
+		return nil
+	}
+
+	progress.PrintInProgress(fmt.Sprintf("Updating %d addon(s), %d at a time...", len(manager.GetUpdatableAddons()), jobs))
+	result := manager.UpdateAll(ctx, jobs)
+	saveAddonManager()
+
+	for _, name := range result.Names {
+		progress.PrintSuccess("Updated " + name)
+	}
+	for _, e := range result.Errors {
+		progress.PrintError(e)
+	}
+	progress.PrintSummary("Updated: %d, Skipped: %d, Failed: %d", result.Updated, result.Skipped, result.Failed)
+
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to update %d addon(s)", result.Failed)
+	}
+	return nil
+}
+
+// printUpdateResult prints an update outcome and its changelog
+func printUpdateResult(name string, result *addons.UpdateResult) {
+	switch {
+	case result.AlreadyUpToDate:
+		progress.PrintSuccess(name + " is already up to date")
+	case result.ReCloned:
+		progress.PrintSuccess("Updated " + name + " (re-cloned)")
+	default:
+		progress.PrintSuccess(fmt.Sprintf("Updated %s %s -> %s", name, result.OldCommit, result.NewCommit))
+		for _, c := range result.Commits {
+			progress.PrintDetail(c.Hash + " " + c.Subject)
+		}
+	}
+	if result.SavedVariablesBackup != "" {
+		progress.PrintDetail("SavedVariables backed up to " + result.SavedVariablesBackup)
+	}
+}
+
+// previewState marks addons with pending updates in dry-run output
+func previewState(preview *addons.UpdatePreview) progress.State {
+	if preview.HasUpdate || preview.ReClone {
+		return progress.StateInProgress
+	}
+	return progress.StateComplete
+}
+
 func init() {
+	addonsUpdateCmd.Flags().BoolVarP(&updateQuiet, "quiet", "q", false, "Plain line output instead of the progress TUI (automatic without a terminal)")
+	addonsUpdateCmd.Flags().BoolVar(&updateCheck, "check", false, "Only report which addons have updates, exit 1 if any do")
+	addonsUpdateCmd.Flags().BoolVar(&updateJSON, "json", false, "Output --check results as JSON")
+	addonsUpdateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show what would be updated without changing anything")
+	addonsUpdateCmd.Flags().BoolVar(&updateBackupSV, "backup-sv", true, "Back up SavedVariables before updating")
+	addonsUpdateCmd.Flags().IntVarP(&updateJobs, "jobs", "j", 0, "Number of addons to update in parallel (default 4)")
+	addonsUpdateCmd.MarkFlagsMutuallyExclusive("check", "dry-run")
 	addonsCmd.AddCommand(addonsUpdateCmd)
 }
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var verifyRecord bool
+
+var addonsVerifyCmd = &cobra.Command{
+	Use:   "verify [name...]",
+	Short: "Check addon files for corruption or changes",
+	Long: `Re-hash addon files and report the ones that changed, went missing or
+were added since install. A bad disk or an interrupted write can corrupt Lua
+files and crash the game on load.
+
+Git addons are compared with the files committed at their current commit.
+Addons installed from a local folder or zip are compared with the checksums
+recorded at install. Unlike 'turtlectl addons repair', which only checks git
+repositories, this checks the addon files themselves.
+
+Without names, every addon that can be verified is checked. Use --record
+after editing a local addon on purpose, to accept its current files.
+
+Examples:
+  turtlectl addons verify                   # All addons
+  turtlectl addons verify pfQuest           # A single addon
+  turtlectl addons verify MyAddon --record  # Accept the current files`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if verifyRecord {
+			if len(args) == 0 {
+				return fmt.Errorf("--record needs the names of the addons to record")
+			}
+			for _, name := range args {
+				if err := manager.RecordChecksums(name); err != nil {
+					return fmt.Errorf("failed to record checksums: %w", err)
+				}
+				progress.PrintSuccess(fmt.Sprintf("%s: checksums recorded", name))
+			}
+			return nil
+		}
+
+		var results []*addons.VerifyResult
+		if len(args) == 0 {
+			progress.PrintInProgress("Verifying addon files...")
+			results = manager.VerifyAll()
+			if len(results) == 0 {
+				fmt.Println("No addons to verify")
+				return nil
+			}
+		} else {
+			for _, name := range args {
+				result, err := manager.Verify(name)
+				if err != nil {
+					result = &addons.VerifyResult{Name: name, Err: err}
+				}
+				results = append(results, result)
+			}
+		}
+
+		var damaged, failed int
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				failed++
+				progress.PrintError(fmt.Sprintf("%s: %v", result.Name, result.Err))
+			case result.OK():
+				progress.PrintSuccess(fmt.Sprintf("%s: %d file(s) match %s", result.Name, result.Checked, result.Source))
+			default:
+				damaged++
+				progress.PrintWarning(fmt.Sprintf("%s: %d changed, %d missing, %d added", result.Name,
+					len(result.Changed), len(result.Missing), len(result.Added)))
+				printVerifyFiles("changed", result.Changed)
+				printVerifyFiles("missing", result.Missing)
+				printVerifyFiles("added", result.Added)
+			}
+		}
+		progress.PrintSummary("Verified: %d, Damaged: %d, Failed: %d", len(results)-failed, damaged, failed)
+
+		if damaged > 0 {
+			fmt.Println("\nReinstall git addons with 'turtlectl addons reinstall <name>'")
+		}
+		if damaged > 0 || failed > 0 {
+			return fmt.Errorf("%d addon(s) don't match, %d couldn't be verified", damaged, failed)
+		}
+		return nil
+	},
+}
+
+// printVerifyFiles prints the files of one kind of difference
+func printVerifyFiles(kind string, files []string) {
+	for _, file := range files {
+		progress.PrintDetail(fmt.Sprintf("%s: %s", kind, file))
+	}
+}
+
+func init() {
+	addonsVerifyCmd.Flags().BoolVar(&verifyRecord, "record", false, "Record the current files of local addons as their checksums")
+	addonsVerifyCmd.ValidArgsFunction = completeAddonNames(managedAddon)
+	addonsCmd.AddCommand(addonsVerifyCmd)
+}
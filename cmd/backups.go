@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var (
+	backupsKeep      int
+	backupsOlderThan string
+	backupsForce     bool
+)
+
+var backupsCmd = &cobra.Command{
+	Use:   "backups",
+	Short: "List and prune addon backups",
+	Long: `Addon folders are backed up as .tar.gz archives when removed or re-cloned,
+and their SavedVariables before updates. Up to 3 backups of each kind are kept per
+addon, so the backups directory slowly grows with every addon ever removed.
+
+Examples:
+  turtlectl backups list                      # Backups per addon, with sizes
+  turtlectl backups prune --keep 1            # Keep only the newest backup
+  turtlectl backups prune --older-than 90d    # Also drop backups over 90 days old
+  turtlectl backups delete pfQuest            # Every backup of pfQuest
+  turtlectl backups delete pfQuest 20240101-120000`,
+}
+
+var backupsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backups with counts and sizes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		all, err := manager.GetBackupManager().ListAll()
+		if err != nil {
+			return fmt.Errorf("failed to list backups: %w", err)
+		}
+		if len(all) == 0 {
+			fmt.Println("No backups")
+			return nil
+		}
+
+		fmt.Printf("%-32s %7s %6s %10s  %s\n", "ADDON", "BACKUPS", "SV", "SIZE", "LATEST")
+		var total int64
+		var count int
+		for _, addon := range all {
+			latest := "-"
+			if len(addon.Backups) > 0 {
+				latest = addon.Backups[0]
+			}
+			if len(addon.SavedVariables) > 0 && addon.SavedVariables[0] > latest {
+				latest = addon.SavedVariables[0]
+			}
+			fmt.Printf("%-32s %7d %6d %10s  %s\n", addon.Name, len(addon.Backups), len(addon.SavedVariables),
+				progress.FormatBytes(addon.Size), styles.MutedText.Render(latest))
+			total += addon.Size
+			count += addon.Count()
+		}
+		fmt.Println()
+		fmt.Printf("%d backup(s) of %d addon(s), %s\n", count, len(all), progress.FormatBytes(total))
+		return nil
+	},
+}
+
+var backupsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old backups of every addon",
+	Long: `Delete backups beyond the newest --keep of each addon, for both addon
+folder and SavedVariables backups. With --older-than, backups taken before
+that age or date are deleted too, even within --keep.
+
+Examples:
+  turtlectl backups prune                          # Apply the default retention
+  turtlectl backups prune --keep 1                 # Only the newest backup
+  turtlectl backups prune --keep 0 --older-than 6m # Only backups under 6 months
+  turtlectl backups prune --older-than 2024-01-01`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backupsKeep < 0 {
+			return fmt.Errorf("invalid --keep %d", backupsKeep)
+		}
+		var before time.Time
+		if backupsOlderThan != "" {
+			var err error
+			if before, err = parseOlderThan(backupsOlderThan); err != nil {
+				return err
+			}
+		}
+		if backupsKeep == 0 && before.IsZero() {
+			return fmt.Errorf("nothing to prune with --keep 0, add --older-than")
+		}
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if needsConfirm(backupsForce) {
+			if backupsKeep > 0 {
+				fmt.Printf("Delete backups beyond the newest %d of each addon?\n", backupsKeep)
+			}
+			if !before.IsZero() {
+				fmt.Printf("Delete backups taken before %s?\n", before.Format("2006-01-02 15:04"))
+			}
+			if !confirm() {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		result, err := manager.GetBackupManager().Prune(backupsKeep, before)
+		if err != nil {
+			return fmt.Errorf("failed to prune backups: %w", err)
+		}
+		progress.PrintSuccess(fmt.Sprintf("Deleted %d backup(s), reclaimed %s", result.Removed, progress.FormatBytes(result.Reclaimed)))
+		return nil
+	},
+}
+
+var backupsDeleteCmd = &cobra.Command{
+	Use:   "delete <addon> [timestamp]",
+	Short: "Delete the backups of an addon",
+	Long: `Delete one backup of an addon, or all of them without a timestamp.
+The SavedVariables backup taken at the same time is deleted too.
+
+Examples:
+  turtlectl backups delete pfQuest                    # Every backup
+  turtlectl backups delete pfQuest 20240101-120000    # One backup`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonName := args[0]
+		var timestamp string
+		if len(args) == 2 {
+			timestamp = args[1]
+		}
+
+		manager, err := getAddonManager()
+		if err != nil {
+			return err
+		}
+
+		if needsConfirm(backupsForce) {
+			if timestamp == "" {
+				fmt.Printf("Delete every backup of %s?\n", styles.Highlighted.Render(addonName))
+			} else {
+				fmt.Printf("Delete the %s backup of %s?\n", timestamp, styles.Highlighted.Render(addonName))
+			}
+			if !confirm() {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		reclaimed, err := manager.GetBackupManager().DeleteBackups(addonName, timestamp)
+		if errors.Is(err, addons.ErrBackupNotFound) {
+			return fmt.Errorf("%w\nRun 'turtlectl backups list' to see the available backups", err)
+		}
+		if err != nil {
+			return err
+		}
+		progress.PrintSuccess(fmt.Sprintf("Deleted, reclaimed %s", progress.FormatBytes(reclaimed)))
+		return nil
+	},
+}
+
+// parseOlderThan turns an age like "90d" or a date like "2024-01-31" into
+// the time backups must be taken before to be pruned
+func parseOlderThan(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	age, err := parseMaxAge(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --older-than %q (use an age like 90d or a date like 2024-01-31)", value)
+	}
+	return time.Now().Add(-age), nil
+}
+
+func init() {
+	backupsPruneCmd.Flags().IntVar(&backupsKeep, "keep", addons.MaxBackupsPerAddon, "Backups to keep per addon and kind (0 for no limit)")
+	backupsPruneCmd.Flags().StringVar(&backupsOlderThan, "older-than", "", "Also delete backups older than an age (90d, 6m, 1y) or a date (2024-01-31)")
+	backupsPruneCmd.Flags().BoolVarP(&backupsForce, "force", "f", false, "Skip confirmation prompt")
+	backupsDeleteCmd.Flags().BoolVarP(&backupsForce, "force", "f", false, "Skip confirmation prompt")
+	backupsDeleteCmd.ValidArgsFunction = completeBackups
+
+	rootCmd.AddCommand(backupsCmd)
+	backupsCmd.AddCommand(backupsListCmd)
+	backupsCmd.AddCommand(backupsPruneCmd)
+	backupsCmd.AddCommand(backupsDeleteCmd)
+}
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/bnema/turtlectl/internal/addons"
 	"github.com/bnema/turtlectl/internal/launcher"
 	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
 )
 
 var cleanAll bool
@@ -17,14 +20,37 @@ var cleanCmd = &cobra.Command{
 	Short:   "Nuclear clean: remove all config, cache, and AppImage (keeps game files)",
 	Long: `Completely removes all launcher data including:
   - Config and preferences (~/.local/share/turtle-wow)
+  - Addon store and logs (~/.local/state/turtle-wow)
   - Cache and AppImage (~/.cache/turtle-wow)
   - Desktop file and icon
 
 Game files in ~/Games/turtle-wow are preserved by default.
-Use --all to also remove game files (full purge).`,
+Use --all to also remove game files (full purge). It lists the directories
+to delete with their sizes and asks for confirmation first, unless --yes
+is passed.
+
+Examples:
+  turtlectl clean            # Launcher data only
+  turtlectl clean --all      # Everything, including game files and addons
+  turtlectl clean --all -y   # Full purge without asking`,
 	Run: func(cmd *cobra.Command, args []string) {
 		l := launcher.New(getLogger())
 
+		if cleanAll && needsConfirm(false) {
+			fmt.Println("Full purge will delete:")
+			printCleanTarget("Data", l.DataDir)
+			printCleanTarget("State", l.StateDir)
+			printCleanTarget("Cache", l.CacheDir)
+			printCleanTarget("Game", l.GameDir)
+			fmt.Println("  and the desktop entry and icon")
+			fmt.Println(styles.FormatWarning("Addons, SavedVariables and screenshots will be lost too!"))
+			if !confirm() {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Println()
+		}
+
 		if cleanAll {
 			progress.PrintTitle("Full Purge")
 			progress.PrintWarning("Removing ALL data including game files")
@@ -53,6 +79,19 @@ Use --all to also remove game files (full purge).`,
 	},
 }
 
+// printCleanTarget prints a directory clean would delete, with its size
+func printCleanTarget(label, dir string) {
+	size, gitSize, err := addons.DirSize(dir)
+	detail := progress.FormatBytes(size + gitSize)
+	switch {
+	case os.IsNotExist(err):
+		detail = "missing"
+	case err != nil:
+		detail = "size unknown"
+	}
+	fmt.Printf("  %-6s %s %s\n", label, styles.Highlighted.Render(dir), styles.MutedText.Render("("+detail+")"))
+}
+
 var resetCredentialsCmd = &cobra.Command{
 	Use:   "reset-credentials",
 	Short: "Reset saved login credentials only",
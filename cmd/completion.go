@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a completion script for your shell. Besides subcommands and
+flags, addon names are completed for commands like remove, update and info,
+and registry addons for addons install.
+
+Examples:
+  # Bash, for the current session and for every new one
+  source <(turtlectl completion bash)
+  turtlectl completion bash > ~/.local/share/bash-completion/completions/turtlectl
+
+  # Zsh (compinit must be enabled)
+  turtlectl completion zsh > "${fpath[1]}/_turtlectl"
+
+  # Fish
+  turtlectl completion fish > ~/.config/fish/completions/turtlectl.fish
+
+  # PowerShell
+  turtlectl completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	},
+}
+
+// completionManager loads the addon store for completion, applying the
+// --game-dir and --profile flags of the line being completed
+// Unlike getAddonManager it creates no directories
+func completionManager(cmd *cobra.Command, args []string) (*addons.Manager, error) {
+	if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+		return nil, err
+	}
+	l := launcher.New(getLogger())
+	manager := newAddonManager(l)
+	if err := manager.Load(); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// completeAddonNames completes the name of an installed addon kept by keep
+// (all addons when nil), for commands taking the name as their first argument
+func completeAddonNames(keep func(*addons.Addon) bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		manager, err := completionManager(cmd, args)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		installed, err := manager.ListInstalled()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var names []cobra.Completion
+		for _, addon := range installed {
+			if keep == nil || keep(addon) {
+				names = append(names, addon.Name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// managedAddon keeps addons that aren't shipped with the game
+func managedAddon(addon *addons.Addon) bool {
+	return !addons.IsDefaultAddon(addon.Name)
+}
+
+// trackedAddon keeps enabled addons installed from git
+func trackedAddon(addon *addons.Addon) bool {
+	return addon.GitURL != "" && !addon.Disabled
+}
+
+// completeBackups completes addons restore with the addons that have backups,
+// then with the backup timestamps of the chosen addon
+func completeBackups(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	manager, err := completionManager(cmd, args)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var choices []string
+	if len(args) == 0 {
+		choices, err = manager.GetBackupManager().ListBackedUpAddons()
+	} else {
+		// Newest first, keep the order
+		choices, err = manager.GetBackupManager().ListBackups(args[0])
+	}
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if len(args) == 1 {
+		directive |= cobra.ShellCompDirectiveKeepOrder
+	}
+	return choices, directive
+}
+
+// completeRegistryURLs completes addons install with the URLs of registry
+// addons, described by their name, falling back to local paths
+// Only the cached registry is used, completion never touches the network
+func completeRegistryURLs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	offline.Set(true)
+
+	l := launcher.New(getLogger())
+	wikiAddons, err := newRegistry(l.CacheDir).GetAddons(false)
+	if err != nil || len(wikiAddons) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	urls := make([]cobra.Completion, 0, len(wikiAddons))
+	for _, addon := range wikiAddons {
+		urls = append(urls, cobra.CompletionWithDesc(addon.URL, addon.Name))
+	}
+	return urls, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+
+	addonsInfoCmd.ValidArgsFunction = completeAddonNames(nil)
+	addonsRemoveCmd.ValidArgsFunction = completeAddonNames(managedAddon)
+	addonsEnableCmd.ValidArgsFunction = completeAddonNames(func(addon *addons.Addon) bool { return addon.Disabled })
+	addonsDisableCmd.ValidArgsFunction = completeAddonNames(func(addon *addons.Addon) bool {
+		return managedAddon(addon) && !addon.Disabled
+	})
+	addonsUpdateCmd.ValidArgsFunction = completeAddonNames(trackedAddon)
+	addonsReinstallCmd.ValidArgsFunction = completeAddonNames(trackedAddon)
+	addonsRestoreCmd.ValidArgsFunction = completeBackups
+	addonsInstallCmd.ValidArgsFunction = completeRegistryURLs
+}
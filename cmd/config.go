@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and change launcher preferences",
+	Long: `Read and change the launcher's preferences.json without editing it by hand.
+Unknown fields in the file are kept as-is.
+
+Keys:
+  language          Launcher language (` + strings.Join(launcher.Languages, ", ") + `)
+  linuxLaunchArgs   Command the launcher runs the game with, must contain $WoW.exe$
+  mirror            Download mirror, one of those returned by the last update check
+  clientDir         Game directory (read-only, follows --game-dir)
+  safeDir           Game directory (read-only, follows --game-dir)
+
+A runner saved with launch --wine/--proton rewrites linuxLaunchArgs on
+every launch; use --wine default to go back to your own args.
+
+turtlectl's own defaults live in a separate config file, see 'config path'.
+
+Examples:
+  turtlectl config get mirror                           # Show the mirror
+  turtlectl config set language de                     # German launcher
+  turtlectl config set linuxLaunchArgs 'wine64 $WoW.exe$'
+  turtlectl config path                                 # turtlectl config file`,
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the turtlectl config file location",
+	Long: `Print where turtlectl reads its config file from: --config, or
+$XDG_CONFIG_HOME/turtlectl/config.toml (~/.config by default). The file is
+optional and sets defaults; flags override it, and it overrides env vars.
+
+Keys (flat TOML, one per line):
+  mirror = "bunny"              # Mirror when preferences.json has none
+  game_dir = "~/Games/turtle-wow"
+  update_concurrency = 8        # Addons updated in parallel
+  nerd_fonts = true             # Nerd Font icons, false for ASCII (--icons)
+  offline = false               # Never touch the network (TURTLECTL_OFFLINE)
+  registry = ["default", "https://example.com/guild-addons.json"]
+  sandbox_binds = ["ro:/usr", "dev:/dev/dri", "~/Games/turtle-wow"]  # launch --sandbox
+
+Examples:
+  turtlectl config path
+  $EDITOR "$(turtlectl config path)"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(configPath())
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:       "get <key>",
+	Short:     "Print a preference",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: launcher.PreferenceKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := launcher.New(getLogger())
+		if err := l.EnsureLauncherDirs(); err != nil {
+			return err
+		}
+
+		value, err := l.GetPreference(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:       "set <key> <value>",
+	Short:     "Change a preference",
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: launcher.PreferenceKeys,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := launcher.New(getLogger())
+		if err := l.EnsureLauncherDirs(); err != nil {
+			return err
+		}
+
+		if err := l.SetPreference(args[0], args[1]); err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(l.PreferencesPath())
+		if err != nil {
+			return err
+		}
+
+		progress.PrintSuccess("Saved " + l.PreferencesPath())
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configPathCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check runtime dependencies needed to run the game",
+	Long: `Run the same preflight checks as launch and install, and print every
+result with a hint on how to fix it. Checks FUSE (used to mount the
+AppImage, without it launch extracts the AppImage instead), the wine/proton
+runner from preferences.json, and update-desktop-database for the menu entry.
+
+Exits with status 1 if a check would stop the game from starting.
+
+Examples:
+  turtlectl doctor                                  # Check everything
+  turtlectl launch -- --appimage-extract-and-run    # Skip FUSE even when available`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		l := launcher.New(getLogger())
+
+		progress.PrintTitle("Checking runtime dependencies")
+
+		checks := l.Preflight(nil)
+		printChecks(checks, true)
+
+		if launcher.PreflightFailed(checks) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// printChecks prints preflight results with their fix hints
+// Passing checks are only shown when all is set
+func printChecks(checks []launcher.Check, all bool) {
+	for _, c := range checks {
+		message := c.Name + ": " + c.Detail
+		switch c.Status {
+		case launcher.CheckOK:
+			if all {
+				progress.PrintComplete(message)
+			}
+			continue
+		case launcher.CheckWarn:
+			progress.PrintWarning(message)
+		case launcher.CheckFail:
+			progress.PrintError(message)
+		}
+		if c.Hint != "" {
+			progress.PrintDetail(c.Hint)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// parseFormat parses a --format Go template
+func parseFormat(format string) (*template.Template, error) {
+	tmpl, err := template.New("format").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printFormatted executes tmpl for each item, one per line, like docker --format
+func printFormatted[T any](w io.Writer, tmpl *template.Template, items []T) error {
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("--format template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,14 +6,25 @@ import (
 
 	"github.com/bnema/turtlectl/internal/launcher"
 	uilauncher "github.com/bnema/turtlectl/internal/ui/launcher"
+	"github.com/bnema/turtlectl/internal/ui/progress"
 )
 
+var installMirror string
+
 var installCmd = &cobra.Command{
 	Use:     "install",
 	Aliases: []string{"i"},
 	Short:   "Install/update AppImage and create desktop file",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		l := launcher.New(getLogger())
+		l.Mirror = installMirror
+
+		// Install still works without these, so only warn
+		printChecks(l.Preflight(nil), false)
+
+		if !useTUI(false) {
+			return installPlain(l)
+		}
 
 		m := uilauncher.NewInstallModel(l)
 		p := tea.NewProgram(m)
@@ -28,6 +39,42 @@ var installCmd = &cobra.Command{
 	},
 }
 
+// installPlain runs the install steps with line-based output
+func installPlain(l *launcher.Launcher) error {
+	progress.PrintTitle("Installing Turtle WoW Launcher")
+
+	progress.PrintInProgress("Creating directories")
+	if err := l.EnsureLauncherDirs(); err != nil {
+		return err
+	}
+	progress.PrintComplete("Directories ready")
+
+	progress.PrintInProgress("Checking for updates")
+	result, err := l.UpdateAppImageWithProgress(nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case result.Skipped:
+		progress.PrintComplete("Launcher ready (offline, update check skipped)")
+	case result.AlreadyLatest:
+		progress.PrintComplete("Launcher already up to date")
+	case result.Mirror != "":
+		progress.PrintComplete("Downloaded from " + result.Mirror)
+	default:
+		progress.PrintComplete("Launcher downloaded")
+	}
+
+	progress.PrintInProgress("Installing desktop entry")
+	if err := l.InstallDesktop(); err != nil {
+		return err
+	}
+	progress.PrintComplete("Desktop entry installed")
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(installCmd)
+
+	installCmd.Flags().StringVar(&installMirror, "mirror", "", "Preferred download mirror (default: preferences.json, then "+launcher.DefaultMirror+")")
 }
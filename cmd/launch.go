@@ -1,14 +1,32 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/launcher"
 	"github.com/bnema/turtlectl/internal/ui/progress"
 )
 
+var (
+	launchWine     string
+	launchProton   string
+	launchGamemode bool
+	launchMangohud bool
+	launchSandbox  bool
+
+	launchFSR         bool
+	launchFSRStrength int
+	launchPresentMode string
+
+	launchNoCd     bool
+	launchNoUpdate bool
+)
+
 var launchCmd = &cobra.Command{
 	Use:     "launch",
 	Aliases: []string{"start", "run", "play"},
@@ -17,10 +35,59 @@ var launchCmd = &cobra.Command{
 
 This will:
   1. Create necessary directories
-  2. Check for launcher updates
+  2. Check for launcher updates (skipped with --no-update or --offline)
   3. Clean any problematic config
   4. Setup environment (Wayland, GPU optimizations)
-  5. Start the AppImage launcher`,
+  5. Start the AppImage launcher
+
+Hooks:
+  Executables in <data dir>/hooks/pre-launch.d run before the game and
+  post-launch.d after it exits, in name order (override the directory with
+  TURTLECTL_HOOKS_DIR). A failing pre-launch hook aborts the launch. Hooks
+  get TURTLE_GAME_DIR, TURTLE_DATA_DIR, TURTLE_APPIMAGE, TURTLE_PROFILE, and
+  post-launch hooks also TURTLE_EXIT_CODE.
+
+Updates:
+  --no-update starts the AppImage already on disk without contacting the
+  update server, for quick or metered-connection launches. It fails only
+  when no AppImage has been downloaded yet.
+
+Wine/Proton:
+  --wine and --proton are saved to preferences.json and used for later
+  launches too. Pass "default" to go back to the bundled launcher's wine.
+
+GameMode/MangoHud:
+  --gamemode runs the game through gamemoderun and --mangohud sets
+  MANGOHUD=1. Both are saved, use --gamemode=false to turn one off again.
+  Missing tools are skipped with a warning.
+
+Sandbox:
+  --sandbox runs the launcher and game inside bubblewrap (bwrap), saved for
+  later launches. The sandbox sees the system read-only, the GPU, X11,
+  Wayland and PulseAudio/PipeWire sockets, and of the home directory only
+  the game, data and cache directories; the AppImage is extracted instead
+  of FUSE-mounted. Set sandbox_binds in the config file to replace the binds
+  (path, ro:path or dev:path), e.g. to add a Proton directory or NVIDIA
+  devices. Without bwrap the game starts unsandboxed with a warning.
+
+Upscaling:
+  --fsr sets WINE_FULLSCREEN_FSR=1 so lower fullscreen resolutions are
+  upscaled, --fsr-strength picks the sharpening (0 sharpest, 5 softest).
+  --present-mode sets MESA_VK_WSI_PRESENT_MODE (immediate, mailbox, fifo,
+  relaxed) for tearing control. All are off unless enabled, and saved;
+  use --fsr=false or --present-mode default to turn them off again.
+
+Examples:
+  turtlectl launch                                  # Start the game
+  turtlectl launch --wine /usr/bin/wine             # Use system wine
+  turtlectl launch --proton ~/.steam/root/compatibilitytools.d/GE-Proton9-20
+  turtlectl launch --wine default                   # Back to the default
+  turtlectl launch --gamemode --mangohud            # Enable gamemode and the HUD
+  turtlectl launch --sandbox                        # Run inside bubblewrap
+  turtlectl launch --fsr --fsr-strength 2           # Upscale with FSR
+  turtlectl launch --present-mode mailbox           # Vsync without tearing
+  turtlectl launch --no-cd                          # Keep the current directory
+  turtlectl launch --no-update                      # Skip the launcher update check`,
 	Run: func(cmd *cobra.Command, args []string) {
 		l := launcher.New(getLogger())
 
@@ -33,12 +100,31 @@ This will:
 		}
 		progress.PrintComplete("Directories ready")
 
-		progress.PrintInProgress("Checking for updates")
-		if err := l.UpdateAppImage(); err != nil {
-			progress.PrintError("Failed to update AppImage: " + err.Error())
-			os.Exit(1)
+		checkDesktopExec(l)
+
+		if launchNoUpdate {
+			if _, err := os.Stat(l.AppImagePath); err != nil {
+				progress.PrintError(fmt.Sprintf("%v: %s (run turtlectl install first, or launch without --no-update)", launcher.ErrAppImageMissing, l.AppImagePath))
+				os.Exit(1)
+			}
+			progress.PrintComplete("Launcher ready (update check skipped)")
+		} else {
+			progress.PrintInProgress("Checking for updates")
+			result, err := l.UpdateAppImageWithProgress(nil)
+			if err != nil {
+				progress.PrintError("Failed to update AppImage: " + err.Error())
+				os.Exit(1)
+			}
+			if result.DownloadErr != nil {
+				progress.PrintWarning("Update download failed, launching the existing AppImage: " + result.DownloadErr.Error())
+			} else if result.Skipped {
+				progress.PrintComplete("Launcher ready (offline, update check skipped)")
+			} else if result.RolledBack {
+				progress.PrintComplete("Launcher ready (rolled back, run 'turtlectl update' to upgrade)")
+			} else {
+				progress.PrintComplete("Launcher ready")
+			}
 		}
-		progress.PrintComplete("Launcher ready")
 
 		if err := l.CleanConfig(); err != nil {
 			progress.PrintWarning("Config cleanup issue: " + err.Error())
@@ -50,9 +136,66 @@ This will:
 			progress.PrintWarning("Failed to initialize preferences: " + err.Error())
 		}
 
+		l.Wine = launchWine
+		l.Proton = launchProton
+		if err := l.ConfigureRunner(); err != nil {
+			progress.PrintError("Failed to configure wine/proton: " + err.Error())
+			os.Exit(1)
+		}
+
+		var gamemode, mangohud *bool
+		if cmd.Flags().Changed("gamemode") {
+			gamemode = &launchGamemode
+		}
+		if cmd.Flags().Changed("mangohud") {
+			mangohud = &launchMangohud
+		}
+		warnings, err := l.ConfigureWrappers(gamemode, mangohud)
+		if err != nil {
+			progress.PrintWarning("Failed to configure gamemode/mangohud: " + err.Error())
+		}
+		for _, w := range warnings {
+			progress.PrintWarning(w)
+		}
+
+		var sandbox *bool
+		if cmd.Flags().Changed("sandbox") {
+			sandbox = &launchSandbox
+		}
+		warnings, err = l.ConfigureSandbox(sandbox)
+		if err != nil {
+			progress.PrintWarning("Failed to configure the sandbox: " + err.Error())
+		}
+		for _, w := range warnings {
+			progress.PrintWarning(w)
+		}
+
+		var scaling launcher.ScalingOptions
+		if cmd.Flags().Changed("fsr") {
+			scaling.FSR = &launchFSR
+		}
+		if cmd.Flags().Changed("fsr-strength") {
+			scaling.FSRStrength = &launchFSRStrength
+		}
+		if cmd.Flags().Changed("present-mode") {
+			scaling.PresentMode = &launchPresentMode
+		}
+		if err := l.ConfigureScaling(scaling); err != nil {
+			progress.PrintError("Failed to configure upscaling: " + err.Error())
+			os.Exit(1)
+		}
+
+		checks := l.Preflight(args)
+		printChecks(checks, false)
+		if launcher.PreflightFailed(checks) {
+			progress.PrintError("Missing runtime dependencies, see turtlectl doctor")
+			os.Exit(1)
+		}
+
 		progress.PrintComplete("Starting game...")
 		progress.PrintNewline()
 
+		l.NoChdir = launchNoCd
 		if err := l.Launch(args); err != nil {
 			progress.PrintError("Failed to launch: " + err.Error())
 			os.Exit(1)
@@ -60,6 +203,44 @@ This will:
 	},
 }
 
+// checkDesktopExec warns when the desktop entry runs a turtlectl that moved,
+// offering to rewrite it when there's a terminal to ask on
+func checkDesktopExec(l *launcher.Launcher) {
+	stale, ok := l.StaleDesktopExec()
+	if !ok {
+		return
+	}
+
+	getLogger().Warn("Desktop entry runs a different turtlectl", "exec", stale, "current", l.ScriptPath)
+	progress.PrintWarning(fmt.Sprintf("Desktop entry runs %s, not this turtlectl (%s)", stale, l.ScriptPath))
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		progress.PrintDetail("Run 'turtlectl install' to fix it")
+		return
+	}
+
+	fmt.Print("Update the desktop entry to this turtlectl?")
+	if !confirm() {
+		return
+	}
+	if err := l.InstallDesktop(); err != nil {
+		progress.PrintWarning("Failed to update the desktop entry: " + err.Error())
+		return
+	}
+	progress.PrintComplete("Desktop entry updated")
+}
+
 func init() {
 	rootCmd.AddCommand(launchCmd)
+
+	launchCmd.Flags().StringVar(&launchWine, "wine", "", "Wine binary to run the game with (saved, \"default\" to reset)")
+	launchCmd.Flags().StringVar(&launchProton, "proton", "", "Proton directory to run the game with (saved, \"default\" to reset)")
+	launchCmd.MarkFlagsMutuallyExclusive("wine", "proton")
+	launchCmd.Flags().BoolVar(&launchGamemode, "gamemode", false, "Run the game through gamemoderun (saved)")
+	launchCmd.Flags().BoolVar(&launchMangohud, "mangohud", false, "Enable the MangoHud overlay (saved)")
+	launchCmd.Flags().BoolVar(&launchSandbox, "sandbox", false, "Run the launcher inside a bubblewrap sandbox (saved)")
+	launchCmd.Flags().BoolVar(&launchNoUpdate, "no-update", false, "Launch the existing AppImage without checking for updates")
+	launchCmd.Flags().BoolVar(&launchNoCd, "no-cd", false, "Keep the current directory instead of running in the game directory")
+	launchCmd.Flags().BoolVar(&launchFSR, "fsr", false, "Enable wine FSR upscaling (saved)")
+	launchCmd.Flags().IntVar(&launchFSRStrength, "fsr-strength", 2, "FSR sharpening, 0 sharpest to 5 softest (saved)")
+	launchCmd.Flags().StringVar(&launchPresentMode, "present-mode", "", "Vulkan present mode: "+strings.Join(launcher.PresentModes, ", ")+" (saved, \"default\" to reset)")
 }
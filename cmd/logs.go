@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/logger"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+// logsPollInterval is how often --follow checks the log file for new lines
+const logsPollInterval = 500 * time.Millisecond
+
+var (
+	logsFollow bool
+	logsLines  int
+	logsPath   bool
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the turtlectl log file",
+	Long: `Print the turtlectl log file. Everything is logged there, even without
+--verbose, so it's the first place to look (and attach to bug reports) when
+a launch or install goes wrong. Past 5 MB it's rotated to turtlectl.log.1
+and .2 on the next run.
+
+Examples:
+  turtlectl logs              # Print the whole log
+  turtlectl logs -n 50        # Last 50 lines
+  turtlectl logs -f           # Follow new lines live (Ctrl+C to stop)
+  turtlectl logs --path       # Print where the log file lives`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := logger.GetLogPath()
+		if logsPath {
+			fmt.Println(path)
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if os.IsNotExist(err) && !logsFollow {
+			fmt.Println(styles.FormatWarning("No log file yet at " + path + ", it's created by the first command that logs"))
+			return nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+
+		var offset int64
+		if f != nil {
+			offset, err = printLogTail(f, logsLines)
+			_ = f.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read log file: %w", err)
+			}
+		}
+
+		if logsFollow {
+			return followLog(path, offset)
+		}
+		if offset == 0 {
+			fmt.Println(styles.Help.Render("Log file is empty: " + path))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new lines as they're written")
+	logsCmd.Flags().IntVarP(&logsLines, "lines", "n", 0, "Only show the last N lines (0 for all)")
+	logsCmd.Flags().BoolVar(&logsPath, "path", false, "Only print the log file location")
+}
+
+// printLogTail prints the last n lines of f (all if n <= 0) and returns the
+// offset reached, where --follow continues from
+func printLogTail(f *os.File, n int) (int64, error) {
+	if n <= 0 {
+		return io.Copy(os.Stdout, f)
+	}
+
+	var read int64
+	ring := make([]string, 0, n)
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		read += int64(len(line))
+		if line != "" {
+			if len(ring) == n {
+				ring = ring[1:]
+			}
+			ring = append(ring, line)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return read, err
+		}
+	}
+
+	for _, line := range ring {
+		fmt.Print(line)
+	}
+	return read, nil
+}
+
+// followLog polls path for data past offset until interrupted
+// A truncated or recreated file is read again from the start
+func followLog(path string, offset int64) error {
+	for {
+		info, err := os.Stat(path)
+		if err == nil {
+			if info.Size() < offset {
+				offset = 0
+			}
+			if info.Size() > offset {
+				f, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("failed to open log file: %w", err)
+				}
+				if _, err := f.Seek(offset, io.SeekStart); err == nil {
+					n, _ := io.Copy(os.Stdout, f)
+					offset += n
+				}
+				_ = f.Close()
+			}
+		}
+		time.Sleep(logsPollInterval)
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/logger"
+)
+
+var pathsJSON bool
+
+// pathEntry is one location reported by the paths command
+type pathEntry struct {
+	Key   string
+	Label string
+	Path  string
+}
+
+var pathsCmd = &cobra.Command{
+	Use:   "paths [key]",
+	Short: "Show where turtlectl keeps its files",
+	Long: `Print the directories and files turtlectl uses, after applying
+--game-dir, --profile and the XDG environment variables. With a key, only
+that path is printed, for use in scripts.
+
+Keys: game, addons, data, state, cache, appimage, desktop, icons, store, backups, log
+
+Examples:
+  turtlectl paths                  # Every path
+  turtlectl paths --json           # JSON output for scripting
+  cd "$(turtlectl paths addons)"   # Jump to Interface/AddOns`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var keys []cobra.Completion
+		for _, entry := range collectPaths() {
+			keys = append(keys, cobra.CompletionWithDesc(entry.Key, entry.Label))
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := collectPaths()
+
+		if len(args) == 1 {
+			i := slices.IndexFunc(paths, func(entry pathEntry) bool { return entry.Key == args[0] })
+			if i < 0 {
+				return fmt.Errorf("unknown path %q (run 'turtlectl paths' to list them)", args[0])
+			}
+			fmt.Println(paths[i].Path)
+			return nil
+		}
+
+		if pathsJSON {
+			report := make(map[string]string, len(paths))
+			for _, entry := range paths {
+				report[entry.Key] = entry.Path
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+
+		for _, entry := range paths {
+			fmt.Printf("%-11s %s%s\n", entry.Label+":", entry.Path, missingSuffix(entry.Path))
+		}
+		return nil
+	},
+}
+
+// collectPaths resolves every path without creating anything
+func collectPaths() []pathEntry {
+	l := launcher.New(getLogger())
+	manager := newAddonManager(l)
+
+	return []pathEntry{
+		{"game", "Game", l.GameDir},
+		{"addons", "Addons", manager.GetAddonsDir()},
+		{"data", "Data", l.DataDir},
+		{"state", "State", l.StateDir},
+		{"cache", "Cache", l.CacheDir},
+		{"appimage", "AppImage", l.AppImagePath},
+		{"desktop", "Desktop", l.DesktopDir},
+		{"icons", "Icons", l.IconDir},
+		{"store", "Store", manager.GetStorePath()},
+		{"backups", "Backups", manager.GetBackupManager().GetBackupDir()},
+		{"log", "Log", logger.GetLogPath()},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(pathsCmd)
+
+	pathsCmd.Flags().BoolVar(&pathsJSON, "json", false, "Output as JSON")
+}
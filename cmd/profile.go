@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles",
+	Long: `Profiles keep separate launcher config, addon store, and backups, e.g. for
+a main and an alt. The AppImage is shared. Select one with --profile <name>
+or TURTLECTL_PROFILE; a profile is created the first time it's used.
+
+The default profile uses the original paths. Combine a profile with
+--game-dir to also keep a separate AddOns folder.
+
+Examples:
+  turtlectl profile list                 # List profiles
+  turtlectl --profile alt launch         # Launch with the "alt" profile
+  turtlectl --profile alt addons list    # Addons tracked by "alt"`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profiles, err := launcher.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+
+		active := launcher.ActiveProfile()
+		for _, name := range profiles {
+			marker := "  "
+			if name == active {
+				marker = styles.CheckMark.String() + " "
+			}
+
+			dataDir := launcher.ProfileDataDir(name)
+			if _, err := os.Stat(dataDir); err != nil {
+				dataDir += " (not created yet)"
+			}
+			fmt.Printf("%s%-12s %s\n", marker, name, styles.Help.Render(dataDir))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+}
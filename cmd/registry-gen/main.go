@@ -17,15 +17,16 @@ import (
 
 func main() {
 	outputPath := flag.String("output", "data/addons.json", "Output path for the registry JSON")
+	fullRefresh := flag.Bool("full", false, "Re-enrich every repo instead of only those pushed since the last run")
 	flag.Parse()
 
-	if err := run(*outputPath); err != nil {
+	if err := run(*outputPath, *fullRefresh); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run(outputPath string) error {
+func run(outputPath string, fullRefresh bool) error {
 	fmt.Println("=== Addon Registry Generator ===")
 	fmt.Println()
 
@@ -61,9 +62,9 @@ func run(outputPath string) error {
 	}
 	fmt.Printf("New addons: %d\n", newCount)
 
-	// Enrich with GitHub metadata using GraphQL
+	// Enrich with GitHub/GitLab metadata using GraphQL
 	fmt.Println()
-	fmt.Println("Enriching addons with GitHub metadata (GraphQL)...")
+	fmt.Println("Enriching addons with GitHub/GitLab metadata (GraphQL)...")
 	if enricher.IsAuthenticated() {
 		fmt.Println("Using GitHub GraphQL API (batched queries)")
 	} else {
@@ -73,9 +74,15 @@ func run(outputPath string) error {
 	}
 	fmt.Println()
 
+	// Incremental runs reuse metadata for repos that haven't been pushed since
+	previous := existing.Addons
+	if fullRefresh {
+		previous = nil
+	}
+
 	startTime := time.Now()
 	lastPrint := time.Now()
-	enricher.EnrichAll(addons, func(current, total int, name string) {
+	skipped := enricher.EnrichIncremental(addons, previous, func(current, total int, name string) {
 		// Print progress every 50 addons or every 2 seconds
 		if current%50 == 0 || time.Since(lastPrint) > 2*time.Second || current == total {
 			elapsed := time.Since(startTime)
@@ -85,6 +92,9 @@ func run(outputPath string) error {
 			lastPrint = time.Now()
 		}
 	})
+	if skipped > 0 {
+		fmt.Printf("Skipped %d unchanged repos (use -full to re-enrich everything)\n", skipped)
+	}
 	fmt.Println()
 
 	// Sort alphabetically
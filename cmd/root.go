@@ -1,21 +1,47 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/launcher"
 	"github.com/bnema/turtlectl/internal/logger"
+	"github.com/bnema/turtlectl/internal/offline"
+	"github.com/bnema/turtlectl/internal/settings"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+	"github.com/bnema/turtlectl/internal/wiki"
 )
 
 // Version info set via ldflags at build time
 var (
 	version = "dev"
 	commit  = "unknown"
+	date    = "unknown"
 )
 
-var verbose bool
+var (
+	verbose     bool
+	configFile  string
+	offlineMode bool
+	gameDir     string
+	profileName string
+	logFormat   string
+	forceTUI    bool
+	noTUI       bool
+	assumeYes   bool
+	iconMode    string
+	noColor     bool
+)
 
 var rootCmd = &cobra.Command{
 	Use:     "turtlectl",
@@ -30,17 +56,165 @@ Quick start:
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	logger.Close()
+
+	var exitErr *exitCodeError
+	switch {
+	case errors.As(err, &exitErr):
+		os.Exit(exitErr.code)
+	case err != nil:
 		os.Exit(1)
 	}
-	logger.Close()
+}
+
+// exitCodeError ends the command with a specific exit status, see exitWithCode
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// exitWithCode makes Execute exit with code once the logger is closed, without
+// cobra printing an error or the usage: the command already reported why
+func exitWithCode(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitCodeError{code: code}
 }
 
 func init() {
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := logger.SetFormat(logFormat); err != nil {
+			return err
+		}
+		if !useColor() {
+			styles.DisableColor()
+			logger.DisableColor()
+		}
 		_ = logger.Init(verbose)
+
+		// Flags win over the config file, which wins over env vars
+		cfg, err := loadSettings()
+		if err != nil {
+			return err
+		}
+		applySettings(cfg)
+		if cmd.Flags().Changed("icons") {
+			if err := progress.SetIconMode(iconMode); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("offline") {
+			offline.Set(offlineMode)
+		}
+		dir := gameDir
+		if dir == "" {
+			dir = cfg.GameDir
+		}
+		launcher.SetGameDir(dir)
+		return launcher.SetProfile(profileName)
 	}
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file for defaults (default ~/.config/turtlectl/config.toml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose/debug logging")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Log output format: text or json (or TURTLECTL_LOG_FORMAT)")
+	rootCmd.PersistentFlags().StringVar(&gameDir, "game-dir", "", "Game directory (overrides config game_dir and TURTLE_WOW_GAME_DIR, default ~/Games/turtle-wow)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Profile for separate config, addon store, and backups (or TURTLECTL_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&forceTUI, "tui", false, "Always use interactive TUIs, even without a terminal")
+	rootCmd.PersistentFlags().BoolVar(&noTUI, "no-tui", false, "Never use interactive TUIs, print plain output instead")
+	rootCmd.MarkFlagsMutuallyExclusive("tui", "no-tui")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "Never touch the network, use cached data only (or config offline, TURTLECTL_OFFLINE=1)")
+	rootCmd.PersistentFlags().StringVar(&iconMode, "icons", progress.IconModeAuto, "Progress icons: nerd, ascii, or auto to detect Nerd Font terminals (or TURTLECTL_NERD_FONTS=1/0)")
+	_ = rootCmd.RegisterFlagCompletionFunc("icons", cobra.FixedCompletions(progress.IconModes, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors (or NO_COLOR, off automatically when stdout isn't a terminal)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to every confirmation prompt")
+}
+
+// configPath returns the config file in use: --config, or the default location
+func configPath() string {
+	if configFile != "" {
+		return configFile
+	}
+	return settings.Path()
+}
+
+// loadSettings reads the config file. Only a missing default file is
+// ignored, a file passed with --config must exist
+func loadSettings() (*settings.Settings, error) {
+	cfg, err := settings.Load(configPath())
+	if errors.Is(err, fs.ErrNotExist) && configFile == "" {
+		return &settings.Settings{}, nil
+	}
+	return cfg, err
+}
+
+// applySettings hands the config file defaults to the packages they configure
+// The game dir is applied with --game-dir in PersistentPreRunE
+func applySettings(cfg *settings.Settings) {
+	if cfg.Mirror != "" {
+		launcher.SetDefaultMirror(cfg.Mirror)
+	}
+	if cfg.UpdateConcurrency > 0 {
+		addons.SetUpdateConcurrency(cfg.UpdateConcurrency)
+	}
+	if cfg.NerdFonts != nil {
+		mode := progress.IconModeASCII
+		if *cfg.NerdFonts {
+			mode = progress.IconModeNerd
+		}
+		_ = progress.SetIconMode(mode)
+	}
+	if cfg.Offline != nil {
+		offline.SetDefault(*cfg.Offline)
+	}
+	if len(cfg.SandboxBinds) > 0 {
+		launcher.SetSandboxBinds(cfg.SandboxBinds)
+	}
+	if len(cfg.Registry) > 0 {
+		wiki.SetRegistrySources(cfg.Registry)
+	}
+}
+
+// useTUI reports whether to run a bubbletea TUI: --tui and --no-tui win,
+// then quiet (a command's own --quiet), then whether stdout is a terminal,
+// which it isn't under cron, systemd, pipes, or ssh without a pty
+func useTUI(quiet bool) bool {
+	switch {
+	case noTUI:
+		return false
+	case forceTUI:
+		return true
+	case quiet:
+		return false
+	}
+	return term.IsTerminal(os.Stdout.Fd())
+}
+
+// useColor reports whether to print colors: not with --no-color or NO_COLOR,
+// nor when stdout isn't a terminal so piped output stays free of escape codes
+func useColor() bool {
+	if noColor || styles.NoColorEnv() {
+		return false
+	}
+	return term.IsTerminal(os.Stdout.Fd())
+}
+
+// needsConfirm reports whether a command should ask before going ahead:
+// not when its own --force or the global --yes is set
+func needsConfirm(force bool) bool {
+	return !force && !assumeYes
+}
+
+// confirm asks "Confirm? [y/N]" on stdin and reports whether the answer was yes
+// Without an answer (e.g. stdin closed) it's a no
+func confirm() bool {
+	fmt.Print("\nConfirm? [y/N] ")
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
 }
 
 // getLogger returns the global logger for use in commands
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/selfupdate"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+)
+
+var (
+	selfUpdateCheck bool
+	selfUpdateForce bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update turtlectl itself to the latest release",
+	Long: `Check the latest turtlectl release on GitHub and replace this binary with
+it. The download is verified against the release checksums before the
+binary is swapped atomically, so an interrupted update leaves the old one.
+
+A binary in a system directory such as /usr/bin needs sudo, or better the
+package manager that installed it (e.g. the AUR package). Development
+builds don't know their version, use --force to replace them anyway.
+
+Examples:
+  turtlectl self-update           # Update if a newer release exists
+  turtlectl self-update --check   # Only report the latest version`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		current := currentBuild().Version
+
+		progress.PrintInProgress("Checking the latest turtlectl release")
+		release, err := selfupdate.Latest(ctx)
+		if err != nil {
+			return err
+		}
+
+		newer, known := selfupdate.Newer(release.Version, current)
+		switch {
+		case known && !newer:
+			progress.PrintComplete(fmt.Sprintf("turtlectl %s is the latest release", current))
+			return nil
+		case known:
+			progress.PrintComplete(fmt.Sprintf("turtlectl %s is available (current %s)", release.Version, current))
+		default:
+			progress.PrintComplete(fmt.Sprintf("Latest release is %s (current build %s)", release.Version, current))
+		}
+		if release.URL != "" {
+			progress.PrintDetail(release.URL)
+		}
+
+		if selfUpdateCheck {
+			return nil
+		}
+		if !known && !selfUpdateForce {
+			progress.PrintWarning("Can't compare with a development build, use --force to install the release")
+			return nil
+		}
+
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to locate the turtlectl binary: %w", err)
+		}
+
+		progress.PrintInProgress("Downloading " + release.Version)
+		data, err := release.Download(ctx)
+		if err != nil {
+			return err
+		}
+		progress.PrintComplete("Download verified")
+
+		if err := selfupdate.Replace(exe, data); err != nil {
+			return err
+		}
+		progress.PrintSuccess(fmt.Sprintf("turtlectl updated to %s", release.Version))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheck, "check", false, "Only report the latest version")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Install the latest release over a development build")
+}
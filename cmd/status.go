@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/addons"
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+	"github.com/bnema/turtlectl/internal/wiki"
+)
+
+var statusJSON bool
+
+// statusReport is the output of the status command
+type statusReport struct {
+	AppImage struct {
+		Path            string   `json:"path"`
+		Installed       bool     `json:"installed"`
+		Size            int64    `json:"size,omitempty"`
+		Version         []string `json:"version,omitempty"`
+		HasPrevious     bool     `json:"has_previous"`
+		PreviousVersion []string `json:"previous_version,omitempty"`
+		RolledBack      bool     `json:"rolled_back"`
+	} `json:"appimage"`
+	Desktop struct {
+		Path      string `json:"path"`
+		Installed bool   `json:"installed"`
+		Exec      string `json:"exec,omitempty"`
+		Stale     bool   `json:"stale"` // Exec runs a different turtlectl
+	} `json:"desktop"`
+	Dirs struct {
+		Game  string `json:"game"`
+		Data  string `json:"data"`
+		State string `json:"state"`
+		Cache string `json:"cache"`
+	} `json:"dirs"`
+	Addons struct {
+		Dir       string `json:"dir"`
+		Installed int    `json:"installed"`
+		Tracked   int    `json:"tracked"`
+		Untracked int    `json:"untracked"`
+		Default   int    `json:"default"`
+		Disabled  int    `json:"disabled"`
+		Error     string `json:"error,omitempty"`
+	} `json:"addons"`
+	Registry struct {
+		Cached      bool   `json:"cached"`
+		Stale       bool   `json:"stale"`
+		Age         string `json:"age,omitempty"`
+		TotalAddons int    `json:"total_addons"`
+	} `json:"registry"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show launcher, game, and addon status",
+	Long: `Summarize the current install: launcher AppImage, directories, installed
+addons, and registry cache. Nothing is downloaded or created, so it's safe
+to run when something looks broken and useful to paste into bug reports.
+
+Examples:
+  turtlectl status          # Human-readable summary
+  turtlectl status --json   # JSON output for scripting`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report := collectStatus()
+
+		if statusJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(report)
+		}
+
+		printStatus(report)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output as JSON")
+}
+
+// collectStatus gathers the report without touching the network or creating directories
+func collectStatus() statusReport {
+	var report statusReport
+	l := launcher.New(getLogger())
+
+	report.AppImage.Path = l.AppImagePath
+	if info, err := os.Stat(l.AppImagePath); err == nil {
+		report.AppImage.Installed = true
+		report.AppImage.Size = info.Size()
+		if cached, err := l.CachedAppImageInfo(); err == nil && cached.Size == info.Size() {
+			report.AppImage.Version = cached.Tags
+		}
+	}
+
+	report.AppImage.HasPrevious = l.HasPreviousAppImage()
+	if prev, err := l.CachedPreviousAppImageInfo(); err == nil && report.AppImage.HasPrevious {
+		report.AppImage.PreviousVersion = prev.Tags
+	}
+	report.AppImage.RolledBack = l.RolledBack()
+
+	report.Desktop.Path = l.DesktopFilePath()
+	if execPath, err := l.DesktopExec(); err == nil {
+		report.Desktop.Installed = true
+		report.Desktop.Exec = execPath
+		_, report.Desktop.Stale = l.StaleDesktopExec()
+	}
+
+	report.Dirs.Game = l.GameDir
+	report.Dirs.Data = l.DataDir
+	report.Dirs.State = l.StateDir
+	report.Dirs.Cache = l.CacheDir
+
+	manager := newAddonManager(l)
+	if err := manager.Load(); err != nil {
+		getLogger().Debug("Failed to load addon store", "error", err)
+	}
+	report.Addons.Dir = manager.GetAddonsDir()
+	if installed, err := manager.ListInstalled(); err != nil {
+		report.Addons.Error = err.Error()
+	} else {
+		report.Addons.Installed = len(installed)
+		for _, addon := range installed {
+			switch {
+			case addon.Disabled:
+				report.Addons.Disabled++
+			case addons.IsDefaultAddon(addon.Name):
+				report.Addons.Default++
+			case addon.GitURL != "":
+				report.Addons.Tracked++
+			default:
+				report.Addons.Untracked++
+			}
+		}
+	}
+
+	info := wiki.NewRegistry(l.CacheDir, getLogger()).GetInfo()
+	report.Registry.Cached = info.HasCache
+	if info.HasCache {
+		report.Registry.Stale = info.IsStale
+		report.Registry.Age = formatAge(info.Age)
+		report.Registry.TotalAddons = info.TotalAddons
+	}
+
+	return report
+}
+
+// printStatus prints a human-readable status report
+func printStatus(r statusReport) {
+	fmt.Println(styles.Title.Render("Launcher"))
+	if r.AppImage.Installed {
+		version := "unknown"
+		if len(r.AppImage.Version) > 0 {
+			version = strings.Join(r.AppImage.Version, ", ")
+		}
+		fmt.Printf("  AppImage:  %s (%.1f MB, version %s)\n", r.AppImage.Path, float64(r.AppImage.Size)/1024/1024, version)
+	} else {
+		fmt.Printf("  AppImage:  %s\n", styles.FormatWarning("not installed (run 'turtlectl install')"))
+	}
+	if r.AppImage.HasPrevious {
+		previous := "version " + formatTags(r.AppImage.PreviousVersion)
+		if r.AppImage.RolledBack {
+			previous += ", rolled back (updates held until 'turtlectl update')"
+		}
+		fmt.Printf("  Previous:  %s\n", previous)
+	}
+
+	switch {
+	case !r.Desktop.Installed:
+		fmt.Printf("  Desktop:   %s\n", styles.FormatWarning("no menu entry (run 'turtlectl install')"))
+	case r.Desktop.Stale:
+		fmt.Printf("  Desktop:   %s\n", styles.FormatWarning("runs "+r.Desktop.Exec+", not this turtlectl (run 'turtlectl install')"))
+	default:
+		fmt.Printf("  Desktop:   %s\n", r.Desktop.Path)
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render("Directories"))
+	fmt.Printf("  Game:   %s%s\n", r.Dirs.Game, missingSuffix(r.Dirs.Game))
+	fmt.Printf("  Data:   %s%s\n", r.Dirs.Data, missingSuffix(r.Dirs.Data))
+	fmt.Printf("  State:  %s%s\n", r.Dirs.State, missingSuffix(r.Dirs.State))
+	fmt.Printf("  Cache:  %s%s\n", r.Dirs.Cache, missingSuffix(r.Dirs.Cache))
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render("Addons"))
+	fmt.Printf("  Directory:  %s\n", r.Addons.Dir)
+	if r.Addons.Error != "" {
+		fmt.Printf("  %s\n", styles.FormatWarning(r.Addons.Error))
+	} else {
+		fmt.Printf("  Installed:  %d (%d tracked, %d untracked, %d default, %d disabled)\n",
+			r.Addons.Installed, r.Addons.Tracked, r.Addons.Untracked, r.Addons.Default, r.Addons.Disabled)
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render("Registry"))
+	if r.Registry.Cached {
+		stale := ""
+		if r.Registry.Stale {
+			stale = " (stale)"
+		}
+		fmt.Printf("  Cache:   %d addons, %s old%s\n", r.Registry.TotalAddons, r.Registry.Age, stale)
+	} else {
+		fmt.Println("  Cache:   none (run 'turtlectl addons explore')")
+	}
+}
+
+// formatAge formats a duration in the largest sensible unit
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// missingSuffix marks directories that don't exist yet
+func missingSuffix(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return " " + styles.FormatWarning("missing")
+	}
+	return ""
+}
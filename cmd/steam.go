@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/steam"
+	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var steamUser string
+
+var steamCmd = &cobra.Command{
+	Use:   "steam",
+	Short: "Integrate with Steam",
+	Long: `Add Turtle WoW to Steam as a non-Steam game, for Steam Input controller
+support and the overlay.
+
+Examples:
+  turtlectl steam add                 # Add the shortcut for the last Steam user
+  turtlectl --profile alt steam add   # Separate shortcut launching the "alt" profile`,
+}
+
+var steamAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a Turtle WoW shortcut to Steam",
+	Long: `Write a non-Steam game shortcut running 'turtlectl launch' into Steam's
+shortcuts.vdf, with the icon from the AppImage. Running it again updates the
+shortcut, e.g. after turtlectl moved. shortcuts.vdf is backed up first.
+
+Native and Flatpak Steam are detected; Flatpak Steam runs turtlectl on the
+host through flatpak-spawn. With several Steam accounts the most recently
+used one is picked, use --user to choose another.
+
+Restart Steam afterwards to see the shortcut. Quit Steam before adding it,
+a running Steam may write its own copy of the shortcuts back.
+
+Examples:
+  turtlectl steam add
+  turtlectl steam add --user 12345678`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := launcher.New(getLogger())
+
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		users := steam.FindUsers(home)
+		if len(users) == 0 {
+			return steam.ErrNoSteam
+		}
+		user := users[0]
+		if steamUser != "" {
+			found := false
+			for _, u := range users {
+				if u.ID == steamUser {
+					user, found = u, true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("steam user %s not found", steamUser)
+			}
+		} else if len(users) > 1 {
+			progress.PrintDetail(fmt.Sprintf("Found %d Steam users, using %s (pick one with --user)", len(users), user.ID))
+		}
+
+		if steam.Running() {
+			progress.PrintWarning("Steam is running, quit it first or it may overwrite the shortcut")
+		}
+
+		icon, err := l.ExtractIcon()
+		if err != nil {
+			progress.PrintWarning("No icon for the shortcut: " + err.Error())
+		}
+
+		shortcut := steam.Shortcut{
+			AppName: "Turtle WoW",
+			Exe:     l.ScriptPath,
+			Args:    "launch",
+			Icon:    icon,
+		}
+		if l.Profile != launcher.DefaultProfile {
+			shortcut.AppName += " (" + l.Profile + ")"
+			shortcut.Args = "--profile " + l.Profile + " launch"
+		}
+
+		backup, updated, err := steam.AddShortcut(user, shortcut)
+		if errors.Is(err, steam.ErrInvalidVDF) {
+			return fmt.Errorf("%w, left untouched", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if backup != "" {
+			progress.PrintDetail("Backed up shortcuts to " + backup)
+		}
+		verb := "added to"
+		if updated {
+			verb = "updated in"
+		}
+		fmt.Println(styles.FormatSuccess(fmt.Sprintf("%s %s Steam (user %s)", shortcut.AppName, verb, user.ID)))
+		fmt.Println(styles.FormatWarning("Restart Steam to see the shortcut"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(steamCmd)
+	steamCmd.AddCommand(steamAddCmd)
+
+	steamAddCmd.Flags().StringVar(&steamUser, "user", "", "Steam account id (userdata directory name)")
+}
@@ -1,38 +1,121 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/launcher"
 	"github.com/bnema/turtlectl/internal/ui/progress"
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+var (
+	uninstallAppImage bool
+	uninstallClean    bool
+	uninstallAll      bool
 )
 
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall",
-	Short: "Remove desktop file (keeps game data)",
+	Short: "Remove desktop file, optionally the AppImage and data (keeps game data)",
+	Long: `Undo 'turtlectl install'. By default only the desktop entry and icons
+are removed; the AppImage, launcher config and game files are kept.
+
+  --appimage  also delete the AppImage (and the one kept for rollback)
+  --clean     also delete launcher config, addon store, logs and cache,
+              like 'turtlectl clean'
+  --all       --clean plus the game files, addons and SavedVariables,
+              like 'turtlectl clean --all' (asks first unless --yes)
+
+Everything kept is listed afterwards.
+
+Examples:
+  turtlectl uninstall              # Menu entry only
+  turtlectl uninstall --appimage   # Menu entry and AppImage
+  turtlectl uninstall --clean      # Everything but the game files
+  turtlectl uninstall --all        # Everything`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		l := launcher.New(getLogger())
+		clean := uninstallClean || uninstallAll
+
+		if uninstallAll && needsConfirm(false) {
+			fmt.Println("Uninstalling with --all will delete:")
+			printCleanTarget("Data", l.DataDir)
+			printCleanTarget("State", l.StateDir)
+			printCleanTarget("Cache", l.CacheDir)
+			printCleanTarget("Game", l.GameDir)
+			fmt.Println("  and the desktop entry and icons")
+			fmt.Println(styles.FormatWarning("Addons, SavedVariables and screenshots will be lost too!"))
+			if !confirm() {
+				fmt.Println("Cancelled.")
+				return
+			}
+			fmt.Println()
+		}
 
-		progress.PrintTitle("Uninstalling Desktop Integration")
+		progress.PrintTitle("Uninstalling Turtle WoW")
 
 		progress.PrintInProgress("Removing desktop file")
 		if err := l.UninstallDesktop(); err != nil {
 			progress.PrintError("Failed to uninstall: " + err.Error())
 			os.Exit(1)
 		}
+		progress.PrintComplete("Desktop file and icons removed")
 
-		progress.PrintComplete("Desktop file removed")
-		progress.PrintComplete("Icon removed")
+		switch {
+		case clean:
+			progress.PrintInProgress("Removing launcher data")
+			if err := l.Clean(uninstallAll); err != nil {
+				progress.PrintError("Failed to clean: " + err.Error())
+				os.Exit(1)
+			}
+			progress.PrintComplete("Data, state and cache directories removed (with the AppImage)")
+			if uninstallAll {
+				progress.PrintComplete("Game files removed")
+			}
+		case uninstallAppImage:
+			progress.PrintInProgress("Removing AppImage")
+			removed, err := l.RemoveAppImage()
+			if err != nil {
+				progress.PrintError("Failed to remove AppImage: " + err.Error())
+				os.Exit(1)
+			}
+			if len(removed) == 0 {
+				progress.PrintComplete("No AppImage to remove")
+			} else {
+				progress.PrintComplete(fmt.Sprintf("AppImage removed (%d file(s))", len(removed)))
+			}
+		}
 
 		progress.PrintNewline()
-		progress.PrintWarning("AppImage and config kept")
-		progress.PrintDetail("Cache: " + l.CacheDir)
-		progress.PrintDetail("Data: " + l.DataDir)
+		var kept []string
+		if !clean && !uninstallAppImage {
+			kept = append(kept, "AppImage: "+l.AppImagePath)
+		}
+		if !clean {
+			kept = append(kept, "Data: "+l.DataDir, "State: "+l.StateDir, "Cache: "+l.CacheDir)
+		}
+		if !uninstallAll {
+			kept = append(kept, "Game: "+l.GameDir)
+		}
+		if len(kept) == 0 {
+			progress.PrintSuccess("Everything removed")
+			return
+		}
+		progress.PrintWarning("Kept")
+		for _, path := range kept {
+			progress.PrintDetail(path)
+		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(uninstallCmd)
+
+	uninstallCmd.Flags().BoolVar(&uninstallAppImage, "appimage", false, "Also remove the AppImage")
+	uninstallCmd.Flags().BoolVar(&uninstallClean, "clean", false, "Also remove launcher config, addon store, logs and cache (like clean)")
+	uninstallCmd.Flags().BoolVar(&uninstallAll, "all", false, "Also remove game files and addons (like clean --all)")
 }
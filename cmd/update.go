@@ -2,19 +2,45 @@ package cmd
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/turtlectl/internal/launcher"
+	"github.com/bnema/turtlectl/internal/offline"
 	"github.com/bnema/turtlectl/internal/ui/progress"
 )
 
+var (
+	updateMirror   string
+	updateRollback bool
+)
+
 var updateCmd = &cobra.Command{
 	Use:     "update",
 	Aliases: []string{"u"},
 	Short:   "Update the launcher AppImage only",
+	Long: `Update the launcher AppImage only. The AppImage being replaced is kept as
+TurtleWoW.AppImage.prev, so a launcher update that breaks something can be
+undone with --rollback. Launch won't update again after a rollback until
+the next turtlectl update.
+
+Examples:
+  turtlectl update              # Update to the latest launcher
+  turtlectl update --rollback   # Go back to the previous launcher`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if updateRollback {
+			runRollback()
+			return
+		}
+
+		if offline.Enabled() {
+			progress.PrintError(offline.ErrOffline.Error())
+			os.Exit(1)
+		}
+
 		l := launcher.New(getLogger())
+		l.Mirror = updateMirror
 
 		progress.PrintTitle("Updating Turtle WoW Launcher")
 
@@ -26,20 +52,59 @@ var updateCmd = &cobra.Command{
 		progress.PrintComplete("Directories ready")
 
 		progress.PrintInProgress("Checking for updates")
+		l.ClearRollback()
 		result, err := l.UpdateAppImageWithProgress(nil)
 		if err != nil {
 			progress.PrintError("Failed to update: " + err.Error())
 			os.Exit(1)
 		}
 
-		if result != nil && result.AlreadyLatest {
+		if result != nil && result.DownloadErr != nil {
+			progress.PrintError("Failed to download the update, kept the existing AppImage: " + result.DownloadErr.Error())
+			os.Exit(1)
+		} else if result != nil && result.AlreadyLatest {
 			progress.PrintComplete("Already up to date")
 		} else {
-			progress.PrintComplete("Launcher updated")
+			msg := "Launcher updated"
+			if result != nil && result.Mirror != "" {
+				msg += " from " + result.Mirror
+			}
+			if result != nil && result.Verified {
+				msg += " (" + result.HashAlgorithm + " verified)"
+			}
+			progress.PrintComplete(msg)
 		}
 	},
 }
 
+// runRollback swaps back to the AppImage kept by the last update
+func runRollback() {
+	l := launcher.New(getLogger())
+
+	progress.PrintTitle("Rolling back Turtle WoW Launcher")
+
+	result, err := l.Rollback()
+	if err != nil {
+		progress.PrintError("Failed to roll back: " + err.Error())
+		os.Exit(1)
+	}
+
+	progress.PrintComplete("Rolled back to version " + formatTags(result.Version))
+	progress.PrintDetail("Version " + formatTags(result.Replaced) + " kept, run 'turtlectl update --rollback' again to return to it")
+	progress.PrintDetail("Launch won't update until the next 'turtlectl update'")
+}
+
+// formatTags joins AppImage version tags, or "unknown" without any
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "unknown"
+	}
+	return strings.Join(tags, ", ")
+}
+
 func init() {
 	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().BoolVar(&updateRollback, "rollback", false, "Swap back to the AppImage replaced by the last update")
+	updateCmd.Flags().StringVar(&updateMirror, "mirror", "", "Preferred download mirror (default: preferences.json, then "+launcher.DefaultMirror+")")
 }
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+var versionOutput string
+
+// buildInfo is the output of the version command
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// currentBuild returns the ldflags build metadata, falling back to the VCS
+// stamp Go embeds for builds without them (go install, plain go build)
+func currentBuild() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "unknown" && len(s.Value) >= 7:
+			info.Commit = s.Value[:7]
+		case s.Key == "vcs.time" && info.BuildDate == "unknown":
+			info.BuildDate = s.Value
+		}
+	}
+	return info
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Long: `Print the turtlectl version, commit, build date, Go version and
+platform, e.g. to include in bug reports. --version prints the short form.
+
+Examples:
+  turtlectl version           # Human-readable
+  turtlectl version -o json   # JSON for scripts and issue templates`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := currentBuild()
+
+		switch versionOutput {
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(info)
+		case "text":
+			fmt.Printf("turtlectl %s\n", info.Version)
+			fmt.Printf("  Commit:  %s\n", info.Commit)
+			fmt.Printf("  Built:   %s\n", info.BuildDate)
+			fmt.Printf("  Go:      %s\n", info.GoVersion)
+			fmt.Printf("  OS/Arch: %s/%s\n", info.OS, info.Arch)
+			return nil
+		default:
+			return fmt.Errorf("invalid output %q (text or json)", versionOutput)
+		}
+	},
+}
+
+func init() {
+	// --version is the short form, with the same fallbacks
+	build := currentBuild()
+	rootCmd.Version = build.Version + " (" + build.Commit + ")"
+
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", "Output format: text or json")
+	_ = versionCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
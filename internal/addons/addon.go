@@ -4,22 +4,41 @@ import "time"
 
 // Addon represents an installed WoW addon
 type Addon struct {
-	Name        string    `json:"name"`         // Folder name (e.g., "pfQuest")
-	Title       string    `json:"title"`        // From .toc: ## Title
-	Version     string    `json:"version"`      // From .toc: ## Version
-	Author      string    `json:"author"`       // From .toc: ## Author
-	Notes       string    `json:"notes"`        // From .toc: ## Notes
-	GitURL      string    `json:"git_url"`      // Source repository URL
-	Path        string    `json:"path"`         // Full path to addon folder
-	InstalledAt time.Time `json:"installed_at"` // When the addon was installed
-	UpdatedAt   time.Time `json:"updated_at"`   // When the addon was last updated
+	Name         string    `json:"name"`                   // Folder name (e.g., "pfQuest")
+	Title        string    `json:"title"`                  // From .toc: ## Title
+	Version      string    `json:"version"`                // From .toc: ## Version
+	Author       string    `json:"author"`                 // From .toc: ## Author
+	Notes        string    `json:"notes"`                  // From .toc: ## Notes
+	Dependencies []string  `json:"dependencies,omitempty"` // From .toc: ## Dependencies / ## RequiredDeps
+	Interface    string    `json:"interface,omitempty"`    // From .toc: ## Interface
+	Website      string    `json:"website,omitempty"`      // From .toc: ## X-Website
+	Category     string    `json:"category,omitempty"`     // From .toc: ## X-Category
+	GitURL       string    `json:"git_url"`                // Source repository URL
+	Ref          string    `json:"ref,omitempty"`          // Pinned branch, tag, or commit
+	Pack         string    `json:"pack,omitempty"`         // Multi-addon repository it was installed from
+	Path         string    `json:"path"`                   // Full path to addon folder
+	InstalledAt  time.Time `json:"installed_at"`           // When the addon was installed
+	UpdatedAt    time.Time `json:"updated_at"`             // When the addon was last updated
+
+	InterfaceWarning string `json:"interface_warning,omitempty"` // Set when Interface doesn't match the client
+	Disabled         bool   `json:"disabled,omitempty"`          // Folder lives in the disabled directory
+	PinnedCommit     string `json:"pinned_commit,omitempty"`     // Frozen at this commit, see Manager.Pin
+
+	Size    int64 `json:"size,omitempty"`     // Bytes on disk excluding .git (set by LoadSizes)
+	GitSize int64 `json:"git_size,omitempty"` // Bytes in the folder's .git directory (set by LoadSizes)
 }
 
 // AddonMetadata is stored in addons.json for tracking
 type AddonMetadata struct {
-	GitURL      string    `json:"git_url"`
-	InstalledAt time.Time `json:"installed_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	GitURL       string            `json:"git_url"`
+	Ref          string            `json:"ref,omitempty"`           // Pinned branch, tag, or commit (empty = default branch)
+	LocalSource  string            `json:"local_source,omitempty"`  // Folder or zip the addon was installed from
+	Pack         string            `json:"pack,omitempty"`          // Shared clone of a multi-addon repo (Interface/AddOnPacks)
+	Disabled     bool              `json:"disabled,omitempty"`      // Moved out of Interface/AddOns
+	PinnedCommit string            `json:"pinned_commit,omitempty"` // Frozen at this commit by Pin, not updated
+	Checksums    map[string]string `json:"checksums,omitempty"`     // SHA-256 of each file at install, for addons not installed from git
+	InstalledAt  time.Time         `json:"installed_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
 }
 
 // Store represents the persistent addon metadata storage
@@ -33,6 +52,7 @@ type RepairResult struct {
 	UntrackedAddons []string // Folder exists but no metadata
 	CorruptedRepos  []string // Git repo is corrupted
 	NameMismatches  []string // Folder name doesn't match .toc
+	FolderConflicts []string // Folder claimed by more than one repository
 	TotalScanned    int
 	IssuesFound     int
 }
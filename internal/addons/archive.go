@@ -0,0 +1,214 @@
+package addons
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// backupArchiveExt is the extension of addon folder backups
+	backupArchiveExt = ".tar.gz"
+
+	// backupSourceFile records the git remote of a backup taken without .git,
+	// so a restored addon stays tracked
+	backupSourceFile = ".turtlectl-source"
+)
+
+// backupGit keeps .git directories in addon backups, see SetBackupGit
+var backupGit bool
+
+// SetBackupGit sets whether addon backups include .git directories
+// They are left out by default since a repository can be cloned again
+func SetBackupGit(enabled bool) {
+	backupGit = enabled
+}
+
+// writeBackupArchive streams dir into a gzipped tar at archivePath
+// .git is skipped unless SetBackupGit(true), its remote URL is then recorded
+// in backupSourceFile. Symlinks are skipped
+func writeBackupArchive(dir, archivePath string) (err error) {
+	tmpPath := archivePath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" && !backupGit {
+			return filepath.SkipDir
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFileTo(tw, path)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !backupGit {
+		if url, urlErr := GetRepoRemoteURL(dir); urlErr == nil {
+			if err = writeTarFile(tw, backupSourceFile, url+"\n"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, archivePath)
+}
+
+// copyFileTo copies the file at path into w
+func copyFileTo(w io.Writer, path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	_, err = io.Copy(w, src)
+	return err
+}
+
+// writeTarFile adds a regular file with the given content to tw
+func writeTarFile(tw *tar.Writer, name, content string) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.WriteString(tw, content)
+	return err
+}
+
+// extractBackupArchive extracts a backup made by writeBackupArchive into
+// destDir, rejecting entries that escape it
+func extractBackupArchive(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	destDir = filepath.Clean(destDir)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if target != destDir && !strings.HasPrefix(target, destDir+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal path in archive: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := extractTarFile(tr, target, header.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractTarFile writes the current entry of tr to target
+func extractTarFile(tr *tar.Reader, target string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dst.Close() }()
+
+	_, err = io.Copy(dst, tr)
+	return err
+}
+
+// takeBackupSource returns the git remote recorded in a restored addon and
+// removes the record, empty if there is none
+func takeBackupSource(addonPath string) string {
+	path := filepath.Join(addonPath, backupSourceFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	_ = os.Remove(path)
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,130 @@
+package addons
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+var (
+	ErrCredentialsInURL = errors.New("credentials must not be embedded in the URL, use --token or GITHUB_TOKEN instead")
+)
+
+// authToken is an explicit HTTPS token for authTokenHost, taking precedence over the environment
+var (
+	authToken     string
+	authTokenHost string
+)
+
+// SetAuthToken sets the token used for HTTPS clones and fetches from host
+func SetAuthToken(token, host string) {
+	authToken = strings.TrimSpace(token)
+	authTokenHost = strings.TrimSpace(host)
+}
+
+// GitURLHost returns the host of a git URL, or "" if it can't be parsed
+func GitURLHost(rawURL string) string {
+	ep, err := transport.NewEndpoint(rawURL)
+	if err != nil {
+		return ""
+	}
+	return ep.Host
+}
+
+// httpsToken returns the token to use for an HTTPS host
+// Tokens are only sent to the host they are meant for, the explicit one to
+// authTokenHost and GITHUB_TOKEN/GH_TOKEN to github.com, so they don't leak to other hosts
+func httpsToken(host string) string {
+	if authToken != "" && strings.EqualFold(host, authTokenHost) {
+		return authToken
+	}
+	if !strings.EqualFold(host, "github.com") {
+		return ""
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
+}
+
+// authForURL returns the auth method for a git URL, or nil for anonymous access
+func authForURL(rawURL string) transport.AuthMethod {
+	ep, err := transport.NewEndpoint(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	switch ep.Protocol {
+	case "https", "http":
+		token := httpsToken(ep.Host)
+		if token == "" {
+			return nil
+		}
+		// GitLab expects "oauth2", GitHub accepts any non-empty username
+		username := "x-access-token"
+		if strings.Contains(strings.ToLower(ep.Host), "gitlab") {
+			username = "oauth2"
+		}
+		return &githttp.BasicAuth{Username: username, Password: token}
+
+	case "ssh":
+		return sshAuth(ep.User)
+	}
+
+	return nil
+}
+
+// sshAuth uses the SSH agent when available, otherwise the first unencrypted default key
+func sshAuth(user string) transport.AuthMethod {
+	if user == "" {
+		user = "git"
+	}
+
+	if os.Getenv("SSH_AUTH_SOCK") != "" {
+		if auth, err := gitssh.NewSSHAgentAuth(user); err == nil {
+			return auth
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		keyPath := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(keyPath); err != nil {
+			continue
+		}
+		if auth, err := gitssh.NewPublicKeysFromFile(user, keyPath, ""); err == nil {
+			return auth
+		}
+	}
+
+	return nil
+}
+
+// remoteAuth returns the auth method for a repository's origin remote
+func remoteAuth(repo *git.Repository) transport.AuthMethod {
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return nil
+	}
+	return authForURL(remote.Config().URLs[0])
+}
+
+// hasURLCredentials reports whether an HTTPS URL embeds a password or token
+func hasURLCredentials(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return false
+	}
+	_, hasPassword := u.User.Password()
+	return hasPassword || (u.Scheme != "ssh" && u.User.Username() != "")
+}
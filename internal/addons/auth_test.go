@@ -0,0 +1,31 @@
+package addons
+
+import "testing"
+
+func TestHTTPSTokenScopedToHost(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("GH_TOKEN", "")
+	t.Cleanup(func() { SetAuthToken("", "") })
+
+	SetAuthToken("flag-token", "gitlab.com")
+	tests := []struct {
+		host, want string
+	}{
+		{"gitlab.com", "flag-token"},
+		{"GitLab.com", "flag-token"},
+		{"github.com", "env-token"},
+		{"git.example.com", ""},
+	}
+	for _, tt := range tests {
+		if got := httpsToken(tt.host); got != tt.want {
+			t.Errorf("httpsToken(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+
+	if got := GitURLHost("https://gitlab.com/guild/addon.git"); got != "gitlab.com" {
+		t.Errorf("GitURLHost() = %q, want gitlab.com", got)
+	}
+	if got := GitURLHost("git@github.com:guild/addon.git"); got != "github.com" {
+		t.Errorf("GitURLHost() = %q, want github.com", got)
+	}
+}
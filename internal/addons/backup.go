@@ -1,10 +1,12 @@
 package addons
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -15,6 +17,9 @@ const (
 	MaxBackupsPerAddon = 3
 	// BackupTimestampFormat is the format used for backup directory names
 	BackupTimestampFormat = "20060102-150405"
+
+	// savedVariablesBackupDir is the folder holding an addon's SavedVariables backups
+	savedVariablesBackupDir = "savedvariables"
 )
 
 // BackupManager handles addon backups
@@ -29,7 +34,13 @@ func NewBackupManager(dataDir string) *BackupManager {
 	}
 }
 
-// CreateBackup creates a backup of an addon directory
+// GetBackupDir returns the directory holding every addon backup
+func (bm *BackupManager) GetBackupDir() string {
+	return bm.backupDir
+}
+
+// CreateBackup creates a compressed backup of an addon directory
+// .git is left out unless SetBackupGit(true), see writeBackupArchive
 func (bm *BackupManager) CreateBackup(addonPath, addonName string) (string, error) {
 	// Create backup directory structure
 	addonBackupDir := filepath.Join(bm.backupDir, addonName)
@@ -37,14 +48,11 @@ func (bm *BackupManager) CreateBackup(addonPath, addonName string) (string, erro
 		return "", fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	// Create timestamped backup folder
+	// Create timestamped backup archive
 	timestamp := time.Now().Format(BackupTimestampFormat)
-	backupPath := filepath.Join(addonBackupDir, timestamp)
+	backupPath := filepath.Join(addonBackupDir, timestamp+backupArchiveExt)
 
-	// Copy the addon directory
-	if err := copyDir(addonPath, backupPath); err != nil {
-		// Cleanup on failure
-		_ = os.RemoveAll(backupPath)
+	if err := writeBackupArchive(addonPath, backupPath); err != nil {
 		return "", fmt.Errorf("failed to backup addon: %w", err)
 	}
 
@@ -57,11 +65,17 @@ func (bm *BackupManager) CreateBackup(addonPath, addonName string) (string, erro
 	return backupPath, nil
 }
 
-// RestoreBackup restores an addon from a backup
+// RestoreBackup restores an addon from a backup, an archive or a folder
+// taken by older versions
 func (bm *BackupManager) RestoreBackup(addonName string, backupTimestamp string, destPath string) error {
-	backupPath := filepath.Join(bm.backupDir, addonName, backupTimestamp)
-
-	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+	if err := checkBackupName(addonName); err != nil {
+		return err
+	}
+	backupPath, err := findBackup(filepath.Join(bm.backupDir, addonName), backupTimestamp)
+	if err != nil {
+		if errors.Is(err, ErrInvalidBackup) {
+			return err
+		}
 		return fmt.Errorf("backup not found: %s", backupTimestamp)
 	}
 
@@ -73,7 +87,12 @@ func (bm *BackupManager) RestoreBackup(addonName string, backupTimestamp string,
 	}
 
 	// Copy backup to destination
-	if err := copyDir(backupPath, destPath); err != nil {
+	if strings.HasSuffix(backupPath, backupArchiveExt) {
+		err = extractBackupArchive(backupPath, destPath)
+	} else {
+		err = copyDir(backupPath, destPath)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to restore backup: %w", err)
 	}
 
@@ -82,9 +101,24 @@ func (bm *BackupManager) RestoreBackup(addonName string, backupTimestamp string,
 
 // ListBackups lists all available backups for an addon
 func (bm *BackupManager) ListBackups(addonName string) ([]string, error) {
-	addonBackupDir := filepath.Join(bm.backupDir, addonName)
+	return listTimestampDirs(filepath.Join(bm.backupDir, addonName))
+}
 
-	entries, err := os.ReadDir(addonBackupDir)
+// ListSavedVariablesBackups lists the SavedVariables backups of an addon, newest first
+func (bm *BackupManager) ListSavedVariablesBackups(addonName string) ([]string, error) {
+	return listTimestampDirs(bm.savedVariablesDir(addonName))
+}
+
+// savedVariablesDir is where the SavedVariables backups of an addon are kept
+func (bm *BackupManager) savedVariablesDir(addonName string) string {
+	return filepath.Join(bm.backupDir, addonName, savedVariablesBackupDir)
+}
+
+// listTimestampDirs lists the timestamps of the backups in dir, newest first
+// Backups are timestamped folders or archives, other folders such as
+// "savedvariables" are skipped and a missing dir has none
+func listTimestampDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []string{}, nil
@@ -92,17 +126,86 @@ func (bm *BackupManager) ListBackups(addonName string) ([]string, error) {
 		return nil, err
 	}
 
-	var backups []string
+	var stamps []string
 	for _, entry := range entries {
-		if entry.IsDir() {
-			backups = append(backups, entry.Name())
+		stamp := entry.Name()
+		if !entry.IsDir() {
+			var ok bool
+			if stamp, ok = strings.CutSuffix(stamp, backupArchiveExt); !ok {
+				continue
+			}
+		}
+		if _, err := time.Parse(BackupTimestampFormat, stamp); err != nil {
+			continue
+		}
+		if !slices.Contains(stamps, stamp) {
+			stamps = append(stamps, stamp)
 		}
 	}
 
 	// Sort by timestamp (newest first)
-	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	sort.Sort(sort.Reverse(sort.StringSlice(stamps)))
+
+	return stamps, nil
+}
+
+// backupPaths returns where a backup taken at stamp can be in dir: an
+// archive, or a folder for SavedVariables and older addon backups
+func backupPaths(dir, stamp string) []string {
+	return []string{
+		filepath.Join(dir, stamp+backupArchiveExt),
+		filepath.Join(dir, stamp),
+	}
+}
 
-	return backups, nil
+// findBackup returns the path of the backup taken at stamp in dir
+// stamp must be in BackupTimestampFormat so it can't point outside dir
+func findBackup(dir, stamp string) (string, error) {
+	if err := checkBackupStamp(stamp); err != nil {
+		return "", err
+	}
+	for _, path := range backupPaths(dir, stamp) {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", os.ErrNotExist
+}
+
+// removeBackup deletes the backup taken at stamp in dir, whatever its form
+// stamp is checked like in findBackup before anything is removed
+func removeBackup(dir, stamp string) error {
+	if err := checkBackupStamp(stamp); err != nil {
+		return err
+	}
+	for _, path := range backupPaths(dir, stamp) {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBackedUpAddons returns the names of addons with at least one backup, sorted
+func (bm *BackupManager) ListBackedUpAddons() ([]string, error) {
+	entries, err := os.ReadDir(bm.backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if backups, err := bm.ListBackups(entry.Name()); err == nil && len(backups) > 0 {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
 }
 
 // GetLatestBackup returns the most recent backup for an addon
@@ -121,12 +224,17 @@ func (bm *BackupManager) GetLatestBackup(addonName string) (string, error) {
 
 // DeleteBackup deletes a specific backup
 func (bm *BackupManager) DeleteBackup(addonName, timestamp string) error {
-	backupPath := filepath.Join(bm.backupDir, addonName, timestamp)
-	return os.RemoveAll(backupPath)
+	if err := checkBackupName(addonName); err != nil {
+		return err
+	}
+	return removeBackup(filepath.Join(bm.backupDir, addonName), timestamp)
 }
 
 // DeleteAllBackups deletes all backups for an addon
 func (bm *BackupManager) DeleteAllBackups(addonName string) error {
+	if err := checkBackupName(addonName); err != nil {
+		return err
+	}
 	addonBackupDir := filepath.Join(bm.backupDir, addonName)
 	return os.RemoveAll(addonBackupDir)
 }
@@ -153,10 +261,20 @@ func (bm *BackupManager) cleanupOldBackups(addonName string) error {
 }
 
 // BackupSavedVariables creates a backup of SavedVariables for an addon
+// Account-wide and per-character files are both copied, keeping their path
+// relative to WTF so files from different accounts/characters don't collide
+// Returns an empty path if the addon has no SavedVariables
+// Old backups aren't pruned here, see PruneSavedVariables
 func (bm *BackupManager) BackupSavedVariables(gameDir, addonName string) (string, error) {
-	svDir := filepath.Join(gameDir, "WTF", "Account")
+	wtfDir := filepath.Join(gameDir, "WTF")
+	svDir := filepath.Join(wtfDir, "Account")
+
+	// SavedVariables are named after the addon folder (plus the game's .bak copies)
+	wanted := map[string]bool{
+		strings.ToLower(addonName + ".lua"):     true,
+		strings.ToLower(addonName + ".lua.bak"): true,
+	}
 
-	// Find SavedVariables files matching the addon name
 	var svFiles []string
 	err := filepath.Walk(svDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -166,9 +284,7 @@ func (bm *BackupManager) BackupSavedVariables(gameDir, addonName string) (string
 			return nil
 		}
 
-		name := info.Name()
-		// Match addon name in SavedVariables files
-		if strings.HasPrefix(name, addonName) && strings.HasSuffix(name, ".lua") {
+		if wanted[strings.ToLower(info.Name())] && strings.EqualFold(filepath.Base(filepath.Dir(path)), "SavedVariables") {
 			svFiles = append(svFiles, path)
 		}
 
@@ -183,16 +299,26 @@ func (bm *BackupManager) BackupSavedVariables(gameDir, addonName string) (string
 		return "", nil // No SavedVariables to backup
 	}
 
-	// Create backup directory
-	timestamp := time.Now().Format(BackupTimestampFormat)
-	backupPath := filepath.Join(bm.backupDir, addonName, "savedvariables", timestamp)
-	if err := os.MkdirAll(backupPath, 0755); err != nil {
+	// Create backup directory, never reusing one taken in the same second
+	svBackupDir := bm.savedVariablesDir(addonName)
+	if err := os.MkdirAll(svBackupDir, 0755); err != nil {
+		return "", err
+	}
+	backupPath, err := newTimestampDir(svBackupDir, time.Now())
+	if err != nil {
 		return "", err
 	}
 
 	// Copy SavedVariables files
 	for _, svFile := range svFiles {
-		destFile := filepath.Join(backupPath, filepath.Base(svFile))
+		rel, err := filepath.Rel(wtfDir, svFile)
+		if err != nil {
+			return "", err
+		}
+		destFile := filepath.Join(backupPath, rel)
+		if err := os.MkdirAll(filepath.Dir(destFile), 0755); err != nil {
+			return "", err
+		}
 		if err := copyFile(svFile, destFile); err != nil {
 			return "", err
 		}
@@ -201,6 +327,74 @@ func (bm *BackupManager) BackupSavedVariables(gameDir, addonName string) (string
 	return backupPath, nil
 }
 
+// PruneSavedVariables keeps only the newest MaxBackupsPerAddon SavedVariables
+// backups of an addon. Call it once the change they guard went through, so
+// failed or no-op operations don't push good backups out
+func (bm *BackupManager) PruneSavedVariables(addonName string) error {
+	if err := checkBackupName(addonName); err != nil {
+		return err
+	}
+	return cleanupOldTimestampDirs(bm.savedVariablesDir(addonName))
+}
+
+// newTimestampDir creates a folder in dir named after t, moving a second
+// forward while the name is taken
+func newTimestampDir(dir string, t time.Time) (string, error) {
+	for {
+		path := filepath.Join(dir, t.Format(BackupTimestampFormat))
+		err := os.Mkdir(path, 0755)
+		if err == nil {
+			return path, nil
+		}
+		if !os.IsExist(err) {
+			return "", err
+		}
+		t = t.Add(time.Second)
+	}
+}
+
+// RestoreSavedVariables copies a SavedVariables backup made by BackupSavedVariables
+// back into the game's WTF folder, overwriting the current files
+func (bm *BackupManager) RestoreSavedVariables(gameDir, backupPath string) error {
+	wtfDir := filepath.Join(gameDir, "WTF")
+	return filepath.Walk(backupPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(backupPath, path)
+		if err != nil {
+			return err
+		}
+		destFile := filepath.Join(wtfDir, rel)
+		if err := os.MkdirAll(filepath.Dir(destFile), 0755); err != nil {
+			return err
+		}
+		return copyFile(path, destFile)
+	})
+}
+
+// cleanupOldTimestampDirs keeps only the newest MaxBackupsPerAddon timestamped folders in dir
+func cleanupOldTimestampDirs(dir string) error {
+	stamps, err := listTimestampDirs(dir)
+	if err != nil {
+		return err
+	}
+	if len(stamps) <= MaxBackupsPerAddon {
+		return nil
+	}
+
+	for _, stamp := range stamps[MaxBackupsPerAddon:] {
+		if err := removeBackup(dir, stamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // copyDir recursively copies a directory
 func copyDir(src, dst string) error {
 	srcInfo, err := os.Stat(src)
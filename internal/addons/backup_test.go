@@ -0,0 +1,26 @@
+package addons
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestBackupSavedVariablesSameSecond(t *testing.T) {
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	writePackFile(t, m.GetGameDir(), "WTF/Account/ME/SavedVariables/Fixture.lua", "FixtureDB = {}")
+
+	bm := m.GetBackupManager()
+	first, err := bm.BackupSavedVariables(m.GetGameDir(), "Fixture")
+	if err != nil {
+		t.Fatalf("BackupSavedVariables() returned error: %v", err)
+	}
+	second, err := bm.BackupSavedVariables(m.GetGameDir(), "Fixture")
+	if err != nil {
+		t.Fatalf("BackupSavedVariables() returned error: %v", err)
+	}
+	if first == second {
+		t.Fatalf("two backups share %s", first)
+	}
+}
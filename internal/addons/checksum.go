@@ -0,0 +1,222 @@
+package addons
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ErrNoChecksums is returned when verifying an addon with nothing to compare against
+var ErrNoChecksums = errors.New("no checksums recorded")
+
+// VerifyResult lists the files of an addon that differ from what was installed
+type VerifyResult struct {
+	Name    string
+	Source  string   // What the files were compared against: "git" or "manifest"
+	Checked int      // Files expected
+	Changed []string // Content differs
+	Missing []string // Expected but gone
+	Added   []string // On disk but not expected
+	Err     error    // Set by VerifyAll when the addon couldn't be verified
+}
+
+// OK reports whether the addon files match
+func (r *VerifyResult) OK() bool {
+	return r.Err == nil && len(r.Changed) == 0 && len(r.Missing) == 0 && len(r.Added) == 0
+}
+
+// Verify re-hashes the files of an installed addon and compares them to the
+// committed tree for git addons, or to the checksums recorded at install
+// for the others
+// This checks the addon files themselves, VerifyRepoIntegrity only checks git
+func (m *Manager) Verify(name string) (*VerifyResult, error) {
+	addonPath := filepath.Join(m.addonsDir, name)
+	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
+		if m.IsDisabled(name) {
+			return nil, fmt.Errorf("%w: %s", ErrAddonDisabled, name)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+
+	result := &VerifyResult{Name: name}
+	meta, _ := m.store.Get(name)
+
+	var expected map[string]string
+	var hash func([]byte) string
+	switch {
+	case meta.GitURL != "":
+		var err error
+		if expected, err = committedHashes(m.RepoPath(name), meta.Pack, name); err != nil {
+			return nil, fmt.Errorf("failed to read committed files of %s: %w", name, err)
+		}
+		result.Source = "git"
+		hash = gitBlobHash
+	case meta.Checksums != nil:
+		expected = meta.Checksums
+		result.Source = "manifest"
+		hash = sha256Hash
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrNoChecksums, name)
+	}
+
+	actual, err := hashFiles(addonPath, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+
+	result.Checked = len(expected)
+	for path, sum := range expected {
+		got, ok := actual[path]
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, path)
+		case got != sum:
+			result.Changed = append(result.Changed, path)
+		}
+	}
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			result.Added = append(result.Added, path)
+		}
+	}
+	sort.Strings(result.Changed)
+	sort.Strings(result.Missing)
+	sort.Strings(result.Added)
+	return result, nil
+}
+
+// VerifyAll verifies every enabled addon that has something to compare
+// against, sorted by name. Addons without checksums are skipped
+func (m *Manager) VerifyAll() []*VerifyResult {
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return nil
+	}
+
+	var results []*VerifyResult
+	for _, addon := range installed {
+		if addon.Disabled || IsDefaultAddon(addon.Name) {
+			continue
+		}
+		result, err := m.Verify(addon.Name)
+		if errors.Is(err, ErrNoChecksums) {
+			continue
+		}
+		if err != nil {
+			result = &VerifyResult{Name: addon.Name, Err: err}
+		}
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// RecordChecksums stores the checksums of a tracked addon's current files,
+// so later changes show up in Verify
+// Git addons are verified against their commits and need none
+func (m *Manager) RecordChecksums(name string) error {
+	meta, ok := m.store.Get(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+	if meta.GitURL != "" {
+		return nil
+	}
+
+	checksums, err := hashFiles(filepath.Join(m.addonsDir, name), sha256Hash)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+	meta.Checksums = checksums
+	m.store.Set(name, meta)
+	return m.store.Save()
+}
+
+// hashFiles hashes every regular file under dir, keyed by slash separated
+// path relative to dir. .git and symlinks are skipped
+func hashFiles(dir string, hash func([]byte) string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = hash(data)
+		return nil
+	})
+	return hashes, err
+}
+
+// committedHashes returns the blob hashes of the files committed at HEAD,
+// limited to the folder of a pack member
+func committedHashes(repoPath, pack, folder string) (map[string]string, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, ErrNotGitRepo
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if pack != "" {
+		if tree, err = tree.Tree(folder); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrPackFolder, folder)
+		}
+	}
+
+	hashes := make(map[string]string)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if f.Mode == filemode.Symlink || f.Mode == filemode.Submodule {
+			return nil
+		}
+		hashes[f.Name] = f.Hash.String()
+		return nil
+	})
+	return hashes, err
+}
+
+// gitBlobHash hashes data the way git hashes file contents
+func gitBlobHash(data []byte) string {
+	return plumbing.ComputeHash(plumbing.BlobObject, data).String()
+}
+
+// sha256Hash returns the hex SHA-256 of data
+func sha256Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package addons
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestVerifyPackMemberAgainstCommit(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	installPackFixture(t, m, src)
+
+	result, err := m.Verify("PackB")
+	if err != nil {
+		t.Fatalf("Verify() returned error: %v", err)
+	}
+	if !result.OK() || result.Source != "git" || result.Checked != 2 {
+		t.Fatalf("fresh install should verify: %+v", result)
+	}
+
+	addonDir := filepath.Join(m.GetAddonsDir(), "PackB")
+	writePackFile(t, addonDir, "core.lua", "-- corrupted")
+	writePackFile(t, addonDir, "extra.lua", "")
+	if err := os.Remove(filepath.Join(addonDir, "PackB.toc")); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+
+	result, err = m.Verify("PackB")
+	if err != nil {
+		t.Fatalf("Verify() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Changed, []string{"core.lua"}) ||
+		!reflect.DeepEqual(result.Missing, []string{"PackB.toc"}) ||
+		!reflect.DeepEqual(result.Added, []string{"extra.lua"}) {
+		t.Fatalf("unexpected differences: %+v", result)
+	}
+}
+
+func TestVerifyLocalAddonAgainstChecksums(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "MyAddon")
+	writePackFile(t, src, "MyAddon.toc", "## Title: My Addon\n")
+	writePackFile(t, src, "core.lua", "-- v1")
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if _, err := m.installLocal(src); err != nil {
+		t.Fatalf("installLocal() returned error: %v", err)
+	}
+
+	writePackFile(t, filepath.Join(m.GetAddonsDir(), "MyAddon"), "core.lua", "-- edited")
+	result, err := m.Verify("MyAddon")
+	if err != nil {
+		t.Fatalf("Verify() returned error: %v", err)
+	}
+	if result.Source != "manifest" || !reflect.DeepEqual(result.Changed, []string{"core.lua"}) {
+		t.Fatalf("expected core.lua to differ from the manifest: %+v", result)
+	}
+
+	if err := m.RecordChecksums("MyAddon"); err != nil {
+		t.Fatalf("RecordChecksums() returned error: %v", err)
+	}
+	if result, _ := m.Verify("MyAddon"); !result.OK() {
+		t.Fatalf("recorded files should verify: %+v", result)
+	}
+}
+
+func TestVerifyWithoutChecksums(t *testing.T) {
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	writePackFile(t, m.GetAddonsDir(), "Manual/Manual.toc", "")
+
+	if _, err := m.Verify("Manual"); !errors.Is(err, ErrNoChecksums) {
+		t.Fatalf("expected ErrNoChecksums, got %v", err)
+	}
+	if results := m.VerifyAll(); len(results) != 0 {
+		t.Fatalf("untracked addons should be skipped: %+v", results)
+	}
+}
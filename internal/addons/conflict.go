@@ -0,0 +1,118 @@
+package addons
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bnema/turtlectl/internal/wiki"
+)
+
+// canonicalRepoURL reduces a git URL to lowercase host/owner/repo so that
+// https, ssh, and .git variants of the same repository compare equal
+// It's wiki.NormalizeURL without the @ref, so conflict detection and the
+// registry's installed marks agree on what the same repository is
+func canonicalRepoURL(gitURL string) string {
+	u, _ := SplitGitRef(strings.TrimSpace(gitURL))
+	return wiki.NormalizeURL(u)
+}
+
+// SameRepo reports whether two git URLs point at the same repository
+func SameRepo(a, b string) bool {
+	return canonicalRepoURL(a) == canonicalRepoURL(b)
+}
+
+// repoOwner returns the owner (user or organization) segment of a git URL
+func repoOwner(gitURL string) string {
+	parts := strings.Split(canonicalRepoURL(gitURL), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+// renamedFolder returns a suffixed folder name for installing alongside a conflict
+func (m *Manager) renamedFolder(name, gitURL string) string {
+	base := name
+	if owner := repoOwner(gitURL); owner != "" {
+		base = name + "-" + owner
+	}
+
+	candidate := base
+	for i := 2; m.addonExists(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return candidate
+}
+
+// existingRemote returns the git URL an installed folder came from
+// It prefers the folder's actual remote over stored metadata
+func (m *Manager) existingRemote(name string) string {
+	for _, dir := range []string{m.addonsDir, m.disabledDir} {
+		if url, err := GetRepoRemoteURL(filepath.Join(dir, name)); err == nil {
+			return url
+		}
+	}
+	if meta, ok := m.store.Get(name); ok {
+		return meta.GitURL
+	}
+	return ""
+}
+
+// conflictError explains why an addon folder is already taken
+// It distinguishes the same repository being reinstalled from a different
+// repository that produces the same folder name
+func (m *Manager) conflictError(name, gitURL string) error {
+	existing := m.existingRemote(name)
+
+	switch {
+	case existing == "":
+		return fmt.Errorf("%w: %s (installed manually, not from git)", ErrAddonExists, name)
+	case SameRepo(existing, gitURL):
+		return fmt.Errorf("%w: %s is already installed from %s", ErrAddonExists, name, existing)
+	default:
+		return fmt.Errorf("%w: %s is already installed from %s (use --rename to install alongside)",
+			ErrFolderConflict, name, existing)
+	}
+}
+
+// findFolderConflicts reports folders whose git remote differs from the tracked
+// URL, and distinct repositories that provide the same .toc addon
+func (m *Manager) findFolderConflicts(folders map[string]bool) []string {
+	var conflicts []string
+	stored := m.store.All()
+	byTOC := make(map[string][]string) // .toc name -> folders
+
+	for name := range folders {
+		addonPath := filepath.Join(m.addonsDir, name)
+
+		remote, err := GetRepoRemoteURL(addonPath)
+		if err == nil {
+			if meta, ok := stored[name]; ok && meta.GitURL != "" && !SameRepo(meta.GitURL, remote) {
+				conflicts = append(conflicts, fmt.Sprintf("%s (tracked from %s, folder is %s)", name, meta.GitURL, remote))
+			}
+		}
+
+		if _, tocName, err := FindTOCFile(addonPath); err == nil {
+			byTOC[strings.ToLower(tocName)] = append(byTOC[strings.ToLower(tocName)], name)
+		}
+	}
+
+	for _, names := range byTOC {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		remotes := make(map[string]bool)
+		for _, name := range names {
+			remotes[canonicalRepoURL(m.existingRemote(name))] = true
+		}
+		if len(remotes) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s provide the same addon from different repos", strings.Join(names, ", ")))
+		}
+	}
+
+	sort.Strings(conflicts)
+	return conflicts
+}
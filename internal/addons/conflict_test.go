@@ -0,0 +1,94 @@
+package addons
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/bnema/turtlectl/internal/wiki"
+)
+
+// sameRepoTests are URL pairs checked against both SameRepo and the registry's
+// wiki.MarkInstalled, which must agree on what the same repository is
+var sameRepoTests = []struct {
+	name string
+	a, b string
+	want bool
+}{
+	{"identical", "https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", true},
+	{"git suffix", "https://github.com/shagu/pfQuest.git", "https://github.com/shagu/pfQuest", true},
+	{"case", "https://GitHub.com/Shagu/pfQuest", "https://github.com/shagu/pfquest", true},
+	{"user info", "https://user@github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", true},
+	{"ssh vs https", "git@github.com:shagu/pfQuest.git", "https://github.com/shagu/pfQuest", true},
+	{"ssh scheme", "ssh://git@github.com/shagu/pfQuest.git", "https://github.com/shagu/pfQuest", true},
+	{"www", "https://www.github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", true},
+	{"trailing slashes", "https://github.com/shagu/pfQuest//", "https://github.com/shagu/pfQuest", true},
+	{"other owner", "https://github.com/someone/pfQuest", "https://github.com/shagu/pfQuest", false},
+	{"other host", "https://gitlab.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", false},
+	{"other repo", "https://github.com/shagu/pfUI", "https://github.com/shagu/pfQuest", false},
+}
+
+func TestSameRepoMatchesRegistry(t *testing.T) {
+	for _, tt := range sameRepoTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameRepo(tt.a, tt.b); got != tt.want {
+				t.Errorf("SameRepo(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+
+			addons := []wiki.WikiAddon{{URL: tt.b}}
+			wiki.MarkInstalled(addons, map[string]bool{tt.a: true})
+			if addons[0].IsInstalled != tt.want {
+				t.Errorf("MarkInstalled(%q) with %q installed = %v, want %v", tt.b, tt.a, addons[0].IsInstalled, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameRepoIgnoresRef(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://github.com/shagu/pfQuest@v4.0.0", "https://github.com/shagu/pfQuest", true},
+		{"https://github.com/shagu/pfQuest.git@main", "git@github.com:shagu/pfQuest.git", true},
+		{"https://www.github.com/Shagu/pfQuest@abc1234", "https://github.com/shagu/pfquest", true},
+		{"https://github.com/shagu/pfQuest@v4.0.0", "https://github.com/shagu/pfUI@v4.0.0", false},
+	}
+
+	for _, tt := range tests {
+		if got := SameRepo(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameRepo(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestConflictError(t *testing.T) {
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	writePackFile(t, m.GetAddonsDir(), "pfQuest/pfQuest.toc", "## Title: pfQuest\n")
+	writePackFile(t, m.GetAddonsDir(), "Manual/Manual.toc", "## Title: Manual\n")
+	m.store.Set("pfQuest", AddonMetadata{GitURL: "https://github.com/shagu/pfQuest.git"})
+
+	tests := []struct {
+		name, folder, url string
+		want              error
+	}{
+		{"manual install", "Manual", "https://github.com/someone/Manual", ErrAddonExists},
+		{"same repo over ssh", "pfQuest", "git@github.com:Shagu/pfQuest.git", ErrAddonExists},
+		{"same repo with ref", "pfQuest", "https://www.github.com/shagu/pfQuest@v4.0.0", ErrAddonExists},
+		{"different repo", "pfQuest", "https://github.com/someone/pfQuest", ErrFolderConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.conflictError(tt.folder, tt.url)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("conflictError(%q, %q) = %v, want %v", tt.folder, tt.url, err, tt.want)
+			}
+			if tt.want == ErrAddonExists && errors.Is(err, ErrFolderConflict) {
+				t.Fatalf("conflictError(%q, %q) reported a folder conflict: %v", tt.folder, tt.url, err)
+			}
+		})
+	}
+}
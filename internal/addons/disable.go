@@ -0,0 +1,93 @@
+package addons
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// disabledDirName is the sibling of AddOns where disabled addons are moved
+// The game only loads Interface/AddOns, so anything here is ignored
+const disabledDirName = "DisabledAddOns"
+
+// addonExists reports whether an addon folder exists, enabled or disabled
+func (m *Manager) addonExists(name string) bool {
+	for _, dir := range []string{m.addonsDir, m.disabledDir} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDisabled reports whether an addon has been moved to the disabled directory
+func (m *Manager) IsDisabled(name string) bool {
+	_, err := os.Stat(filepath.Join(m.disabledDir, name))
+	return err == nil
+}
+
+// Disable moves an addon out of Interface/AddOns without deleting it
+// SavedVariables are untouched, so enabling it again restores it as it was
+func (m *Manager) Disable(name string) error {
+	addonPath := filepath.Join(m.addonsDir, name)
+	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
+		if m.IsDisabled(name) {
+			return fmt.Errorf("%w: %s", ErrAddonDisabled, name)
+		}
+		return fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+
+	if err := os.MkdirAll(m.disabledDir, 0755); err != nil {
+		return fmt.Errorf("%w: %v", ErrAddonsDir, err)
+	}
+
+	if err := os.Rename(addonPath, filepath.Join(m.disabledDir, name)); err != nil {
+		return fmt.Errorf("failed to disable addon: %w", err)
+	}
+
+	m.setDisabled(name, true)
+	m.log.Info("Addon disabled", "name", name)
+	return nil
+}
+
+// Enable moves a disabled addon back into Interface/AddOns
+func (m *Manager) Enable(name string) error {
+	disabledPath := filepath.Join(m.disabledDir, name)
+	if _, err := os.Stat(disabledPath); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(m.addonsDir, name)); err == nil {
+			return fmt.Errorf("%w: %s", ErrAddonEnabled, name)
+		}
+		return fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+
+	addonPath := filepath.Join(m.addonsDir, name)
+	if _, err := os.Stat(addonPath); err == nil {
+		return fmt.Errorf("%w: %s", ErrAddonExists, name)
+	}
+
+	if err := m.EnsureAddonsDir(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(disabledPath, addonPath); err != nil {
+		return fmt.Errorf("failed to enable addon: %w", err)
+	}
+
+	m.setDisabled(name, false)
+	m.log.Info("Addon enabled", "name", name)
+	return nil
+}
+
+// setDisabled records the disabled state of a tracked addon
+func (m *Manager) setDisabled(name string, disabled bool) {
+	meta, ok := m.store.Get(name)
+	if !ok {
+		return
+	}
+
+	meta.Disabled = disabled
+	m.store.Set(name, meta)
+	if err := m.store.Save(); err != nil {
+		m.log.Warn("Failed to save addon metadata", "error", err)
+	}
+}
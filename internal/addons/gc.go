@@ -0,0 +1,128 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// CompactRepo reclaims space in a repository's .git directory: reflogs and
+// sample hooks are dropped, unreachable loose objects pruned and the rest
+// repacked into one pack
+// Shallow clones only have their reflogs and sample hooks dropped
+func CompactRepo(repoPath string) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return ErrNotGitRepo
+	}
+
+	gitDir := filepath.Join(repoPath, ".git")
+	if err := os.RemoveAll(filepath.Join(gitDir, "logs")); err != nil {
+		return fmt.Errorf("failed to drop reflogs: %w", err)
+	}
+	samples, _ := filepath.Glob(filepath.Join(gitDir, "hooks", "*.sample"))
+	for _, sample := range samples {
+		_ = os.Remove(sample)
+	}
+
+	// Pruning and repacking walk the full history, which a shallow clone doesn't have
+	shallow, err := repo.Storer.Shallow()
+	if err != nil {
+		return err
+	}
+	if len(shallow) > 0 {
+		return nil
+	}
+
+	if err := repo.Prune(git.PruneOptions{Handler: repo.DeleteObject}); err != nil {
+		return fmt.Errorf("failed to prune objects: %w", err)
+	}
+	if !needsRepack(gitDir) {
+		return nil
+	}
+	if err := repo.RepackObjects(&git.RepackConfig{}); err != nil {
+		return fmt.Errorf("failed to repack objects: %w", err)
+	}
+	return nil
+}
+
+// needsRepack reports whether a repository holds loose objects or more than one
+// pack, repacking a single pack only re-encodes it
+func needsRepack(gitDir string) bool {
+	packs, _ := filepath.Glob(filepath.Join(gitDir, "objects", "pack", "*.pack"))
+	if len(packs) > 1 {
+		return true
+	}
+	loose, _ := filepath.Glob(filepath.Join(gitDir, "objects", "[0-9a-f][0-9a-f]", "*"))
+	return len(loose) > 0
+}
+
+// CleanGitResult reports the space reclaimed in one addon's repository
+type CleanGitResult struct {
+	Name   string
+	Before int64  // .git size before compacting
+	After  int64  // .git size after compacting
+	Shared string // Other addon whose repository this is, for pack members already compacted
+	Error  error
+}
+
+// Reclaimed returns the bytes freed by compacting
+func (r CleanGitResult) Reclaimed() int64 {
+	return max(r.Before-r.After, 0)
+}
+
+// CleanGit compacts the git repositories of the given addons
+// Pack members share one repository, which is compacted once
+func (m *Manager) CleanGit(ctx context.Context, names []string) []CleanGitResult {
+	results := make([]CleanGitResult, 0, len(names))
+	done := make(map[string]string) // Repository path -> first addon compacted through it
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result := CleanGitResult{Name: name}
+		repoPath := m.RepoPath(name)
+		if first, ok := done[repoPath]; ok {
+			result.Shared = first
+			results = append(results, result)
+			continue
+		}
+		done[repoPath] = name
+
+		result.Before, result.After, result.Error = m.compactRepo(name, repoPath)
+		if result.Error != nil {
+			m.log.Warn("Failed to compact repository", "name", name, "error", result.Error)
+		} else {
+			m.log.Info("Repository compacted", "name", name, "before", result.Before, "after", result.After)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// compactRepo measures and compacts the repository behind an addon
+func (m *Manager) compactRepo(name, repoPath string) (before, after int64, err error) {
+	if meta, ok := m.store.Get(name); ok && meta.Pack != "" {
+		lock := m.packLock(meta.Pack)
+		lock.Lock()
+		defer lock.Unlock()
+	}
+
+	if !IsGitRepo(repoPath) {
+		return 0, 0, fmt.Errorf("%w: %s", ErrNotGitRepo, name)
+	}
+
+	gitDir := filepath.Join(repoPath, ".git")
+	if before, _, err = DirSize(gitDir); err != nil {
+		return 0, 0, err
+	}
+	if err := CompactRepo(repoPath); err != nil {
+		return before, 0, err
+	}
+	after, _, err = DirSize(gitDir)
+	return before, after, err
+}
@@ -0,0 +1,56 @@
+package addons
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompactRepo(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 3, 1024)
+
+	full := filepath.Join(t.TempDir(), "full")
+	if err := CloneRepo(context.Background(), src, full, "", 0, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	shallow := filepath.Join(t.TempDir(), "shallow")
+	if err := CloneRepo(context.Background(), src, shallow, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+
+	// Every update fetches its own pack
+	for i := 0; i < 3; i++ {
+		commitFixture(t, src, srcRepo, 1024, fmt.Sprintf("update %d", i))
+		for _, dest := range []string{full, shallow} {
+			if err := UpdateRepo(context.Background(), dest, "", nil); err != nil {
+				t.Fatalf("UpdateRepo() returned error: %v", err)
+			}
+		}
+	}
+
+	for _, dest := range []string{full, shallow} {
+		head, _ := GetCurrentCommit(dest)
+		before := dirSize(t, filepath.Join(dest, ".git"))
+
+		if err := CompactRepo(dest); err != nil {
+			t.Fatalf("CompactRepo(%s) returned error: %v", filepath.Base(dest), err)
+		}
+
+		if after := dirSize(t, filepath.Join(dest, ".git")); after > before {
+			t.Fatalf("%s grew from %d to %d bytes", filepath.Base(dest), before, after)
+		}
+		if err := VerifyRepoIntegrity(dest); err != nil {
+			t.Fatalf("%s corrupted by compacting: %v", filepath.Base(dest), err)
+		}
+		if got, _ := GetCurrentCommit(dest); got != head {
+			t.Fatalf("%s HEAD moved from %s to %s", filepath.Base(dest), head, got)
+		}
+	}
+
+	// Still updatable after compacting
+	commitFixture(t, src, srcRepo, 1024, "after compact")
+	if err := UpdateRepo(context.Background(), full, "", nil); err != nil {
+		t.Fatalf("UpdateRepo() after compacting returned error: %v", err)
+	}
+}
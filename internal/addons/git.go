@@ -1,15 +1,19 @@
 package addons
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 var (
@@ -17,61 +21,175 @@ var (
 	ErrFFNotPossible   = errors.New("fast-forward not possible, local changes exist")
 	ErrNoRemote        = errors.New("no remote configured")
 	ErrAlreadyUpToDate = errors.New("already up to date")
+	ErrRefNotFound     = errors.New("ref not found")
 )
 
+// ShallowDepth is the clone depth used for shallow installs
+const ShallowDepth = 1
+
+// unshallowDepth is the depth git itself uses for --unshallow
+const unshallowDepth = math.MaxInt32
+
 // CloneRepo clones a git repository to the specified path
+// ref is an optional branch, tag, or commit to checkout after cloning
+// depth limits the fetched history (0 for a full clone)
 // progressWriter can be nil to disable progress output
-func CloneRepo(url, destPath string, progressWriter io.Writer) error {
-	_, err := git.PlainClone(destPath, false, &git.CloneOptions{
-		URL:      url,
-		Progress: progressWriter,
-		Depth:    0, // Full clone for updates to work
+func CloneRepo(ctx context.Context, url, destPath, ref string, depth int, progressWriter io.Writer) error {
+	var repo *git.Repository
+	attempted := false
+	err := withRetry(ctx, progressWriter, func(ctx context.Context) error {
+		// Retry from a clean directory, dropping the partial clone
+		if attempted {
+			_ = os.RemoveAll(destPath)
+		}
+		attempted = true
+
+		var err error
+		repo, err = git.PlainCloneContext(ctx, destPath, false, &git.CloneOptions{
+			URL:      url,
+			Auth:     authForURL(url),
+			Progress: progressWriter,
+			Depth:    depth,
+		})
+		return err
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
 
+	if ref != "" {
+		err := checkoutRef(repo, ref)
+		if err != nil && depth > 0 {
+			// The pinned tag or commit may be outside the shallow history
+			if err := unshallow(ctx, repo, ref, progressWriter); err != nil {
+				return err
+			}
+			err = checkoutRef(repo, ref)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// UpdateRepo performs a fast-forward update on a git repository
-// progressWriter can be nil to disable progress output
-func UpdateRepo(repoPath string, progressWriter io.Writer) error {
-	repo, err := git.PlainOpen(repoPath)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrNotGitRepo, err)
+// isShallow reports whether a repository was cloned with limited history
+func isShallow(repo *git.Repository) bool {
+	shallows, err := repo.Storer.Shallow()
+	return err == nil && len(shallows) > 0
+}
+
+// unshallow fetches the complete history of a shallow repository
+func unshallow(ctx context.Context, repo *git.Repository, ref string, progressWriter io.Writer) error {
+	err := withRetry(ctx, progressWriter, func(ctx context.Context) error {
+		return repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: "origin",
+			Auth:       remoteAuth(repo),
+			Progress:   progressWriter,
+			Depth:      unshallowDepth,
+			Tags:       fetchTagMode(ref),
+		})
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to fetch full history: %w", err)
+	}
+	return nil
+}
+
+// fetchOrigin fetches from origin, falling back to a full fetch when an
+// incremental fetch into a shallow repository fails
+func fetchOrigin(ctx context.Context, repo *git.Repository, ref string, progressWriter io.Writer) error {
+	err := withRetry(ctx, progressWriter, func(ctx context.Context) error {
+		return repo.FetchContext(ctx, &git.FetchOptions{
+			RemoteName: "origin",
+			Auth:       remoteAuth(repo),
+			Progress:   progressWriter,
+			Tags:       fetchTagMode(ref),
+		})
+	})
+	if err == nil || err == git.NoErrAlreadyUpToDate {
+		return nil
 	}
 
-	// Get the worktree
+	if isShallow(repo) && !isTransientError(err) && ctx.Err() == nil {
+		return unshallow(ctx, repo, ref, progressWriter)
+	}
+
+	return fmt.Errorf("failed to fetch: %w", err)
+}
+
+// checkoutRef checks out a branch, tag, or commit in a freshly cloned repository
+// Branches get a local tracking branch, tags and commits are checked out detached
+func checkoutRef(repo *git.Repository, ref string) error {
 	worktree, err := repo.Worktree()
 	if err != nil {
 		return fmt.Errorf("failed to get worktree: %w", err)
 	}
 
-	// Check for local modifications
-	status, err := worktree.Status()
+	// Branch: create a local branch pointing at the remote one
+	remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", ref), true)
+	if err == nil {
+		branchName := plumbing.NewBranchReferenceName(ref)
+		_, localErr := repo.Reference(branchName, false)
+		err = worktree.Checkout(&git.CheckoutOptions{
+			Branch: branchName,
+			Hash:   remoteRef.Hash(),
+			Create: localErr != nil,
+			Force:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to checkout branch %s: %w", ref, err)
+		}
+		return nil
+	}
+
+	// Tag or commit: detached checkout
+	hash, err := resolveRef(repo, ref)
 	if err != nil {
-		return fmt.Errorf("failed to get status: %w", err)
+		return err
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: hash, Force: true}); err != nil {
+		return fmt.Errorf("failed to checkout %s: %w", ref, err)
 	}
 
-	if !status.IsClean() {
-		return ErrFFNotPossible
+	return nil
+}
+
+// resolveRef resolves a pinned ref to a commit hash
+// It tries a remote branch, then a tag, then a (possibly abbreviated) commit hash
+func resolveRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	if remoteRef, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", ref), true); err == nil {
+		return remoteRef.Hash(), nil
 	}
 
-	// Fetch from origin
-	err = repo.Fetch(&git.FetchOptions{
-		RemoteName: "origin",
-		Progress:   progressWriter,
-	})
-	if err != nil && err != git.NoErrAlreadyUpToDate {
-		return fmt.Errorf("failed to fetch: %w", err)
+	if tagRef, err := repo.Tag(ref); err == nil {
+		// Annotated tags point to a tag object, peel it to the commit
+		if tagObj, err := repo.TagObject(tagRef.Hash()); err == nil {
+			commit, err := tagObj.Commit()
+			if err != nil {
+				return plumbing.ZeroHash, fmt.Errorf("failed to resolve tag %s: %w", ref, err)
+			}
+			return commit.Hash, nil
+		}
+		return tagRef.Hash(), nil
 	}
 
-	// Get current branch reference
-	head, err := repo.Head()
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
 	if err != nil {
-		return fmt.Errorf("failed to get HEAD: %w", err)
+		return plumbing.ZeroHash, fmt.Errorf("%w: %s", ErrRefNotFound, ref)
+	}
+
+	return *hash, nil
+}
+
+// resolveRemoteHash returns the commit an update should move HEAD to
+// With a pinned ref it resolves that ref, otherwise the remote tracking branch
+// of the current branch (falling back to main/master)
+func resolveRemoteHash(repo *git.Repository, head *plumbing.Reference, ref string) (plumbing.Hash, error) {
+	if ref != "" {
+		return resolveRef(repo, ref)
 	}
 
 	// Get the remote tracking branch
@@ -89,18 +207,87 @@ func UpdateRepo(repoPath string, progressWriter io.Writer) error {
 			}
 		}
 		if err != nil {
-			return fmt.Errorf("failed to find remote branch: %w", err)
+			return plumbing.ZeroHash, fmt.Errorf("failed to find remote branch: %w", err)
+		}
+	}
+
+	return remoteRefObj.Hash(), nil
+}
+
+// fetchTagMode returns the tag fetch mode for a given pinned ref
+// Pinned addons fetch all tags so newly pinned tags can be resolved
+func fetchTagMode(ref string) git.TagMode {
+	if ref != "" {
+		return git.AllTags
+	}
+	return git.TagFollowing
+}
+
+// fetchUpdateTarget fetches from origin and returns the current HEAD along with
+// the commit an update would move it to (the remote branch or pinned ref)
+func fetchUpdateTarget(ctx context.Context, repo *git.Repository, ref string, progressWriter io.Writer) (*plumbing.Reference, plumbing.Hash, error) {
+	if err := fetchOrigin(ctx, repo, ref, progressWriter); err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	target, err := resolveRemoteHash(repo, head, ref)
+	if err != nil && ref != "" && isShallow(repo) {
+		// A newly pinned tag or commit may predate the shallow history
+		if err := unshallow(ctx, repo, ref, progressWriter); err != nil {
+			return nil, plumbing.ZeroHash, err
 		}
+		target, err = resolveRemoteHash(repo, head, ref)
+	}
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+
+	return head, target, nil
+}
+
+// UpdateRepo performs a fast-forward update on a git repository
+// If ref is set, the repository is moved to that branch, tag, or commit instead
+// progressWriter can be nil to disable progress output
+func UpdateRepo(ctx context.Context, repoPath, ref string, progressWriter io.Writer) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotGitRepo, err)
+	}
+
+	// Get the worktree
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	// Check for local modifications
+	status, err := worktree.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	if !status.IsClean() {
+		return ErrFFNotPossible
+	}
+
+	head, target, err := fetchUpdateTarget(ctx, repo, ref, progressWriter)
+	if err != nil {
+		return err
 	}
 
 	// Check if we're already up to date
-	if head.Hash() == remoteRefObj.Hash() {
+	if head.Hash() == target {
 		return ErrAlreadyUpToDate
 	}
 
-	// Perform fast-forward by resetting to remote
+	// Perform fast-forward by resetting to remote (or the pinned ref)
 	err = worktree.Reset(&git.ResetOptions{
-		Commit: remoteRefObj.Hash(),
+		Commit: target,
 		Mode:   git.HardReset,
 	})
 	if err != nil {
@@ -110,6 +297,60 @@ func UpdateRepo(repoPath string, progressWriter io.Writer) error {
 	return nil
 }
 
+// CommitSummary is a one-line description of a commit
+type CommitSummary struct {
+	Hash    string // Short commit hash
+	Subject string // First line of the commit message
+}
+
+// MaxChangelogCommits caps how many commits CommitsSince walks and returns
+const MaxChangelogCommits = 50
+
+// CommitsSince lists commits reachable from HEAD back to (excluding) the commit
+// whose hash starts with since, newest first
+// If since isn't an ancestor (e.g. a force push), the walk stops at MaxChangelogCommits
+func CommitsSince(repoPath, since string) ([]CommitSummary, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotGitRepo, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []CommitSummary
+	err = iter.ForEach(func(c *object.Commit) error {
+		hash := c.Hash.String()
+		if since != "" && strings.HasPrefix(hash, since) {
+			return storer.ErrStop
+		}
+		if len(commits) >= MaxChangelogCommits {
+			return storer.ErrStop
+		}
+
+		subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		commits = append(commits, CommitSummary{
+			Hash:    hash[:shortHashLen],
+			Subject: strings.TrimSpace(subject),
+		})
+		return nil
+	})
+	// Shallow clones end at a commit whose parents are missing
+	if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return commits, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	return commits, nil
+}
+
 // IsGitRepo checks if a directory is a git repository
 func IsGitRepo(path string) bool {
 	_, err := git.PlainOpen(path)
@@ -136,8 +377,18 @@ func GetRepoRemoteURL(repoPath string) (string, error) {
 	return urls[0], nil
 }
 
-// GetCurrentCommit returns the current HEAD commit hash
+// GetCurrentCommit returns the current HEAD commit hash, abbreviated for display
 func GetCurrentCommit(repoPath string) (string, error) {
+	hash, err := GetHeadCommit(repoPath)
+	if err != nil {
+		return "", err
+	}
+	return ShortCommit(hash), nil
+}
+
+// GetHeadCommit returns the full HEAD commit hash, for commits stored to be
+// checked out again later (an abbreviation can become ambiguous)
+func GetHeadCommit(repoPath string) (string, error) {
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
 		return "", ErrNotGitRepo
@@ -148,12 +399,45 @@ func GetCurrentCommit(repoPath string) (string, error) {
 		return "", fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
-	return head.Hash().String()[:8], nil
+	return head.Hash().String(), nil
+}
+
+// ShortCommit abbreviates a commit hash for display like GetCurrentCommit
+func ShortCommit(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// SplitGitRef splits an optional "@ref" suffix (branch, tag, or commit) from a git URL
+// e.g. "https://github.com/shagu/pfQuest@v4.0.0" -> ("https://github.com/shagu/pfQuest", "v4.0.0")
+// The ref starts at the last "@" of the repository path, so it may contain slashes
+// ("@feature/x") while the user in SSH URLs like "git@github.com:user/repo.git" is left untouched
+func SplitGitRef(gitURL string) (string, string) {
+	pathStart := 0
+	if i := strings.Index(gitURL, "://"); i >= 0 {
+		slash := strings.Index(gitURL[i+3:], "/")
+		if slash < 0 {
+			return gitURL, ""
+		}
+		pathStart = i + 3 + slash
+	} else if i := strings.Index(gitURL, ":"); i >= 0 {
+		pathStart = i + 1
+	}
+
+	at := strings.LastIndex(gitURL[pathStart:], "@")
+	if at <= 0 || pathStart+at == len(gitURL)-1 {
+		return gitURL, ""
+	}
+	at += pathStart
+	return gitURL[:at], gitURL[at+1:]
 }
 
 // ExtractRepoName extracts the repository name from a git URL
 func ExtractRepoName(gitURL string) string {
-	// Remove .git suffix
+	// Remove @ref suffix and .git suffix
+	gitURL, _ = SplitGitRef(gitURL)
 	name := strings.TrimSuffix(gitURL, ".git")
 
 	// Get the last path component
@@ -175,11 +459,11 @@ func ValidateGitURL(url string) error {
 	url = strings.ToLower(url)
 
 	// Check for common git URL patterns
-	if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "git://") {
+	if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "git@") || strings.HasPrefix(url, "git://") || strings.HasPrefix(url, "ssh://") {
 		return nil
 	}
 
-	return fmt.Errorf("invalid git URL: must start with https://, git@, or git://")
+	return fmt.Errorf("invalid git URL: must start with https://, git@, git://, or ssh://")
 }
 
 // NormalizeGitURL ensures the URL ends with .git
@@ -205,49 +489,46 @@ func CleanupFailedClone(path string) error {
 	return os.RemoveAll(path)
 }
 
-// CheckForUpdates checks if a repository has updates available without applying them
-// Returns true if updates are available, false if up to date
-func CheckForUpdates(repoPath string) (bool, error) {
+// UpdatePreview describes what an update would change, without applying it
+type UpdatePreview struct {
+	From      string // Current short commit hash
+	To        string // Target short commit hash
+	HasUpdate bool
+	ReClone   bool // Not a git repository, would be re-cloned from the stored URL
+}
+
+// shortHashLen is the abbreviated commit hash length used in previews
+const shortHashLen = 7
+
+// PreviewUpdate fetches and resolves the update target without touching the worktree
+// If ref is set, HEAD is compared against that pinned ref instead of the remote branch
+func PreviewUpdate(ctx context.Context, repoPath, ref string) (*UpdatePreview, error) {
 	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
-		return false, fmt.Errorf("%w: %v", ErrNotGitRepo, err)
-	}
-
-	// Fetch from origin (updates remote refs without changing local)
-	err = repo.Fetch(&git.FetchOptions{
-		RemoteName: "origin",
-	})
-	if err != nil && err != git.NoErrAlreadyUpToDate {
-		return false, fmt.Errorf("failed to fetch: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrNotGitRepo, err)
 	}
 
-	// Get current HEAD
-	head, err := repo.Head()
+	head, target, err := fetchUpdateTarget(ctx, repo, ref, nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to get HEAD: %w", err)
+		return nil, err
 	}
 
-	// Get the remote tracking branch
-	branchName := head.Name().Short()
-	remoteRef := plumbing.NewRemoteReferenceName("origin", branchName)
+	return &UpdatePreview{
+		From:      head.Hash().String()[:shortHashLen],
+		To:        target.String()[:shortHashLen],
+		HasUpdate: head.Hash() != target,
+	}, nil
+}
 
-	remoteRefObj, err := repo.Reference(remoteRef, true)
+// CheckForUpdates checks if a repository has updates available without applying them
+// If ref is set, HEAD is compared against that pinned ref instead of the remote branch
+// Returns true if updates are available, false if up to date
+func CheckForUpdates(ctx context.Context, repoPath, ref string) (bool, error) {
+	preview, err := PreviewUpdate(ctx, repoPath, ref)
 	if err != nil {
-		// Try common default branches
-		for _, defaultBranch := range []string{"main", "master"} {
-			remoteRef = plumbing.NewRemoteReferenceName("origin", defaultBranch)
-			remoteRefObj, err = repo.Reference(remoteRef, true)
-			if err == nil {
-				break
-			}
-		}
-		if err != nil {
-			return false, fmt.Errorf("failed to find remote branch: %w", err)
-		}
+		return false, err
 	}
-
-	// Compare hashes
-	return head.Hash() != remoteRefObj.Hash(), nil
+	return preview.HasUpdate, nil
 }
 
 // VerifyRepoIntegrity checks if a git repository is valid and not corrupted
@@ -0,0 +1,316 @@
+package addons
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// newFixtureRepo creates a repository with commits history, each rewriting a
+// blob of random (incompressible) data so old history has a real cost
+func newFixtureRepo(t *testing.T, commits, blobSize int) (string, *git.Repository) {
+	t.Helper()
+
+	// Local clones go through git-upload-pack, which must be installed
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() returned error: %v", err)
+	}
+
+	for i := 0; i < commits; i++ {
+		commitFixture(t, dir, repo, blobSize, fmt.Sprintf("commit %d", i))
+	}
+
+	return dir, repo
+}
+
+func commitFixture(t *testing.T, dir string, repo *git.Repository, blobSize int, msg string) plumbing.Hash {
+	t.Helper()
+
+	data := make([]byte, blobSize)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("rand.Read() returned error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.bin"), data, 0644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() returned error: %v", err)
+	}
+	if _, err := worktree.Add("data.bin"); err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+
+	hash, err := worktree.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Commit() returned error: %v", err)
+	}
+	return hash
+}
+
+// serveFixtureRepo serves a fixture repository over HTTPS as <name>.git through
+// git http-backend, for code paths that only accept remote URLs
+func serveFixtureRepo(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", "--exec-path").Output()
+	if err != nil {
+		t.Skip("git exec path not available")
+	}
+	backend := filepath.Join(strings.TrimSpace(string(out)), "git-http-backend")
+	if _, err := os.Stat(backend); err != nil {
+		t.Skip("git http-backend not available")
+	}
+
+	root := t.TempDir()
+	if err := os.Symlink(filepath.Join(dir, ".git"), filepath.Join(root, name+".git")); err != nil {
+		t.Fatalf("Symlink() returned error: %v", err)
+	}
+
+	server := httptest.NewTLSServer(&cgi.Handler{
+		Path: backend,
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(server.Close)
+
+	// The test server's certificate is self-signed
+	client.InstallProtocol("https", githttp.NewClient(&http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}))
+	t.Cleanup(func() { client.InstallProtocol("https", githttp.DefaultClient) })
+
+	return server.URL + "/" + name + ".git"
+}
+
+func dirSize(t *testing.T, dir string) int64 {
+	t.Helper()
+
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir() returned error: %v", err)
+	}
+	return size
+}
+
+func TestSplitGitRef(t *testing.T) {
+	tests := []struct {
+		in, url, ref string
+	}{
+		{"https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", ""},
+		{"https://github.com/shagu/pfQuest@v4.0.0", "https://github.com/shagu/pfQuest", "v4.0.0"},
+		{"https://github.com/shagu/pfQuest.git@master", "https://github.com/shagu/pfQuest.git", "master"},
+		{"https://github.com/shagu/pfQuest@feature/x", "https://github.com/shagu/pfQuest", "feature/x"},
+		{"https://gitlab.com/group/sub/addon@release/1.2", "https://gitlab.com/group/sub/addon", "release/1.2"},
+		{"https://github.com/shagu/pfQuest@", "https://github.com/shagu/pfQuest@", ""},
+		{"git@github.com:user/repo.git", "git@github.com:user/repo.git", ""},
+		{"git@github.com:user/repo.git@feature/x", "git@github.com:user/repo.git", "feature/x"},
+		{"ssh://git@github.com/user/repo.git", "ssh://git@github.com/user/repo.git", ""},
+		{"ssh://git@github.com/user/repo.git@v1", "ssh://git@github.com/user/repo.git", "v1"},
+	}
+	for _, tt := range tests {
+		url, ref := SplitGitRef(tt.in)
+		if url != tt.url || ref != tt.ref {
+			t.Errorf("SplitGitRef(%q) = (%q, %q), want (%q, %q)", tt.in, url, ref, tt.url, tt.ref)
+		}
+	}
+}
+
+func TestCloneRepoShallowFetchesLessThanFull(t *testing.T) {
+	const (
+		commits  = 20
+		blobSize = 64 * 1024
+	)
+	src, _ := newFixtureRepo(t, commits, blobSize)
+
+	shallowDir := filepath.Join(t.TempDir(), "shallow")
+	start := time.Now()
+	if err := CloneRepo(context.Background(), src, shallowDir, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("shallow CloneRepo() returned error: %v", err)
+	}
+	shallowTime := time.Since(start)
+
+	fullDir := filepath.Join(t.TempDir(), "full")
+	start = time.Now()
+	if err := CloneRepo(context.Background(), src, fullDir, "", 0, nil); err != nil {
+		t.Fatalf("full CloneRepo() returned error: %v", err)
+	}
+	fullTime := time.Since(start)
+
+	shallowSize := dirSize(t, filepath.Join(shallowDir, ".git"))
+	fullSize := dirSize(t, filepath.Join(fullDir, ".git"))
+
+	// With incompressible blobs the full clone carries every revision while
+	// the shallow one carries only the latest, roughly a 1/commits ratio
+	t.Logf("%d commits of %d KiB: shallow %d KiB in %s, full %d KiB in %s",
+		commits, blobSize/1024, shallowSize/1024, shallowTime, fullSize/1024, fullTime)
+
+	if shallowSize*4 > fullSize {
+		t.Fatalf("expected shallow clone to be much smaller: shallow=%d full=%d", shallowSize, fullSize)
+	}
+
+	repo, err := git.PlainOpen(shallowDir)
+	if err != nil {
+		t.Fatalf("PlainOpen() returned error: %v", err)
+	}
+	if !isShallow(repo) {
+		t.Fatal("expected shallow clone to record shallow commits")
+	}
+}
+
+func TestUpdateRepoShallow(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 3, 1024)
+
+	dest := filepath.Join(t.TempDir(), "addon")
+	if err := CloneRepo(context.Background(), src, dest, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+
+	if err := UpdateRepo(context.Background(), dest, "", nil); err != ErrAlreadyUpToDate {
+		t.Fatalf("expected ErrAlreadyUpToDate, got %v", err)
+	}
+
+	want := commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	if err := UpdateRepo(context.Background(), dest, "", nil); err != nil {
+		t.Fatalf("UpdateRepo() returned error: %v", err)
+	}
+
+	got, err := GetCurrentCommit(dest)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit() returned error: %v", err)
+	}
+	if got != want.String()[:len(got)] {
+		t.Fatalf("unexpected HEAD after update: got %s, want %s", got, want)
+	}
+}
+
+func TestCloneRepoShallowPinnedToOldCommit(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+	pinned := commitFixture(t, src, srcRepo, 1024, "pinned")
+	commitFixture(t, src, srcRepo, 1024, "latest")
+
+	dest := filepath.Join(t.TempDir(), "addon")
+	if err := CloneRepo(context.Background(), src, dest, pinned.String(), ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+
+	repo, err := git.PlainOpen(dest)
+	if err != nil {
+		t.Fatalf("PlainOpen() returned error: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head() returned error: %v", err)
+	}
+	if head.Hash() != pinned {
+		t.Fatalf("unexpected HEAD: got %s, want %s", head.Hash(), pinned)
+	}
+}
+
+func TestCommitsSinceShallow(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 2, 1024)
+
+	dest := filepath.Join(t.TempDir(), "addon")
+	if err := CloneRepo(context.Background(), src, dest, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	before, err := GetCurrentCommit(dest)
+	if err != nil {
+		t.Fatalf("GetCurrentCommit() returned error: %v", err)
+	}
+
+	commitFixture(t, src, srcRepo, 1024, "fix tooltip\n\nlonger body")
+	commitFixture(t, src, srcRepo, 1024, "add options")
+	if err := UpdateRepo(context.Background(), dest, "", nil); err != nil {
+		t.Fatalf("UpdateRepo() returned error: %v", err)
+	}
+
+	commits, err := CommitsSince(dest, before)
+	if err != nil {
+		t.Fatalf("CommitsSince() returned error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "add options" || commits[1].Subject != "fix tooltip" {
+		t.Fatalf("unexpected commits: %+v", commits)
+	}
+}
+
+func TestCheckUpdatesReportsCommits(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	gameDir := t.TempDir()
+	m := NewManager(gameDir, t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+
+	results := m.CheckUpdates(context.Background(), []string{"Fixture", "Missing"})
+	if len(results) != 1 || results[0].HasUpdate || results[0].Error != nil {
+		t.Fatalf("expected Fixture up to date and Missing skipped, got %+v", results)
+	}
+	if results[0].Current != results[0].Latest {
+		t.Fatalf("up to date addon has different commits: %+v", results[0])
+	}
+
+	want := commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	results = m.CheckUpdates(context.Background(), []string{"Fixture"})
+	if len(results) != 1 || !results[0].HasUpdate {
+		t.Fatalf("expected an update, got %+v", results)
+	}
+	if results[0].Latest != want.String()[:len(results[0].Latest)] {
+		t.Fatalf("unexpected latest commit: got %s, want %s", results[0].Latest, want)
+	}
+}
+
+func TestPreviewUpdateCanceled(t *testing.T) {
+	src, _ := newFixtureRepo(t, 1, 1024)
+	dest := filepath.Join(t.TempDir(), "Fixture")
+	if err := CloneRepo(context.Background(), src, dest, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := PreviewUpdate(ctx, dest, ""); err == nil {
+		t.Fatal("expected an error with a canceled context")
+	}
+}
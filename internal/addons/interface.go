@@ -0,0 +1,42 @@
+package addons
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultInterfaceVersion is the .toc Interface value of the Turtle WoW client (1.12)
+const DefaultInterfaceVersion = 11200
+
+// ExpectedInterfaceVersion returns the client Interface version addons are checked against
+// TURTLECTL_INTERFACE_VERSION overrides the default for future client bumps
+func ExpectedInterfaceVersion() int {
+	if v := os.Getenv("TURTLECTL_INTERFACE_VERSION"); v != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n
+		}
+	}
+	return DefaultInterfaceVersion
+}
+
+// IsInterfaceCompatible reports whether a .toc Interface value targets the same
+// major client version as the expected one (e.g. 11000 and 11200 are both 1.x)
+// An empty or unparsable value is treated as compatible since it can't be checked
+func IsInterfaceCompatible(iface string, expected int) bool {
+	n, err := strconv.Atoi(strings.TrimSpace(iface))
+	if err != nil || n <= 0 {
+		return true
+	}
+	return n/10000 == expected/10000
+}
+
+// InterfaceWarning returns a warning for an incompatible Interface value, or "" if it looks fine
+func InterfaceWarning(iface string) string {
+	expected := ExpectedInterfaceVersion()
+	if IsInterfaceCompatible(iface, expected) {
+		return ""
+	}
+	return fmt.Sprintf("Interface %s may be incompatible (expected %d)", strings.TrimSpace(iface), expected)
+}
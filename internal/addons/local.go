@@ -0,0 +1,188 @@
+package addons
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bnema/turtlectl/internal/settings"
+)
+
+// IsLocalSource reports whether src points to an addon folder or zip file on disk
+// rather than a git URL
+func IsLocalSource(src string) bool {
+	if ValidateGitURL(src) == nil {
+		return false
+	}
+	info, err := os.Stat(settings.ExpandHome(src))
+	if err != nil {
+		return false
+	}
+	return info.IsDir() || isZipFile(src)
+}
+
+// LocalSourceName returns the addon name implied by a local folder or zip path
+func LocalSourceName(src string) string {
+	name := filepath.Base(filepath.Clean(settings.ExpandHome(src)))
+	if isZipFile(name) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+func isZipFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+// installLocal copies a local addon folder or extracts a zip into the AddOns directory
+// The addon is recorded without a git URL so it shows as untracked
+func (m *Manager) installLocal(src string) (*InstallResult, error) {
+	src, err := filepath.Abs(settings.ExpandHome(src))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.EnsureAddonsDir(); err != nil {
+		return nil, err
+	}
+
+	// Stage inside the AddOns directory so the final move is a plain rename
+	stagingDir, err := os.MkdirTemp(m.addonsDir, ".turtlectl-install-")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAddonsDir, err)
+	}
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	root := filepath.Join(stagingDir, LocalSourceName(src))
+	if isZipFile(src) {
+		if err := extractZip(src, root); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", src, err)
+		}
+		root = stripWrapperDir(root)
+	} else if err := copyDir(src, root); err != nil {
+		return nil, fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	addonName := filepath.Base(root)
+	tocPath, tocName, err := FindTOCFile(root)
+	if err != nil {
+		m.log.Warn("No .toc file found in local source", "path", src)
+	}
+	if tocName != "" {
+		addonName = tocName
+	}
+
+	addonPath := filepath.Join(m.addonsDir, addonName)
+	if m.addonExists(addonName) {
+		return nil, fmt.Errorf("%w: %s", ErrAddonExists, addonName)
+	}
+
+	if err := os.Rename(root, addonPath); err != nil {
+		return nil, fmt.Errorf("failed to move addon into place: %w", err)
+	}
+
+	var tocInfo *TOCInfo
+	if tocPath != "" {
+		rel, _ := filepath.Rel(root, tocPath)
+		tocInfo, _ = ParseTOC(filepath.Join(addonPath, rel))
+	}
+
+	checksums, err := hashFiles(addonPath, sha256Hash)
+	if err != nil {
+		m.log.Warn("Failed to record addon checksums", "name", addonName, "error", err)
+	}
+
+	now := time.Now()
+	m.store.Set(addonName, AddonMetadata{
+		LocalSource: src,
+		Checksums:   checksums,
+		InstalledAt: now,
+		UpdatedAt:   now,
+	})
+	if err := m.store.Save(); err != nil {
+		m.log.Warn("Failed to save addon metadata", "error", err)
+	}
+
+	result := &InstallResult{
+		Name: addonName,
+		Path: addonPath,
+	}
+	m.applyTOCInfo(result, tocInfo)
+
+	m.log.Info("Addon installed from local source", "name", addonName, "source", src)
+	return result, nil
+}
+
+// stripWrapperDir descends into dir while it contains exactly one directory and nothing else
+func stripWrapperDir(dir string) string {
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+			return dir
+		}
+		dir = filepath.Join(dir, entries[0].Name())
+	}
+}
+
+// extractZip extracts a zip archive into destDir, rejecting entries that escape it
+func extractZip(zipPath, destDir string) error {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	destDir = filepath.Clean(destDir)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	for _, f := range r.File {
+		if path.IsAbs(f.Name) || filepath.IsAbs(f.Name) {
+			return fmt.Errorf("illegal path in archive: %s", f.Name)
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(f.Name))
+		if target != destDir && !strings.HasPrefix(target, destDir+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal path in archive: %s", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dst.Close() }()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,109 @@
+package addons
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+// writeZip creates a zip archive at path holding the given entries
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip Create(%q) returned error: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("zip Write() returned error: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip Close() returned error: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+}
+
+func TestExtractZipRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{"parent dir", "../evil"},
+		{"nested parent dir", "Addon/../../evil"},
+		{"absolute path", "/tmp/evil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			zipPath := filepath.Join(dir, "addon.zip")
+			writeZip(t, zipPath, map[string]string{tt.entry: "pwned"})
+
+			dest := filepath.Join(dir, "out", "Addon")
+			if err := extractZip(zipPath, dest); err == nil {
+				t.Fatalf("extractZip() accepted %q", tt.entry)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "out", "evil")); err == nil {
+				t.Fatalf("%q was written outside the destination", tt.entry)
+			}
+		})
+	}
+}
+
+func TestStripWrapperDir(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "pfQuest-master.zip")
+	writeZip(t, zipPath, map[string]string{
+		"pfQuest-master/pfQuest/pfQuest.toc": "## Title: pfQuest\n",
+		"pfQuest-master/pfQuest/core.lua":    "-- core",
+	})
+
+	dest := filepath.Join(dir, "out")
+	if err := extractZip(zipPath, dest); err != nil {
+		t.Fatalf("extractZip() returned error: %v", err)
+	}
+	if got, want := stripWrapperDir(dest), filepath.Join(dest, "pfQuest-master", "pfQuest"); got != want {
+		t.Fatalf("stripWrapperDir() = %q, want %q", got, want)
+	}
+
+	// A folder with files next to the subfolder is the addon itself
+	writePackFile(t, dir, "flat/Flat.toc", "## Title: Flat\n")
+	writePackFile(t, dir, "flat/libs/lib.lua", "-- lib")
+	if got := stripWrapperDir(filepath.Join(dir, "flat")); got != filepath.Join(dir, "flat") {
+		t.Fatalf("stripWrapperDir() descended into %q", got)
+	}
+}
+
+func TestInstallLocalZipWithWrapperFolder(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "pfQuest-v4.zip")
+	writeZip(t, zipPath, map[string]string{
+		"pfQuest-v4/pfQuest.toc": "## Title: pfQuest\n",
+		"pfQuest-v4/core.lua":    "-- core",
+	})
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	result, err := m.installLocal(zipPath)
+	if err != nil {
+		t.Fatalf("installLocal() returned error: %v", err)
+	}
+	if result.Name != "pfQuest" {
+		t.Fatalf("installLocal() name = %q, want pfQuest", result.Name)
+	}
+	if _, err := os.Stat(filepath.Join(m.GetAddonsDir(), "pfQuest", "core.lua")); err != nil {
+		t.Fatalf("wrapper folder not stripped: %v", err)
+	}
+}
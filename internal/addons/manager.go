@@ -1,33 +1,50 @@
 package addons
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
+
+	"github.com/bnema/turtlectl/internal/offline"
 )
 
 var (
-	ErrAddonNotFound = errors.New("addon not found")
-	ErrAddonExists   = errors.New("addon already exists")
-	ErrInvalidURL    = errors.New("invalid git URL")
-	ErrAddonsDir     = errors.New("failed to access addons directory")
+	ErrAddonNotFound  = errors.New("addon not found")
+	ErrAddonExists    = errors.New("addon already exists")
+	ErrFolderConflict = errors.New("addon folder is used by a different repository")
+	ErrInvalidURL     = errors.New("invalid git URL")
+	ErrAddonsDir      = errors.New("failed to access addons directory")
+	ErrNewerInstall   = errors.New("installed addon is newer than backup")
+	ErrAddonDisabled  = errors.New("addon is disabled")
+	ErrAddonEnabled   = errors.New("addon is not disabled")
+	ErrLocalSource    = errors.New("addon was installed from a local source and can't be updated automatically")
+	ErrAddonPinned    = errors.New("addon is pinned")
+	ErrAddonNotPinned = errors.New("addon is not pinned")
 )
 
 // Manager handles addon operations
 type Manager struct {
-	gameDir   string
-	addonsDir string
-	dataDir   string
-	store     *StoreManager
-	backup    *BackupManager
-	log       *log.Logger
+	gameDir     string
+	addonsDir   string
+	disabledDir string
+	packsDir    string
+	dataDir     string
+	store       *StoreManager
+	backup      *BackupManager
+	log         *log.Logger
+	packLocks   sync.Map // Pack name -> *sync.Mutex
+
+	backupSavedVariables bool // Back up SavedVariables before update/remove
 }
 
 // NewManager creates a new addon manager
@@ -35,17 +52,72 @@ func NewManager(gameDir, dataDir string, logger *log.Logger) *Manager {
 	addonsDir := filepath.Join(gameDir, "Interface", "AddOns")
 
 	m := &Manager{
-		gameDir:   gameDir,
-		addonsDir: addonsDir,
-		dataDir:   dataDir,
-		store:     NewStoreManager(dataDir),
-		backup:    NewBackupManager(dataDir),
-		log:       logger,
+		gameDir:     gameDir,
+		addonsDir:   addonsDir,
+		disabledDir: filepath.Join(gameDir, "Interface", disabledDirName),
+		packsDir:    filepath.Join(gameDir, "Interface", packsDirName),
+		dataDir:     dataDir,
+		store:       NewStoreManager(dataDir),
+		backup:      NewBackupManager(dataDir),
+		log:         logger,
+
+		backupSavedVariables: true,
 	}
 
 	return m
 }
 
+// SetStateDir keeps the addon store in dir, the XDG state dir, rather than
+// the data dir. A store still in the data dir is moved there on Load
+func (m *Manager) SetStateDir(dir string) {
+	m.store = NewStoreManager(dir)
+	m.store.legacyPath = filepath.Join(m.dataDir, storeFileName)
+}
+
+// SetBackupSavedVariables controls whether SavedVariables are backed up
+// before updating or removing an addon (enabled by default)
+func (m *Manager) SetBackupSavedVariables(enabled bool) {
+	m.backupSavedVariables = enabled
+}
+
+// GetGameDir returns the game directory the manager operates on
+func (m *Manager) GetGameDir() string {
+	return m.gameDir
+}
+
+// GameDirExists reports whether the game directory exists, it doesn't until
+// the game has been installed
+func (m *Manager) GameDirExists() bool {
+	info, err := os.Stat(m.gameDir)
+	return err == nil && info.IsDir()
+}
+
+// backupSV backs up an addon's SavedVariables from the game's WTF folder
+// Returns the backup path, or "" if disabled, nothing was found, or it failed
+func (m *Manager) backupSV(name string) string {
+	if !m.backupSavedVariables {
+		return ""
+	}
+
+	path, err := m.backup.BackupSavedVariables(m.gameDir, name)
+	if err != nil {
+		m.log.Warn("Failed to back up SavedVariables", "name", name, "error", err)
+		return ""
+	}
+	if path != "" {
+		m.log.Info("SavedVariables backed up", "name", name, "path", path)
+	}
+	return path
+}
+
+// pruneSV drops SavedVariables backups of an addon beyond MaxBackupsPerAddon,
+// called once the operation that took a new one succeeded
+func (m *Manager) pruneSV(name string) {
+	if err := m.backup.PruneSavedVariables(name); err != nil {
+		m.log.Warn("Failed to clean up old SavedVariables backups", "name", name, "error", err)
+	}
+}
+
 // EnsureAddonsDir creates the Interface/AddOns directory if it doesn't exist
 func (m *Manager) EnsureAddonsDir() error {
 	if err := os.MkdirAll(m.addonsDir, 0755); err != nil {
@@ -69,26 +141,76 @@ type InstallResult struct {
 	Name  string
 	Title string
 	Path  string
+	Ref   string // Pinned branch, tag, or commit (empty for default branch)
+
+	MissingDependencies []string // Declared in .toc but not installed
+	InterfaceWarning    string   // Set when the .toc Interface doesn't match the client
+
+	Folders []string // Addons installed from a multi-addon repo (Name is the first)
 }
 
-// Install installs an addon from a git URL
+// InstallOptions controls how an addon is installed
+type InstallOptions struct {
+	Full   bool     // Clone the complete git history instead of a shallow clone
+	Rename bool     // Install into a suffixed folder if the name is taken by another repo
+	Only   []string // Addon folders to install from a multi-addon repo (default: all)
+}
+
+// Install installs an addon from a git URL, a local folder, or a zip file
+// The URL may carry an "@ref" suffix to pin a branch, tag, or commit
+// GitHub and GitLab web page URLs are reduced to the repository, see NormalizeRepoURL
 // progressWriter can be nil to disable progress output
-func (m *Manager) Install(gitURL string, progressWriter io.Writer) (*InstallResult, error) {
+func (m *Manager) Install(ctx context.Context, gitURL string, progressWriter io.Writer) (*InstallResult, error) {
+	return m.InstallWithOptions(ctx, gitURL, InstallOptions{}, progressWriter)
+}
+
+// InstallWithOptions installs an addon like Install, using the given options
+func (m *Manager) InstallWithOptions(ctx context.Context, gitURL string, opts InstallOptions, progressWriter io.Writer) (*InstallResult, error) {
+	if IsLocalSource(gitURL) {
+		if len(opts.Only) > 0 {
+			return nil, fmt.Errorf("%w: local sources are always installed whole", ErrNotPack)
+		}
+		return m.installLocal(gitURL)
+	}
+
 	// Validate URL
 	if err := ValidateGitURL(gitURL); err != nil {
 		return nil, ErrInvalidURL
 	}
 
+	if offline.Enabled() {
+		return nil, offline.ErrOffline
+	}
+
+	// Tokens in the URL would end up in addons.json and .git/config
+	if hasURLCredentials(gitURL) {
+		return nil, ErrCredentialsInURL
+	}
+
+	// Browser URLs like .../tree/master point below the repository
+	gitURL, err := NormalizeRepoURL(gitURL)
+	if err != nil {
+		return nil, err
+	}
+
+	gitURL, ref := SplitGitRef(gitURL)
 	gitURL = NormalizeGitURL(gitURL)
 
 	// Extract addon name from URL
 	addonName := ExtractRepoName(gitURL)
 
 	// Check if addon already exists
-	addonPath := filepath.Join(m.addonsDir, addonName)
-	if _, err := os.Stat(addonPath); err == nil {
-		return nil, fmt.Errorf("%w: %s", ErrAddonExists, addonName)
+	renamed := false
+	if m.addonExists(addonName) {
+		err := m.conflictError(addonName, gitURL)
+		if !opts.Rename || !errors.Is(err, ErrFolderConflict) {
+			return nil, err
+		}
+		addonName = m.renamedFolder(addonName, gitURL)
+		renamed = true
+		m.log.Warn("Installing into renamed folder to avoid conflict", "name", addonName)
 	}
+	addonPath := filepath.Join(m.addonsDir, addonName)
 
 	// Ensure addons directory exists
 	if err := m.EnsureAddonsDir(); err != nil {
@@ -96,11 +218,24 @@ func (m *Manager) Install(gitURL string, progressWriter io.Writer) (*InstallResu
 	}
 
 	// Clone the repository
-	if err := CloneRepo(gitURL, addonPath, progressWriter); err != nil {
+	depth := ShallowDepth
+	if opts.Full {
+		depth = 0
+	}
+	if err := CloneRepo(ctx, gitURL, addonPath, ref, depth, progressWriter); err != nil {
 		_ = CleanupFailedClone(addonPath)
 		return nil, err
 	}
 
+	// A repository of several addon folders is installed as a pack
+	if folders := FindAddonFolders(addonPath); len(folders) > 0 {
+		return m.installPack(addonPath, gitURL, ref, folders, opts)
+	}
+	if len(opts.Only) > 0 {
+		_ = os.RemoveAll(addonPath)
+		return nil, fmt.Errorf("%w: %s", ErrNotPack, gitURL)
+	}
+
 	// Check for .toc file and get correct addon name
 	tocPath, tocName, err := FindTOCFile(addonPath)
 	if err != nil {
@@ -108,13 +243,13 @@ func (m *Manager) Install(gitURL string, progressWriter io.Writer) (*InstallResu
 		m.log.Warn("No .toc file found in repository", "path", addonPath)
 	}
 
-	// If .toc name differs from folder name, rename
-	if tocName != "" && tocName != addonName {
+	// If .toc name differs from folder name, rename (unless we renamed on purpose)
+	if tocName != "" && tocName != addonName && !renamed {
 		newPath := filepath.Join(m.addonsDir, tocName)
-		if _, err := os.Stat(newPath); err == nil {
+		if m.addonExists(tocName) {
 			// Target already exists, keep original name
 			m.log.Warn("Target addon name already exists, keeping original",
-				"original", addonName, "target", tocName)
+				"original", addonName, "target", tocName, "reason", m.conflictError(tocName, gitURL))
 		} else {
 			if err := os.Rename(addonPath, newPath); err != nil {
 				m.log.Warn("Failed to rename addon folder", "error", err)
@@ -138,6 +273,7 @@ func (m *Manager) Install(gitURL string, progressWriter io.Writer) (*InstallResu
 	now := time.Now()
 	meta := AddonMetadata{
 		GitURL:      gitURL,
+		Ref:         ref,
 		InstalledAt: now,
 		UpdatedAt:   now,
 	}
@@ -150,69 +286,242 @@ func (m *Manager) Install(gitURL string, progressWriter io.Writer) (*InstallResu
 	result := &InstallResult{
 		Name: addonName,
 		Path: addonPath,
+		Ref:  ref,
 	}
+	m.applyTOCInfo(result, tocInfo)
+
+	m.log.Info("Addon installed", "name", addonName, "url", gitURL, "ref", ref)
+	return result, nil
+}
+
+// applyTOCInfo fills the title and compatibility warnings of an install result
+func (m *Manager) applyTOCInfo(result *InstallResult, tocInfo *TOCInfo) {
 	if tocInfo != nil && tocInfo.Title != "" {
 		result.Title = tocInfo.Title
 	} else {
-		result.Title = addonName
+		result.Title = result.Name
 	}
+	if tocInfo != nil {
+		result.MissingDependencies = m.MissingDependencies(tocInfo.Dependencies)
+		if len(result.MissingDependencies) > 0 {
+			m.log.Warn("Addon has missing dependencies",
+				"name", result.Name, "missing", strings.Join(result.MissingDependencies, ", "))
+		}
+		result.InterfaceWarning = InterfaceWarning(tocInfo.Interface)
+		if result.InterfaceWarning != "" {
+			m.log.Warn("Addon interface version mismatch",
+				"name", result.Name, "interface", tocInfo.Interface, "expected", ExpectedInterfaceVersion())
+		}
+	}
+}
 
-	m.log.Info("Addon installed", "name", addonName, "url", gitURL)
-	return result, nil
+// RemoveResult contains information about a completed removal
+type RemoveResult struct {
+	Name                 string
+	BackupPath           string // Addon folder backup (empty if none)
+	BackupErr            error  // Why the requested folder backup failed (the addon is removed anyway)
+	SavedVariablesBackup string // SavedVariables backup (empty if none)
 }
 
 // Remove removes an installed addon
-func (m *Manager) Remove(name string, createBackup bool) error {
+func (m *Manager) Remove(name string, createBackup bool) (*RemoveResult, error) {
 	addonPath := filepath.Join(m.addonsDir, name)
+	if m.IsDisabled(name) {
+		addonPath = filepath.Join(m.disabledDir, name)
+	}
 
 	// Check addon exists
 	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
-		return fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
 	}
 
+	result := &RemoveResult{Name: name}
+
 	// Create backup if requested
 	if createBackup {
 		backupPath, err := m.backup.CreateBackup(addonPath, name)
 		if err != nil {
 			m.log.Warn("Failed to create backup", "error", err)
+			result.BackupErr = err
 		} else {
 			m.log.Info("Backup created", "path", backupPath)
+			result.BackupPath = backupPath
 		}
 	}
+	result.SavedVariablesBackup = m.backupSV(name)
 
 	// Remove the addon directory
 	if err := os.RemoveAll(addonPath); err != nil {
-		return fmt.Errorf("failed to remove addon: %w", err)
+		return nil, fmt.Errorf("failed to remove addon: %w", err)
 	}
+	m.pruneSV(name)
 
-	// Remove from store
+	// Remove from store, along with a pack clone no other addon uses
+	meta, _ := m.store.Get(name)
 	m.store.Delete(name)
 	if err := m.store.Save(); err != nil {
 		m.log.Warn("Failed to save store after removal", "error", err)
 	}
+	if meta.Pack != "" {
+		m.prunePack(meta.Pack)
+	}
 
 	m.log.Info("Addon removed", "name", name)
-	return nil
+	return result, nil
+}
+
+// RestoreResult contains information about a completed restore
+type RestoreResult struct {
+	Name      string
+	Timestamp string // Backup timestamp that was restored
+	Path      string
+}
+
+// Restore restores an addon from a backup
+// timestamp selects the backup to restore, empty means the latest one
+// Unless force is set, an existing install newer than the backup is left untouched
+func (m *Manager) Restore(name, timestamp string, force bool) (*RestoreResult, error) {
+	if timestamp == "" {
+		latest, err := m.backup.GetLatestBackup(name)
+		if err != nil {
+			return nil, err
+		}
+		timestamp = latest
+	}
+
+	backupTime, err := time.ParseInLocation(BackupTimestampFormat, timestamp, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup timestamp: %s", timestamp)
+	}
+
+	addonPath := filepath.Join(m.addonsDir, name)
+
+	// Refuse to clobber an install that is newer than the backup
+	if info, err := os.Stat(addonPath); err == nil && !force {
+		installedAt := info.ModTime()
+		if meta, ok := m.store.Get(name); ok && !meta.UpdatedAt.IsZero() {
+			installedAt = meta.UpdatedAt
+		}
+		if installedAt.After(backupTime) {
+			return nil, fmt.Errorf("%w: %s (installed %s, backup %s)", ErrNewerInstall, name,
+				installedAt.Format("2006-01-02 15:04:05"), backupTime.Format("2006-01-02 15:04:05"))
+		}
+	}
+
+	if err := m.EnsureAddonsDir(); err != nil {
+		return nil, err
+	}
+
+	if err := m.backup.RestoreBackup(name, timestamp, addonPath); err != nil {
+		return nil, err
+	}
+
+	// Re-register metadata, keeping existing tracking info when available
+	now := time.Now()
+	meta, ok := m.store.Get(name)
+	if !ok {
+		meta = AddonMetadata{InstalledAt: now}
+	}
+	// Backups without .git record their remote instead, the next update re-clones
+	source := takeBackupSource(addonPath)
+	if url, err := GetRepoRemoteURL(addonPath); err == nil {
+		meta.GitURL = url
+	} else if source != "" {
+		meta.GitURL = source
+	}
+	meta.UpdatedAt = now
+	if meta.GitURL != "" {
+		m.store.Set(name, meta)
+		if err := m.store.Save(); err != nil {
+			m.log.Warn("Failed to save store after restore", "error", err)
+		}
+	}
+
+	m.log.Info("Addon restored from backup", "name", name, "backup", timestamp)
+	return &RestoreResult{
+		Name:      name,
+		Timestamp: timestamp,
+		Path:      addonPath,
+	}, nil
 }
 
 // UpdateResult contains information about an update operation
 type UpdateResult struct {
-	Updated         bool
-	AlreadyUpToDate bool
-	ReCloned        bool
+	Updated              bool
+	AlreadyUpToDate      bool
+	ReCloned             bool
+	SavedVariablesBackup string // SavedVariables backup taken before updating (empty if none)
+
+	OldCommit string          // Short HEAD hash before updating (empty when re-cloned)
+	NewCommit string          // Short HEAD hash after updating
+	Commits   []CommitSummary // Commits pulled in by the update, newest first
+}
+
+// UpdateDryRun reports what Update would do for an addon without changing anything
+func (m *Manager) UpdateDryRun(ctx context.Context, name string) (*UpdatePreview, error) {
+	addonPath := filepath.Join(m.addonsDir, name)
+
+	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
+		if m.IsDisabled(name) {
+			return nil, fmt.Errorf("%w: %s", ErrAddonDisabled, name)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+
+	meta, ok := m.store.Get(name)
+	if ok && meta.GitURL == "" && meta.LocalSource != "" {
+		return nil, fmt.Errorf("%w: %s", ErrLocalSource, name)
+	}
+	if meta.PinnedCommit != "" {
+		return nil, fmt.Errorf("%w: %s (at %s)", ErrAddonPinned, name, ShortCommit(meta.PinnedCommit))
+	}
+
+	repoPath := m.RepoPath(name)
+	if !IsGitRepo(repoPath) {
+		if !ok || meta.GitURL == "" {
+			return nil, fmt.Errorf("addon is not a git repository and has no stored URL")
+		}
+		return &UpdatePreview{HasUpdate: true, ReClone: true}, nil
+	}
+
+	if meta.Pack != "" {
+		lock := m.packLock(meta.Pack)
+		lock.Lock()
+		defer lock.Unlock()
+	}
+	return PreviewUpdate(ctx, repoPath, meta.Ref)
 }
 
 // Update updates an addon using git fast-forward
 // progressWriter can be nil to disable progress output
-func (m *Manager) Update(name string, progressWriter io.Writer) (*UpdateResult, error) {
+func (m *Manager) Update(ctx context.Context, name string, progressWriter io.Writer) (*UpdateResult, error) {
 	addonPath := filepath.Join(m.addonsDir, name)
 	result := &UpdateResult{}
 
 	// Check addon exists
 	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
+		if m.IsDisabled(name) {
+			return nil, fmt.Errorf("%w: %s", ErrAddonDisabled, name)
+		}
 		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
 	}
 
+	if meta, ok := m.store.Get(name); ok && meta.GitURL == "" && meta.LocalSource != "" {
+		return nil, fmt.Errorf("%w: %s", ErrLocalSource, name)
+	} else if meta.PinnedCommit != "" {
+		return nil, fmt.Errorf("%w: %s (at %s, unpin it to update)", ErrAddonPinned, name, ShortCommit(meta.PinnedCommit))
+	}
+
+	// Fail before backups or re-clones touch anything
+	if offline.Enabled() {
+		return nil, offline.ErrOffline
+	}
+
+	// Pack members update together through their shared clone
+	if meta, ok := m.store.Get(name); ok && meta.Pack != "" {
+		return m.updatePack(ctx, name, meta, progressWriter)
+	}
+
 	// Check it's a git repo
 	if !IsGitRepo(addonPath) {
 		// Try to get URL from store and re-clone
@@ -225,14 +534,16 @@ func (m *Manager) Update(name string, progressWriter io.Writer) (*UpdateResult,
 		if _, err := m.backup.CreateBackup(addonPath, name); err != nil {
 			m.log.Warn("Failed to create backup before re-clone", "error", err)
 		}
+		result.SavedVariablesBackup = m.backupSV(name)
 
 		if err := os.RemoveAll(addonPath); err != nil {
 			return nil, fmt.Errorf("failed to remove for re-clone: %w", err)
 		}
 
-		if err := CloneRepo(meta.GitURL, addonPath, progressWriter); err != nil {
+		if err := CloneRepo(ctx, meta.GitURL, addonPath, meta.Ref, ShallowDepth, progressWriter); err != nil {
 			return nil, err
 		}
+		m.pruneSV(name)
 
 		meta.UpdatedAt = time.Now()
 		m.store.Set(name, meta)
@@ -240,11 +551,29 @@ func (m *Manager) Update(name string, progressWriter io.Writer) (*UpdateResult,
 
 		result.Updated = true
 		result.ReCloned = true
+		result.NewCommit, _ = GetCurrentCommit(addonPath)
 		return result, nil
 	}
 
-	// Perform git update
-	err := UpdateRepo(addonPath, progressWriter)
+	// Perform git update (respecting a pinned ref if any)
+	// Only back up SavedVariables when there is something to update: the
+	// reset can't be undone, but a backup per no-op run would crowd out the
+	// good ones
+	meta, _ := m.store.Get(name)
+	preview, err := PreviewUpdate(ctx, addonPath, meta.Ref)
+	if err == nil && !preview.HasUpdate {
+		err = ErrAlreadyUpToDate
+	}
+	if err == nil {
+		result.SavedVariablesBackup = m.backupSV(name)
+		result.OldCommit, _ = GetCurrentCommit(addonPath)
+		err = UpdateRepo(ctx, addonPath, meta.Ref, progressWriter)
+	}
+	if err != nil && result.SavedVariablesBackup != "" {
+		// Nothing changed, drop the backup rather than piling them up
+		_ = os.RemoveAll(result.SavedVariablesBackup)
+		result.SavedVariablesBackup = ""
+	}
 	if errors.Is(err, ErrAlreadyUpToDate) {
 		m.log.Debug("Addon already up to date", "name", name)
 		result.AlreadyUpToDate = true
@@ -264,8 +593,17 @@ func (m *Manager) Update(name string, progressWriter io.Writer) (*UpdateResult,
 		_ = m.store.Save()
 	}
 
+	m.pruneSV(name)
+
+	result.NewCommit, _ = GetCurrentCommit(addonPath)
+	if commits, err := CommitsSince(addonPath, result.OldCommit); err == nil {
+		result.Commits = commits
+	} else {
+		m.log.Debug("Failed to read changelog", "name", name, "error", err)
+	}
+
 	result.Updated = true
-	m.log.Info("Addon updated", "name", name)
+	m.log.Info("Addon updated", "name", name, "from", result.OldCommit, "to", result.NewCommit)
 	return result, nil
 }
 
@@ -275,79 +613,235 @@ type UpdateAllResult struct {
 	Failed  int
 	Skipped int
 	Errors  []string
+	Names   []string // Addons that were updated
 }
 
-// UpdateAll updates all tracked addons
-func (m *Manager) UpdateAll() *UpdateAllResult {
-	result := &UpdateAllResult{}
-	addons := m.store.List()
+// DefaultUpdateConcurrency is the number of addons updated in parallel by default
+const DefaultUpdateConcurrency = 4
 
-	for _, name := range addons {
-		updateResult, err := m.Update(name, nil)
-		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
-			continue
+// updateConcurrency is set from the config file, see SetUpdateConcurrency
+var updateConcurrency int
+
+// SetUpdateConcurrency sets the number of parallel addon updates, over
+// TURTLECTL_UPDATE_JOBS. 0 restores the default
+func SetUpdateConcurrency(n int) {
+	updateConcurrency = n
+}
+
+// UpdateConcurrency returns the number of parallel addon updates
+// SetUpdateConcurrency, then TURTLECTL_UPDATE_JOBS, override the default
+func UpdateConcurrency() int {
+	if updateConcurrency > 0 {
+		return updateConcurrency
+	}
+	if v := os.Getenv("TURTLECTL_UPDATE_JOBS"); v != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n
 		}
+	}
+	return DefaultUpdateConcurrency
+}
+
+// UpdateAll updates all tracked addons using up to concurrency workers
+// Disabled and pinned addons are left out
+// Each addon is its own repository, so fetches don't contend; store saves are
+// serialized by the store lock. Once ctx is done no further addons are started
+func (m *Manager) UpdateAll(ctx context.Context, concurrency int) *UpdateAllResult {
+	result := &UpdateAllResult{}
+	addons := m.GetUpdatableAddons()
 
-		if updateResult.AlreadyUpToDate {
-			result.Skipped++
-		} else if updateResult.Updated {
-			result.Updated++
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(addons) {
+		concurrency = len(addons)
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				updateResult, err := m.Update(ctx, name, nil)
+
+				mu.Lock()
+				switch {
+				case errors.Is(err, ErrLocalSource):
+					result.Skipped++
+				case err != nil:
+					result.Failed++
+					result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", name, err))
+				case updateResult.AlreadyUpToDate:
+					result.Skipped++
+				case updateResult.Updated:
+					result.Updated++
+					result.Names = append(result.Names, name)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, name := range addons {
+		select {
+		case jobs <- name:
+		case <-ctx.Done():
+			break feed
 		}
 	}
+	close(jobs)
+	wg.Wait()
 
+	sort.Strings(result.Errors)
+	sort.Strings(result.Names)
 	return result
 }
 
-// GetTrackedAddons returns the list of tracked addon names
+// GetTrackedAddons returns the names of tracked addons that are not disabled
 func (m *Manager) GetTrackedAddons() []string {
-	return m.store.List()
+	var names []string
+	for name, meta := range m.store.All() {
+		if !meta.Disabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // CheckUpdatesResult contains information about available updates
 type CheckUpdatesResult struct {
 	Name      string
+	Current   string // Short HEAD hash
+	Latest    string // Short hash of the update target (empty if the check failed)
+	Ref       string // Pinned branch, tag, or commit (empty for default branch)
 	HasUpdate bool
 	Error     error
 }
 
 // CheckAllUpdates checks all tracked addons for available updates
-func (m *Manager) CheckAllUpdates() []CheckUpdatesResult {
+// Returns no results in offline mode since checking requires a fetch
+func (m *Manager) CheckAllUpdates(ctx context.Context) []CheckUpdatesResult {
+	return m.CheckUpdates(ctx, m.GetUpdatableAddons())
+}
+
+// UpdateCheckTimeout bounds the fetch of a single addon during an update check,
+// so one hung remote doesn't stall the whole check
+const UpdateCheckTimeout = 30 * time.Second
+
+// CheckUpdates checks the named addons for available updates without applying them,
+// fetching up to UpdateConcurrency addons in parallel
+// Addons that aren't git repositories are skipped, results keep the order of names
+func (m *Manager) CheckUpdates(ctx context.Context, names []string) []CheckUpdatesResult {
 	var results []CheckUpdatesResult
-	tracked := m.store.List()
+	if offline.Enabled() {
+		m.log.Debug("Offline, skipping update check")
+		return results
+	}
 
-	for _, name := range tracked {
-		addonPath := filepath.Join(m.addonsDir, name)
+	concurrency := UpdateConcurrency()
+	if concurrency > len(names) {
+		concurrency = len(names)
+	}
 
-		// Skip if not a git repo
-		if !IsGitRepo(addonPath) {
-			continue
-		}
+	var (
+		wg      sync.WaitGroup
+		jobs    = make(chan int)
+		checked = make([]*CheckUpdatesResult, len(names))
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				checked[idx] = m.checkUpdate(ctx, names[idx])
+			}
+		}()
+	}
 
-		hasUpdate, err := CheckForUpdates(addonPath)
-		results = append(results, CheckUpdatesResult{
-			Name:      name,
-			HasUpdate: hasUpdate,
-			Error:     err,
-		})
+feed:
+	for idx := range names {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break feed
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
+	for _, result := range checked {
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
 	return results
 }
 
+// checkUpdate checks a single addon for an update, nil if it isn't a git
+// repo or is pinned
+func (m *Manager) checkUpdate(ctx context.Context, name string) *CheckUpdatesResult {
+	repoPath := m.RepoPath(name)
+
+	// Skip if not a git repo
+	if !IsGitRepo(repoPath) || m.isPinned(name) {
+		return nil
+	}
+
+	meta, _ := m.store.Get(name)
+	if meta.Pack != "" {
+		lock := m.packLock(meta.Pack)
+		lock.Lock()
+		defer lock.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, UpdateCheckTimeout)
+	defer cancel()
+
+	result := &CheckUpdatesResult{Name: name, Ref: meta.Ref}
+	preview, err := PreviewUpdate(ctx, repoPath, meta.Ref)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s: %w", UpdateCheckTimeout, err)
+		}
+		result.Error = err
+		if current, err := GetCurrentCommit(repoPath); err == nil {
+			result.Current = current[:shortHashLen]
+		}
+	} else {
+		result.Current = preview.From
+		result.Latest = preview.To
+		result.HasUpdate = preview.HasUpdate
+	}
+	return result
+}
+
 // GetInfo returns detailed information about an addon
 func (m *Manager) GetInfo(name string) (*Addon, error) {
 	addonPath := filepath.Join(m.addonsDir, name)
+	disabled := false
 
-	// Check addon exists
+	// Check addon exists, falling back to the disabled directory
 	if _, err := os.Stat(addonPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+		if !m.IsDisabled(name) {
+			return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+		}
+		addonPath = filepath.Join(m.disabledDir, name)
+		disabled = true
 	}
 
 	addon := &Addon{
-		Name: name,
-		Path: addonPath,
+		Name:     name,
+		Path:     addonPath,
+		Disabled: disabled,
 	}
 
 	// Get .toc info
@@ -358,12 +852,20 @@ func (m *Manager) GetInfo(name string) (*Addon, error) {
 			addon.Version = tocInfo.Version
 			addon.Author = tocInfo.Author
 			addon.Notes = tocInfo.Notes
+			addon.Dependencies = tocInfo.Dependencies
+			addon.Interface = tocInfo.Interface
+			addon.Website = tocInfo.Website()
+			addon.Category = tocInfo.Category()
+			addon.InterfaceWarning = InterfaceWarning(tocInfo.Interface)
 		}
 	}
 
 	// Get stored metadata
 	if meta, ok := m.store.Get(name); ok {
 		addon.GitURL = meta.GitURL
+		addon.Ref = meta.Ref
+		addon.Pack = meta.Pack
+		addon.PinnedCommit = meta.PinnedCommit
 		addon.InstalledAt = meta.InstalledAt
 		addon.UpdatedAt = meta.UpdatedAt
 	} else {
@@ -376,42 +878,89 @@ func (m *Manager) GetInfo(name string) (*Addon, error) {
 	return addon, nil
 }
 
-// ListInstalled returns all installed addons
-func (m *Manager) ListInstalled() ([]*Addon, error) {
-	entries, err := os.ReadDir(m.addonsDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []*Addon{}, nil
+// MissingDependencies returns the dependencies with no matching folder in the AddOns directory
+// Folder names are compared case-insensitively, as the game client does
+func (m *Manager) MissingDependencies(deps []string) []string {
+	if len(deps) == 0 {
+		return nil
+	}
+
+	installed := make(map[string]bool)
+	if entries, err := os.ReadDir(m.addonsDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				installed[strings.ToLower(entry.Name())] = true
+			}
 		}
-		return nil, err
 	}
 
-	var addons []*Addon
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+	var missing []string
+	for _, dep := range deps {
+		if !installed[strings.ToLower(dep)] {
+			missing = append(missing, dep)
 		}
+	}
+	return missing
+}
 
-		// Skip hidden directories
-		if strings.HasPrefix(entry.Name(), ".") {
-			continue
+// InstalledURLs returns the git URLs of installed addons, for matching
+// against the addon registry
+func (m *Manager) InstalledURLs() map[string]bool {
+	urls := make(map[string]bool)
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return urls
+	}
+	for _, addon := range installed {
+		if addon.GitURL != "" {
+			urls[addon.GitURL] = true
 		}
+	}
+	return urls
+}
 
-		addon, err := m.GetInfo(entry.Name())
+// ListInstalled returns all installed addons
+func (m *Manager) ListInstalled() ([]*Addon, error) {
+	var addons []*Addon
+	for _, dir := range []string{m.addonsDir, m.disabledDir} {
+		entries, err := os.ReadDir(dir)
 		if err != nil {
-			// Include addon even if we can't get full info
-			addon = &Addon{
-				Name: entry.Name(),
-				Path: filepath.Join(m.addonsDir, entry.Name()),
+			if os.IsNotExist(err) {
+				continue
 			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			// Skip hidden directories
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			addon, err := m.GetInfo(entry.Name())
+			if err != nil {
+				// Include addon even if we can't get full info
+				addon = &Addon{
+					Name:     entry.Name(),
+					Path:     filepath.Join(dir, entry.Name()),
+					Disabled: dir == m.disabledDir,
+				}
+			}
+			addons = append(addons, addon)
 		}
-		addons = append(addons, addon)
 	}
 
-	// Sort by status (default first, then tracked, then untracked), then by name
+	// Sort by status (default first, then tracked, untracked, disabled), then by name
 	sort.Slice(addons, func(i, j int) bool {
-		// Get status priority: default=0, tracked=1, untracked=2
+		// Get status priority: default=0, tracked=1, untracked=2, disabled=3
 		getPriority := func(a *Addon) int {
+			if a.Disabled {
+				return 3
+			}
 			if IsDefaultAddon(a.Name) {
 				return 0
 			}
@@ -475,9 +1024,9 @@ func (m *Manager) Repair() (*RepairResult, error) {
 		}
 	}
 
-	// Check for orphaned entries (in store but no folder)
+	// Check for orphaned entries (in store but no folder, enabled or disabled)
 	for _, name := range m.store.List() {
-		if !installedFolders[name] {
+		if !installedFolders[name] && !m.IsDisabled(name) {
 			result.OrphanedEntries = append(result.OrphanedEntries, name)
 			result.IssuesFound++
 		}
@@ -526,6 +1075,27 @@ func (m *Manager) Repair() (*RepairResult, error) {
 		}
 	}
 
+	// Check the shared clones of multi-addon repos (update re-clones missing ones)
+	packs := make(map[string]bool)
+	for _, meta := range storedAddons {
+		if meta.Pack != "" {
+			packs[meta.Pack] = true
+		}
+	}
+	for pack := range packs {
+		packPath := filepath.Join(m.packsDir, pack)
+		if IsGitRepo(packPath) {
+			if err := VerifyRepoIntegrity(packPath); err != nil {
+				result.CorruptedRepos = append(result.CorruptedRepos, packsDirName+"/"+pack)
+				result.IssuesFound++
+			}
+		}
+	}
+
+	// Check for folders claimed by more than one repository
+	result.FolderConflicts = m.findFolderConflicts(installedFolders)
+	result.IssuesFound += len(result.FolderConflicts)
+
 	// Remove orphaned entries
 	for _, name := range result.OrphanedEntries {
 		m.store.Delete(name)
@@ -545,6 +1115,11 @@ func (m *Manager) GetAddonsDir() string {
 	return m.addonsDir
 }
 
+// GetStorePath returns the path of the addon metadata store
+func (m *Manager) GetStorePath() string {
+	return m.store.Path()
+}
+
 // GetBackupManager returns the backup manager
 func (m *Manager) GetBackupManager() *BackupManager {
 	return m.backup
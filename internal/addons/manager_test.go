@@ -0,0 +1,95 @@
+package addons
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+func TestUpdateKeepsSavedVariablesBackups(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	m.store.Set("Fixture", AddonMetadata{GitURL: src})
+	writePackFile(t, m.GetGameDir(), "WTF/Account/ME/SavedVariables/Fixture.lua", "FixtureDB = {}")
+
+	bm := m.GetBackupManager()
+	old := []string{"20240301-120000", "20240201-120000", "20240101-120000"}
+	for _, stamp := range old {
+		writePackFile(t, filepath.Join(bm.savedVariablesDir("Fixture"), stamp), "Account/ME/SavedVariables/Fixture.lua", "old")
+	}
+
+	// Runs with nothing to update, e.g. from cron, must not touch the backups
+	for i := 0; i < 4; i++ {
+		result, err := m.Update(context.Background(), "Fixture", nil)
+		if err != nil {
+			t.Fatalf("Update() returned error: %v", err)
+		}
+		if !result.AlreadyUpToDate || result.SavedVariablesBackup != "" {
+			t.Fatalf("unexpected result for a no-op update: %+v", result)
+		}
+	}
+	if backups, _ := bm.ListSavedVariablesBackups("Fixture"); !slices.Equal(backups, old) {
+		t.Fatalf("no-op updates changed the backups: %v", backups)
+	}
+
+	// A real update backs up first, then prunes the oldest one
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+	result, err := m.Update(context.Background(), "Fixture", nil)
+	if err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+	if !result.Updated || result.SavedVariablesBackup == "" {
+		t.Fatalf("expected an update with a backup: %+v", result)
+	}
+	backups, _ := bm.ListSavedVariablesBackups("Fixture")
+	want := []string{filepath.Base(result.SavedVariablesBackup), old[0], old[1]}
+	if !slices.Equal(backups, want) {
+		t.Fatalf("backups after update = %v, want %v", backups, want)
+	}
+}
+
+func TestCheckUpdatesKeepsOrderAndIsolatesErrors(t *testing.T) {
+	src, _ := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	names := []string{"Charlie", "Alpha", "Broken", "Bravo"}
+	for _, name := range names {
+		if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), name), "", ShallowDepth, nil); err != nil {
+			t.Fatalf("CloneRepo() returned error: %v", err)
+		}
+	}
+	// Point one addon at a remote that doesn't exist
+	brokenRepo, err := git.PlainOpen(filepath.Join(m.GetAddonsDir(), "Broken"))
+	if err != nil {
+		t.Fatalf("PlainOpen() returned error: %v", err)
+	}
+	if err := brokenRepo.DeleteRemote("origin"); err != nil {
+		t.Fatalf("DeleteRemote() returned error: %v", err)
+	}
+	if _, err := brokenRepo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{filepath.Join(t.TempDir(), "missing")}}); err != nil {
+		t.Fatalf("CreateRemote() returned error: %v", err)
+	}
+
+	results := m.CheckUpdates(context.Background(), names)
+	if len(results) != len(names) {
+		t.Fatalf("expected %d results, got %+v", len(names), results)
+	}
+	for i, result := range results {
+		if result.Name != names[i] {
+			t.Fatalf("result %d is %s, want %s", i, result.Name, names[i])
+		}
+		if (result.Error != nil) != (result.Name == "Broken") {
+			t.Fatalf("unexpected error state for %s: %v", result.Name, result.Error)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package addons
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ManifestVersion is the current manifest format version
+const ManifestVersion = 1
+
+// unmanagedComment explains why an entry won't be reinstalled on import
+const unmanagedComment = "No git URL tracked, will not be reinstalled"
+
+// Manifest is a portable list of addons that can be exported and re-imported
+type Manifest struct {
+	Version int             `json:"version"`
+	Addons  []ManifestEntry `json:"addons"`
+}
+
+// ManifestEntry describes a single addon in a manifest
+type ManifestEntry struct {
+	Name      string `json:"name"`
+	GitURL    string `json:"git_url,omitempty"`
+	Ref       string `json:"ref,omitempty"`
+	Pack      string `json:"pack,omitempty"` // Installed from a multi-addon repo along with the other entries naming it
+	Unmanaged bool   `json:"unmanaged,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// ImportResult contains the outcome of importing a manifest
+type ImportResult struct {
+	Installed []string
+	Skipped   []string // Already installed
+	Unmanaged []string // No git URL in the manifest
+	Failed    []string
+	Errors    []string
+}
+
+// ReadManifest decodes a manifest from r
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// WriteManifest encodes a manifest to w as indented JSON
+func WriteManifest(w io.Writer, manifest *Manifest) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(manifest)
+}
+
+// Export builds a manifest of tracked addons
+// Installed addons without a git URL are included as unmanaged entries
+func (m *Manager) Export() (*Manifest, error) {
+	manifest := &Manifest{Version: ManifestVersion}
+
+	tracked := m.store.All()
+	for name, meta := range tracked {
+		entry := ManifestEntry{Name: name, GitURL: meta.GitURL, Ref: meta.Ref, Pack: meta.Pack}
+		if entry.GitURL == "" {
+			entry.Unmanaged = true
+			entry.Comment = unmanagedComment
+		}
+		manifest.Addons = append(manifest.Addons, entry)
+	}
+
+	installed, err := m.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+	for _, addon := range installed {
+		if _, ok := tracked[addon.Name]; ok {
+			continue
+		}
+		entry := ManifestEntry{Name: addon.Name, GitURL: addon.GitURL}
+		if entry.GitURL == "" {
+			entry.Unmanaged = true
+			entry.Comment = unmanagedComment
+		}
+		manifest.Addons = append(manifest.Addons, entry)
+	}
+
+	sort.Slice(manifest.Addons, func(i, j int) bool {
+		return manifest.Addons[i].Name < manifest.Addons[j].Name
+	})
+
+	return manifest, nil
+}
+
+// Import installs every manifest entry that isn't already present
+// Entries from the same multi-addon repo are installed together, limited to
+// the folders listed in the manifest
+// onInstall, if not nil, is called before each install attempt
+func (m *Manager) Import(ctx context.Context, manifest *Manifest, onInstall func(entry ManifestEntry)) *ImportResult {
+	result := &ImportResult{}
+
+	packFolders := make(map[string][]string) // Pack -> folders to install
+	for _, entry := range manifest.Addons {
+		if entry.Pack != "" && entry.GitURL != "" {
+			packFolders[entry.Pack] = append(packFolders[entry.Pack], entry.Name)
+		}
+	}
+	installed := make(map[string]bool)
+
+	for _, entry := range manifest.Addons {
+		if installed[entry.Name] {
+			continue
+		}
+		if entry.Name != "" {
+			if m.addonExists(entry.Name) {
+				result.Skipped = append(result.Skipped, entry.Name)
+				continue
+			}
+		}
+
+		if entry.Unmanaged || entry.GitURL == "" {
+			result.Unmanaged = append(result.Unmanaged, entry.Name)
+			continue
+		}
+
+		if onInstall != nil {
+			onInstall(entry)
+		}
+
+		gitURL := entry.GitURL
+		if entry.Ref != "" {
+			gitURL += "@" + entry.Ref
+		}
+
+		var opts InstallOptions
+		for _, folder := range packFolders[entry.Pack] {
+			if !m.addonExists(folder) {
+				opts.Only = append(opts.Only, folder)
+			}
+		}
+
+		installResult, err := m.InstallWithOptions(ctx, gitURL, opts, nil)
+		if errors.Is(err, ErrAddonExists) {
+			result.Skipped = append(result.Skipped, entry.Name)
+			continue
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, entry.Name)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", entry.Name, err))
+			continue
+		}
+
+		if len(installResult.Folders) > 0 {
+			for _, folder := range installResult.Folders {
+				installed[folder] = true
+			}
+			result.Installed = append(result.Installed, installResult.Folders...)
+			continue
+		}
+		result.Installed = append(result.Installed, installResult.Name)
+	}
+
+	return result
+}
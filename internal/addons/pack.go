@@ -0,0 +1,278 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// packsDirName is the sibling of AddOns holding clones of multi-addon repositories
+// Their addon folders are copied into AddOns, the game ignores the clones
+const packsDirName = "AddOnPacks"
+
+var (
+	ErrNotPack    = errors.New("not a multi-addon repository")
+	ErrPackFolder = errors.New("folder not found in multi-addon repository")
+)
+
+// packLock serializes git operations on a pack's shared clone, since every
+// member addon updates and checks through it
+func (m *Manager) packLock(pack string) *sync.Mutex {
+	lock, _ := m.packLocks.LoadOrStore(pack, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// RepoPath returns the git repository backing an addon: the shared clone for
+// pack members, the addon folder itself otherwise
+func (m *Manager) RepoPath(name string) string {
+	if meta, ok := m.store.Get(name); ok && meta.Pack != "" {
+		return filepath.Join(m.packsDir, meta.Pack)
+	}
+	return filepath.Join(m.addonsDir, name)
+}
+
+// packMembers returns the tracked addons installed from a pack, enabled or disabled
+func (m *Manager) packMembers(pack string) []string {
+	var names []string
+	for name, meta := range m.store.All() {
+		if meta.Pack == pack {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectPackFolders narrows a pack's addon folders to only, if set
+func selectPackFolders(folders, only []string) ([]string, error) {
+	if len(only) == 0 {
+		return folders, nil
+	}
+
+	var selected []string
+	for _, name := range only {
+		if !slices.Contains(folders, name) {
+			return nil, fmt.Errorf("%w: %s (available: %s)", ErrPackFolder, name, strings.Join(folders, ", "))
+		}
+		if !slices.Contains(selected, name) {
+			selected = append(selected, name)
+		}
+	}
+	return selected, nil
+}
+
+// installPack moves a fresh clone of a multi-addon repository into the packs
+// directory and copies the selected addon folders into Interface/AddOns, each
+// tracked as its own addon sharing the git URL
+// The fresh clone is removed if anything fails
+func (m *Manager) installPack(clonePath, gitURL, ref string, folders []string, opts InstallOptions) (*InstallResult, error) {
+	pack := filepath.Base(clonePath)
+	packPath := filepath.Join(m.packsDir, pack)
+
+	selected, err := selectPackFolders(folders, opts.Only)
+	if err == nil {
+		for _, folder := range selected {
+			if m.addonExists(folder) {
+				err = m.conflictError(folder, gitURL)
+				break
+			}
+		}
+	}
+	if err == nil {
+		if _, statErr := os.Stat(packPath); statErr == nil {
+			err = fmt.Errorf("%w: %s is already installed as a multi-addon repository", ErrAddonExists, pack)
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(clonePath)
+		return nil, err
+	}
+
+	if err := os.MkdirAll(m.packsDir, 0755); err != nil {
+		_ = os.RemoveAll(clonePath)
+		return nil, fmt.Errorf("%w: %v", ErrAddonsDir, err)
+	}
+	if err := os.Rename(clonePath, packPath); err != nil {
+		_ = os.RemoveAll(clonePath)
+		return nil, fmt.Errorf("failed to move clone into place: %w", err)
+	}
+
+	for i, folder := range selected {
+		if err := copyDir(filepath.Join(packPath, folder), filepath.Join(m.addonsDir, folder)); err != nil {
+			for _, copied := range selected[:i+1] {
+				_ = os.RemoveAll(filepath.Join(m.addonsDir, copied))
+			}
+			_ = os.RemoveAll(packPath)
+			return nil, fmt.Errorf("failed to install %s: %w", folder, err)
+		}
+	}
+
+	now := time.Now()
+	for _, folder := range selected {
+		m.store.Set(folder, AddonMetadata{
+			GitURL:      gitURL,
+			Ref:         ref,
+			Pack:        pack,
+			InstalledAt: now,
+			UpdatedAt:   now,
+		})
+	}
+	if err := m.store.Save(); err != nil {
+		m.log.Warn("Failed to save addon metadata", "error", err)
+	}
+
+	// Dependencies between the pack's own addons are satisfied now
+	var deps []string
+	var first *TOCInfo
+	for _, folder := range selected {
+		info, err := ParseTOC(filepath.Join(m.addonsDir, folder, folder+".toc"))
+		if err != nil {
+			continue
+		}
+		if first == nil {
+			first = info
+		}
+		deps = append(deps, info.Dependencies...)
+	}
+
+	result := &InstallResult{
+		Name:    selected[0],
+		Path:    filepath.Join(m.addonsDir, selected[0]),
+		Ref:     ref,
+		Folders: selected,
+	}
+	m.applyTOCInfo(result, first)
+	result.MissingDependencies = m.MissingDependencies(deps)
+
+	m.log.Info("Multi-addon repository installed", "pack", pack, "addons", strings.Join(selected, ", "), "url", gitURL, "ref", ref)
+	return result, nil
+}
+
+// updatePack updates the shared clone behind a pack member and re-syncs every
+// addon installed from it, so members always stay on the same commit
+func (m *Manager) updatePack(ctx context.Context, name string, meta AddonMetadata, progressWriter io.Writer) (*UpdateResult, error) {
+	lock := m.packLock(meta.Pack)
+	lock.Lock()
+	defer lock.Unlock()
+
+	packPath := filepath.Join(m.packsDir, meta.Pack)
+	members := m.packMembers(meta.Pack)
+	result := &UpdateResult{}
+
+	if IsGitRepo(packPath) {
+		result.OldCommit, _ = GetCurrentCommit(packPath)
+		err := UpdateRepo(ctx, packPath, meta.Ref, progressWriter)
+		if errors.Is(err, ErrAlreadyUpToDate) {
+			m.log.Debug("Addon already up to date", "name", name, "pack", meta.Pack)
+			result.AlreadyUpToDate = true
+			return result, nil
+		}
+		if errors.Is(err, ErrFFNotPossible) {
+			return nil, fmt.Errorf("cannot update %s: local modifications exist in %s (remove and re-install to force)", name, packPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// The shared clone went missing, fetch it again
+		_ = os.RemoveAll(packPath)
+		if err := os.MkdirAll(m.packsDir, 0755); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAddonsDir, err)
+		}
+		if err := CloneRepo(ctx, meta.GitURL, packPath, meta.Ref, ShallowDepth, progressWriter); err != nil {
+			return nil, err
+		}
+		result.ReCloned = true
+	}
+
+	for _, member := range members {
+		if path := m.backupSV(member); member == name {
+			result.SavedVariablesBackup = path
+		}
+	}
+	if err := m.syncPack(meta.Pack, members); err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		m.pruneSV(member)
+	}
+
+	now := time.Now()
+	for _, member := range members {
+		memberMeta, _ := m.store.Get(member)
+		memberMeta.UpdatedAt = now
+		m.store.Set(member, memberMeta)
+	}
+	_ = m.store.Save()
+
+	result.NewCommit, _ = GetCurrentCommit(packPath)
+	if result.OldCommit != "" {
+		if commits, err := CommitsSince(packPath, result.OldCommit); err == nil {
+			result.Commits = commits
+		} else {
+			m.log.Debug("Failed to read changelog", "name", name, "error", err)
+		}
+	}
+
+	result.Updated = true
+	m.log.Info("Multi-addon repository updated", "pack", meta.Pack, "addons", strings.Join(members, ", "),
+		"from", result.OldCommit, "to", result.NewCommit)
+	return result, nil
+}
+
+// syncPack replaces each member's folder with the copy from the pack's clone,
+// in AddOns or the disabled directory depending on where the member lives
+func (m *Manager) syncPack(pack string, members []string) error {
+	packPath := filepath.Join(m.packsDir, pack)
+	for _, member := range members {
+		src := filepath.Join(packPath, member)
+		if _, err := os.Stat(src); err != nil {
+			m.log.Warn("Addon folder is no longer in its repository, keeping the installed copy", "name", member, "pack", pack)
+			continue
+		}
+
+		dir := m.addonsDir
+		if meta, _ := m.store.Get(member); meta.Disabled {
+			dir = m.disabledDir
+		}
+		if err := replaceDir(src, filepath.Join(dir, member)); err != nil {
+			return fmt.Errorf("failed to sync %s: %w", member, err)
+		}
+	}
+	return nil
+}
+
+// replaceDir swaps dst for a copy of src, staging the copy next to dst first
+func replaceDir(src, dst string) error {
+	staging := filepath.Join(filepath.Dir(dst), ".turtlectl-sync-"+filepath.Base(dst))
+	_ = os.RemoveAll(staging)
+	if err := copyDir(src, staging); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		_ = os.RemoveAll(staging)
+		return err
+	}
+	return os.Rename(staging, dst)
+}
+
+// prunePack deletes a pack's clone once no tracked addon uses it anymore
+func (m *Manager) prunePack(pack string) {
+	if len(m.packMembers(pack)) > 0 {
+		return
+	}
+	if err := os.RemoveAll(filepath.Join(m.packsDir, pack)); err != nil {
+		m.log.Warn("Failed to remove multi-addon repository", "pack", pack, "error", err)
+		return
+	}
+	m.log.Info("Removed multi-addon repository with no addons left", "pack", pack)
+}
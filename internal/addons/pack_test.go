@@ -0,0 +1,206 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// newPackFixture creates a repository with the addon folders PackA and PackB
+func newPackFixture(t *testing.T) (string, *git.Repository) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() returned error: %v", err)
+	}
+
+	writePackFile(t, dir, "README.md", "UI pack")
+	writePackFile(t, dir, "PackA/PackA.toc", "## Title: Pack A\n## Dependencies: PackB\n")
+	writePackFile(t, dir, "PackB/PackB.toc", "## Title: Pack B\n")
+	writePackFile(t, dir, "PackB/core.lua", "-- v1")
+	commitPack(t, repo, "initial")
+
+	return dir, repo
+}
+
+func writePackFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll() returned error: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+}
+
+func commitPack(t *testing.T, repo *git.Repository, msg string) {
+	t.Helper()
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() returned error: %v", err)
+	}
+	if err := worktree.AddGlob("."); err != nil {
+		t.Fatalf("AddGlob() returned error: %v", err)
+	}
+	_, err = worktree.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Commit() returned error: %v", err)
+	}
+}
+
+// installPackFixture clones src into AddOns like Install does and installs it as a pack
+func installPackFixture(t *testing.T, m *Manager, src string, only ...string) *InstallResult {
+	t.Helper()
+
+	clonePath := filepath.Join(m.GetAddonsDir(), "ui-pack")
+	if err := CloneRepo(context.Background(), src, clonePath, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	result, err := m.installPack(clonePath, src, "", FindAddonFolders(clonePath), InstallOptions{Only: only})
+	if err != nil {
+		t.Fatalf("installPack() returned error: %v", err)
+	}
+	return result
+}
+
+func TestFindAddonFolders(t *testing.T) {
+	dir := t.TempDir()
+	writePackFile(t, dir, "PackB/PackB.toc", "")
+	writePackFile(t, dir, "PackA/PackA.toc", "")
+	writePackFile(t, dir, "Docs/readme.txt", "")
+	writePackFile(t, dir, "Misnamed/Other.toc", "")
+
+	if got, want := FindAddonFolders(dir), []string{"PackA", "PackB"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindAddonFolders() = %v, want %v", got, want)
+	}
+
+	writePackFile(t, dir, "Root.toc", "")
+	if got := FindAddonFolders(dir); got != nil {
+		t.Fatalf("expected no folders with a root .toc, got %v", got)
+	}
+}
+
+func TestInstallPackTracksEveryFolder(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+
+	result := installPackFixture(t, m, src)
+	if !reflect.DeepEqual(result.Folders, []string{"PackA", "PackB"}) {
+		t.Fatalf("unexpected folders: %v", result.Folders)
+	}
+	if len(result.MissingDependencies) != 0 {
+		t.Fatalf("dependency inside the pack reported missing: %v", result.MissingDependencies)
+	}
+
+	if _, err := os.Stat(filepath.Join(m.GetAddonsDir(), "ui-pack")); !os.IsNotExist(err) {
+		t.Fatal("clone left in the AddOns directory")
+	}
+	for _, name := range result.Folders {
+		if _, err := os.Stat(filepath.Join(m.GetAddonsDir(), name, name+".toc")); err != nil {
+			t.Fatalf("%s not installed: %v", name, err)
+		}
+		meta, ok := m.store.Get(name)
+		if !ok || meta.Pack != "ui-pack" || meta.GitURL != src {
+			t.Fatalf("unexpected metadata for %s: %+v", name, meta)
+		}
+		if !IsGitRepo(m.RepoPath(name)) {
+			t.Fatalf("RepoPath(%s) is not the shared clone", name)
+		}
+	}
+}
+
+func TestInstallPackOnly(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+
+	result := installPackFixture(t, m, src, "PackB")
+	if !reflect.DeepEqual(result.Folders, []string{"PackB"}) {
+		t.Fatalf("unexpected folders: %v", result.Folders)
+	}
+	if m.addonExists("PackA") {
+		t.Fatal("PackA installed without being selected")
+	}
+
+	clonePath := filepath.Join(m.GetAddonsDir(), "other-pack")
+	if err := CloneRepo(context.Background(), src, clonePath, "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	_, err := m.installPack(clonePath, src, "", FindAddonFolders(clonePath), InstallOptions{Only: []string{"Missing"}})
+	if !errors.Is(err, ErrPackFolder) {
+		t.Fatalf("expected ErrPackFolder, got %v", err)
+	}
+	if _, err := os.Stat(clonePath); !os.IsNotExist(err) {
+		t.Fatal("failed install left its clone behind")
+	}
+}
+
+func TestUpdatePackSyncsAllMembers(t *testing.T) {
+	src, srcRepo := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	m.SetBackupSavedVariables(false)
+	installPackFixture(t, m, src)
+
+	writePackFile(t, src, "PackB/core.lua", "-- v2")
+	commitPack(t, srcRepo, "update PackB")
+
+	// Updating one member brings the whole pack forward
+	result, err := m.Update(context.Background(), "PackA", nil)
+	if err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+	if !result.Updated || len(result.Commits) != 1 {
+		t.Fatalf("unexpected update result: %+v", result)
+	}
+	data, err := os.ReadFile(filepath.Join(m.GetAddonsDir(), "PackB", "core.lua"))
+	if err != nil || string(data) != "-- v2" {
+		t.Fatalf("PackB not synced: %q, %v", data, err)
+	}
+
+	result, err = m.Update(context.Background(), "PackB", nil)
+	if err != nil || !result.AlreadyUpToDate {
+		t.Fatalf("expected PackB already up to date, got %+v, %v", result, err)
+	}
+}
+
+func TestRemovePackMemberPrunesClone(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	m.SetBackupSavedVariables(false)
+	installPackFixture(t, m, src)
+	packPath := m.RepoPath("PackA")
+
+	if _, err := m.Remove("PackA", false); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+	if !IsGitRepo(packPath) {
+		t.Fatal("clone removed while PackB still uses it")
+	}
+
+	if _, err := m.Remove("PackB", false); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+	if _, err := os.Stat(packPath); !os.IsNotExist(err) {
+		t.Fatal("clone kept after its last addon was removed")
+	}
+}
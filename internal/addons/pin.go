@@ -0,0 +1,77 @@
+package addons
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pin freezes a tracked git addon at its current commit: Update refuses it
+// and UpdateAll and update checks skip it until Unpin. Returns the full
+// commit hash, which is what gets stored so reinstalling can't pick another
+// commit sharing its abbreviation
+func (m *Manager) Pin(name string) (string, error) {
+	meta, ok := m.store.Get(name)
+	if !ok || !m.addonExists(name) {
+		return "", fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+	if meta.GitURL == "" && meta.LocalSource != "" {
+		return "", fmt.Errorf("%w: %s", ErrLocalSource, name)
+	}
+	if meta.Pack != "" {
+		return "", fmt.Errorf("%s is part of %s, whose addons update together and can't be pinned one by one", name, meta.Pack)
+	}
+	if meta.PinnedCommit != "" {
+		return "", fmt.Errorf("%w: %s (at %s)", ErrAddonPinned, name, ShortCommit(meta.PinnedCommit))
+	}
+
+	commit, err := GetHeadCommit(m.RepoPath(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the current commit of %s: %w", name, err)
+	}
+
+	meta.PinnedCommit = commit
+	m.store.Set(name, meta)
+	if err := m.store.Save(); err != nil {
+		return "", err
+	}
+	m.log.Info("Addon pinned", "name", name, "commit", ShortCommit(commit))
+	return commit, nil
+}
+
+// Unpin lets a pinned addon update again
+func (m *Manager) Unpin(name string) error {
+	meta, ok := m.store.Get(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+	if meta.PinnedCommit == "" {
+		return fmt.Errorf("%w: %s", ErrAddonNotPinned, name)
+	}
+
+	meta.PinnedCommit = ""
+	m.store.Set(name, meta)
+	if err := m.store.Save(); err != nil {
+		return err
+	}
+	m.log.Info("Addon unpinned", "name", name)
+	return nil
+}
+
+// isPinned reports whether an addon is frozen by Pin
+func (m *Manager) isPinned(name string) bool {
+	meta, _ := m.store.Get(name)
+	return meta.PinnedCommit != ""
+}
+
+// GetUpdatableAddons returns the tracked addons that update, leaving out
+// disabled and pinned ones
+func (m *Manager) GetUpdatableAddons() []string {
+	var names []string
+	for name, meta := range m.store.All() {
+		if !meta.Disabled && meta.PinnedCommit == "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,132 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestPinSkipsUpdates(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	m.store.Set("Fixture", AddonMetadata{GitURL: src})
+
+	commit, err := m.Pin("Fixture")
+	if err != nil {
+		t.Fatalf("Pin() returned error: %v", err)
+	}
+	if info, err := m.GetInfo("Fixture"); err != nil || info.PinnedCommit != commit {
+		t.Fatalf("GetInfo() doesn't report the pin %s: %+v, %v", commit, info, err)
+	}
+	if _, err := m.Pin("Fixture"); !errors.Is(err, ErrAddonPinned) {
+		t.Fatalf("expected ErrAddonPinned pinning twice, got %v", err)
+	}
+
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	if names := m.GetUpdatableAddons(); len(names) != 0 {
+		t.Fatalf("pinned addon still updatable: %v", names)
+	}
+	if results := m.CheckUpdates(context.Background(), []string{"Fixture"}); len(results) != 0 {
+		t.Fatalf("pinned addon checked for updates: %+v", results)
+	}
+	if _, err := m.Update(context.Background(), "Fixture", nil); !errors.Is(err, ErrAddonPinned) {
+		t.Fatalf("expected ErrAddonPinned from Update, got %v", err)
+	}
+
+	if err := m.Unpin("Fixture"); err != nil {
+		t.Fatalf("Unpin() returned error: %v", err)
+	}
+	if err := m.Unpin("Fixture"); !errors.Is(err, ErrAddonNotPinned) {
+		t.Fatalf("expected ErrAddonNotPinned, got %v", err)
+	}
+	results := m.CheckUpdates(context.Background(), []string{"Fixture"})
+	if len(results) != 1 || !results[0].HasUpdate {
+		t.Fatalf("expected an update after unpinning, got %+v", results)
+	}
+}
+
+func TestPinRejectsPackMembers(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	installPackFixture(t, m, src)
+
+	if _, err := m.Pin("PackA"); err == nil {
+		t.Fatal("expected an error pinning a pack member")
+	}
+	if _, err := m.Pin("Missing"); !errors.Is(err, ErrAddonNotFound) {
+		t.Fatalf("expected ErrAddonNotFound, got %v", err)
+	}
+}
+
+func TestReinstallRefKeepsPin(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	m.store.Set("Fixture", AddonMetadata{GitURL: src, Ref: "master"})
+
+	commit, err := m.Pin("Fixture")
+	if err != nil {
+		t.Fatalf("Pin() returned error: %v", err)
+	}
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	meta, _ := m.store.Get("Fixture")
+	ref := reinstallRef(meta)
+	if ref != commit {
+		t.Fatalf("reinstallRef() = %q, want the pinned commit %q", ref, commit)
+	}
+	if ref := reinstallRef(AddonMetadata{Ref: "master"}); ref != "master" {
+		t.Fatalf("reinstallRef() of an unpinned addon = %q, want master", ref)
+	}
+
+	// A fresh clone at that ref stays on the pinned commit despite the new one
+	dest := filepath.Join(t.TempDir(), "Fixture")
+	if err := CloneRepo(context.Background(), src, dest, ref, ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	if head, err := GetHeadCommit(dest); err != nil || head != commit {
+		t.Fatalf("clone at %s is on %s (%v)", ref, head, err)
+	}
+}
+
+func TestReinstallPinnedAddon(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+	gitURL := serveFixtureRepo(t, src, "Fixture")
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if _, err := m.Install(context.Background(), gitURL, nil); err != nil {
+		t.Fatalf("Install() returned error: %v", err)
+	}
+
+	commit, err := m.Pin("Fixture")
+	if err != nil {
+		t.Fatalf("Pin() returned error: %v", err)
+	}
+	if len(commit) != 40 {
+		t.Fatalf("Pin() = %q, want the full commit hash", commit)
+	}
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	if _, err := m.Reinstall(context.Background(), "Fixture", false, nil); err != nil {
+		t.Fatalf("Reinstall() returned error: %v", err)
+	}
+
+	if head, err := GetHeadCommit(m.RepoPath("Fixture")); err != nil || head != commit {
+		t.Fatalf("reinstalled addon is on %s (%v), want the pinned %s", head, err, commit)
+	}
+	if meta, _ := m.store.Get("Fixture"); meta.PinnedCommit != commit {
+		t.Fatalf("Reinstall() dropped the pin: %+v", meta)
+	}
+}
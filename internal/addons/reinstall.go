@@ -0,0 +1,270 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+var ErrNoSourceURL = errors.New("no git URL to reinstall from")
+
+// ReinstallResult contains information about a completed reinstall
+type ReinstallResult struct {
+	Name                   string
+	GitURL                 string // URL the addon was re-cloned from
+	SavedVariablesBackup   string // SavedVariables backup taken first (empty if the addon had none)
+	SavedVariablesRestored bool   // Backup copied back into WTF after reinstalling
+
+	Install *InstallResult
+}
+
+// reinstallSource returns the URL an addon was installed from: the stored one,
+// falling back to the origin remote of its repository
+func (m *Manager) reinstallSource(name string, meta AddonMetadata) (string, error) {
+	if meta.GitURL != "" {
+		return meta.GitURL, nil
+	}
+	if url, err := GetRepoRemoteURL(m.RepoPath(name)); err == nil {
+		return url, nil
+	}
+	if meta.LocalSource != "" {
+		return "", fmt.Errorf("%w: %s was installed from local source %s", ErrNoSourceURL, name, meta.LocalSource)
+	}
+	return "", fmt.Errorf("%w: %s has no stored git URL and no origin remote", ErrNoSourceURL, name)
+}
+
+// Reinstall replaces an addon with a fresh clone of its repository, discarding
+// local changes and corrupted files
+// SavedVariables are always backed up first, restoreSV copies them back afterwards
+// The old folder is put back if the new clone fails
+func (m *Manager) Reinstall(ctx context.Context, name string, restoreSV bool, progressWriter io.Writer) (*ReinstallResult, error) {
+	meta, tracked := m.store.Get(name)
+	if !tracked && !m.addonExists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+	if m.IsDisabled(name) {
+		return nil, fmt.Errorf("%w: %s (enable it before reinstalling)", ErrAddonDisabled, name)
+	}
+
+	gitURL, err := m.reinstallSource(name, meta)
+	if err != nil {
+		return nil, err
+	}
+	if offline.Enabled() {
+		return nil, offline.ErrOffline
+	}
+
+	svBackup, err := m.backup.BackupSavedVariables(m.gameDir, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up SavedVariables: %w", err)
+	}
+	if svBackup != "" {
+		m.log.Info("SavedVariables backed up", "name", name, "path", svBackup)
+	}
+
+	result := &ReinstallResult{
+		Name:                 name,
+		GitURL:               gitURL,
+		SavedVariablesBackup: svBackup,
+	}
+
+	if meta.Pack != "" {
+		result.Install, err = m.reinstallPack(ctx, name, meta, progressWriter)
+	} else {
+		result.Install, err = m.reinstallAddon(ctx, name, gitURL, reinstallRef(meta), progressWriter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if restoreSV && svBackup != "" {
+		if err := m.backup.RestoreSavedVariables(m.gameDir, svBackup); err != nil {
+			return nil, fmt.Errorf("addon reinstalled but restoring SavedVariables failed (backup kept in %s): %w", svBackup, err)
+		}
+		result.SavedVariablesRestored = true
+	}
+
+	m.pruneSV(name)
+	m.log.Info("Addon reinstalled", "name", name, "url", gitURL)
+	return result, nil
+}
+
+// reinstallRef returns the ref to clone an addon back at: its pinned commit
+// when pinned, so reinstalling doesn't move it to the latest one
+func reinstallRef(meta AddonMetadata) string {
+	if meta.PinnedCommit != "" {
+		return meta.PinnedCommit
+	}
+	return meta.Ref
+}
+
+// reinstallAddon moves the addon folder aside and installs it again from gitURL
+func (m *Manager) reinstallAddon(ctx context.Context, name, gitURL, ref string, progressWriter io.Writer) (*InstallResult, error) {
+	addonPath := filepath.Join(m.addonsDir, name)
+	aside := filepath.Join(m.addonsDir, ".turtlectl-reinstall-"+name)
+
+	moved := false
+	if _, err := os.Stat(addonPath); err == nil {
+		_ = os.RemoveAll(aside)
+		if err := os.Rename(addonPath, aside); err != nil {
+			return nil, fmt.Errorf("failed to move %s aside: %w", name, err)
+		}
+		moved = true
+	}
+
+	meta, tracked := m.store.Get(name)
+	m.store.Delete(name)
+
+	source := gitURL
+	if ref != "" {
+		source += "@" + ref
+	}
+	result, err := m.Install(ctx, source, progressWriter)
+	if err != nil {
+		if moved {
+			if renameErr := os.Rename(aside, addonPath); renameErr != nil {
+				m.log.Warn("Failed to put the old addon folder back", "name", name, "path", aside, "error", renameErr)
+			}
+		}
+		if tracked {
+			m.store.Set(name, meta)
+		}
+		return nil, err
+	}
+
+	if err := os.RemoveAll(aside); err != nil {
+		m.log.Warn("Failed to remove the old addon folder", "path", aside, "error", err)
+	}
+
+	// Keep the original install date, ref and pin
+	if tracked && result.Name == name {
+		newMeta, _ := m.store.Get(name)
+		newMeta.InstalledAt = meta.InstalledAt
+		newMeta.Ref = meta.Ref
+		newMeta.PinnedCommit = meta.PinnedCommit
+		m.store.Set(name, newMeta)
+		_ = m.store.Save()
+	}
+	return result, nil
+}
+
+// reinstallPack clones a pack member's repository again and re-syncs every
+// addon installed from it, so members stay on the same commit
+func (m *Manager) reinstallPack(ctx context.Context, name string, meta AddonMetadata, progressWriter io.Writer) (*InstallResult, error) {
+	lock := m.packLock(meta.Pack)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.MkdirAll(m.packsDir, 0755); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAddonsDir, err)
+	}
+
+	// Clone next to the old copy so a failed clone leaves it untouched
+	packPath := filepath.Join(m.packsDir, meta.Pack)
+	staging := filepath.Join(m.packsDir, ".turtlectl-reinstall-"+meta.Pack)
+	_ = os.RemoveAll(staging)
+	if err := CloneRepo(ctx, meta.GitURL, staging, meta.Ref, ShallowDepth, progressWriter); err != nil {
+		_ = os.RemoveAll(staging)
+		return nil, err
+	}
+	if err := os.RemoveAll(packPath); err != nil {
+		_ = os.RemoveAll(staging)
+		return nil, fmt.Errorf("failed to remove the old clone: %w", err)
+	}
+	if err := os.Rename(staging, packPath); err != nil {
+		return nil, fmt.Errorf("failed to move clone into place: %w", err)
+	}
+
+	members := m.packMembers(meta.Pack)
+	for _, member := range members {
+		if member != name {
+			m.backupSV(member)
+		}
+	}
+	if err := m.syncPack(meta.Pack, members); err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member != name {
+			m.pruneSV(member)
+		}
+	}
+
+	now := time.Now()
+	for _, member := range members {
+		memberMeta, _ := m.store.Get(member)
+		memberMeta.UpdatedAt = now
+		m.store.Set(member, memberMeta)
+	}
+	_ = m.store.Save()
+
+	result := &InstallResult{
+		Name:    name,
+		Path:    filepath.Join(m.addonsDir, name),
+		Ref:     meta.Ref,
+		Folders: members,
+	}
+	info, _ := ParseTOC(filepath.Join(result.Path, name+".toc"))
+	m.applyTOCInfo(result, info)
+	return result, nil
+}
+
+// RepairFix describes the remediation of one repository flagged by Repair
+type RepairFix struct {
+	Repo    string   // Entry from RepairResult.CorruptedRepos
+	Addon   string   // Addon that was reinstalled
+	Backups []string // Folder backups taken before reinstalling
+	Err     error
+}
+
+// FixCorruptedRepos backs up and reinstalls every repository Repair flagged as
+// corrupted, one at a time so a failure doesn't stop the others
+// A corrupted pack clone is fixed by reinstalling one of its addons
+func (m *Manager) FixCorruptedRepos(ctx context.Context, result *RepairResult) []RepairFix {
+	fixes := make([]RepairFix, 0, len(result.CorruptedRepos))
+	for _, repo := range result.CorruptedRepos {
+		if ctx.Err() != nil {
+			break
+		}
+
+		fix := RepairFix{Repo: repo, Addon: repo}
+		folders := []string{repo}
+		if pack, ok := strings.CutPrefix(repo, packsDirName+"/"); ok {
+			folders = m.packMembers(pack)
+			if len(folders) == 0 {
+				fix.Err = fmt.Errorf("%w: no addon installed from %s", ErrAddonNotFound, repo)
+				fixes = append(fixes, fix)
+				continue
+			}
+			fix.Addon = folders[0]
+		}
+
+		for _, folder := range folders {
+			path := filepath.Join(m.addonsDir, folder)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			backupPath, err := m.backup.CreateBackup(path, folder)
+			if err != nil {
+				fix.Err = fmt.Errorf("failed to back up %s: %w", folder, err)
+				break
+			}
+			fix.Backups = append(fix.Backups, backupPath)
+		}
+		if fix.Err == nil {
+			_, fix.Err = m.Reinstall(ctx, fix.Addon, false, nil)
+		}
+		if fix.Err != nil {
+			m.log.Warn("Failed to fix corrupted repository", "repo", repo, "error", fix.Err)
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
@@ -0,0 +1,94 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestReinstallWithoutSourceURL(t *testing.T) {
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	writePackFile(t, m.GetAddonsDir(), "Manual/Manual.toc", "## Title: Manual\n")
+
+	_, err := m.Reinstall(context.Background(), "Manual", false, nil)
+	if !errors.Is(err, ErrNoSourceURL) {
+		t.Fatalf("expected ErrNoSourceURL, got %v", err)
+	}
+	if !m.addonExists("Manual") {
+		t.Fatal("addon removed although it couldn't be reinstalled")
+	}
+
+	if _, err := m.Reinstall(context.Background(), "Missing", false, nil); !errors.Is(err, ErrAddonNotFound) {
+		t.Fatalf("expected ErrAddonNotFound, got %v", err)
+	}
+}
+
+func TestReinstallPackMemberRestoresSavedVariables(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	m.SetBackupSavedVariables(false)
+	installPackFixture(t, m, src)
+
+	// Corrupt the install and lose the addon's settings
+	corePath := filepath.Join(m.GetAddonsDir(), "PackB", "core.lua")
+	if err := os.Remove(corePath); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+	svPath := filepath.Join(m.GetGameDir(), "WTF", "Account", "ME", "SavedVariables", "PackB.lua")
+	writePackFile(t, filepath.Dir(svPath), "PackB.lua", "PackBDB = { scale = 2 }")
+
+	result, err := m.Reinstall(context.Background(), "PackB", true, nil)
+	if err != nil {
+		t.Fatalf("Reinstall() returned error: %v", err)
+	}
+	if result.SavedVariablesBackup == "" || !result.SavedVariablesRestored {
+		t.Fatalf("SavedVariables not backed up and restored: %+v", result)
+	}
+
+	if data, err := os.ReadFile(corePath); err != nil || string(data) != "-- v1" {
+		t.Fatalf("PackB not reinstalled: %q, %v", data, err)
+	}
+	if data, err := os.ReadFile(svPath); err != nil || string(data) != "PackBDB = { scale = 2 }" {
+		t.Fatalf("SavedVariables not restored: %q, %v", data, err)
+	}
+	if meta, ok := m.store.Get("PackB"); !ok || meta.Pack != "ui-pack" {
+		t.Fatalf("unexpected metadata after reinstall: %+v", meta)
+	}
+}
+
+func TestFixCorruptedPackRepo(t *testing.T) {
+	src, _ := newPackFixture(t)
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	m.SetBackupSavedVariables(false)
+	installPackFixture(t, m, src)
+
+	// A HEAD pointing nowhere opens fine but fails the integrity check
+	headPath := filepath.Join(m.RepoPath("PackA"), ".git", "HEAD")
+	if err := os.WriteFile(headPath, []byte("ref: refs/heads/missing\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() returned error: %v", err)
+	}
+
+	result, err := m.Repair()
+	if err != nil {
+		t.Fatalf("Repair() returned error: %v", err)
+	}
+	if len(result.CorruptedRepos) != 1 {
+		t.Fatalf("expected one corrupted repo, got %v", result.CorruptedRepos)
+	}
+
+	fixes := m.FixCorruptedRepos(context.Background(), result)
+	if len(fixes) != 1 || fixes[0].Err != nil || fixes[0].Addon != "PackA" {
+		t.Fatalf("unexpected fixes: %+v", fixes)
+	}
+	if len(fixes[0].Backups) != 2 {
+		t.Fatalf("expected a backup per pack addon, got %v", fixes[0].Backups)
+	}
+	if err := VerifyRepoIntegrity(m.RepoPath("PackA")); err != nil {
+		t.Fatalf("repository still corrupted: %v", err)
+	}
+}
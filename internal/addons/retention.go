@@ -0,0 +1,216 @@
+package addons
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	// ErrBackupNotFound is returned when deleting a backup that doesn't exist
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrInvalidBackup is returned for an addon name or timestamp that can't
+	// name a backup, such as ".." or a path
+	ErrInvalidBackup = errors.New("invalid backup")
+)
+
+// checkBackupName rejects addon names that would resolve outside their own
+// folder of the backup directory
+func checkBackupName(addonName string) error {
+	if addonName == "" || addonName == "." || addonName == ".." || filepath.Base(addonName) != addonName {
+		return fmt.Errorf("%w: addon name %q", ErrInvalidBackup, addonName)
+	}
+	return nil
+}
+
+// checkBackupStamp rejects timestamps not in BackupTimestampFormat
+func checkBackupStamp(stamp string) error {
+	if _, err := time.Parse(BackupTimestampFormat, stamp); err != nil {
+		return fmt.Errorf("%w: timestamp %q (expected YYYYMMDD-HHMMSS)", ErrInvalidBackup, stamp)
+	}
+	return nil
+}
+
+// AddonBackups summarizes the backups kept for one addon
+type AddonBackups struct {
+	Name           string
+	Backups        []string // Addon folder backups, newest first
+	SavedVariables []string // SavedVariables backups, newest first
+	Size           int64    // Disk usage of every backup of the addon
+}
+
+// Count returns the number of backups, SavedVariables included
+func (b AddonBackups) Count() int {
+	return len(b.Backups) + len(b.SavedVariables)
+}
+
+// ListAll summarizes the backups of every addon, sorted by name
+func (bm *BackupManager) ListAll() ([]AddonBackups, error) {
+	entries, err := os.ReadDir(bm.backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var all []AddonBackups
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		backups, err := bm.summarize(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if backups.Count() > 0 {
+			all = append(all, backups)
+		}
+	}
+	return all, nil
+}
+
+// summarize lists the backups of one addon and measures them
+func (bm *BackupManager) summarize(addonName string) (AddonBackups, error) {
+	backups := AddonBackups{Name: addonName}
+
+	var err error
+	if backups.Backups, err = bm.ListBackups(addonName); err != nil {
+		return backups, err
+	}
+	if backups.SavedVariables, err = bm.ListSavedVariablesBackups(addonName); err != nil {
+		return backups, err
+	}
+
+	size, gitSize, err := DirSize(filepath.Join(bm.backupDir, addonName))
+	if err != nil {
+		return backups, err
+	}
+	backups.Size = size + gitSize
+	return backups, nil
+}
+
+// PruneResult reports what Prune removed
+type PruneResult struct {
+	Removed   int   // Backups deleted, SavedVariables included
+	Reclaimed int64 // Bytes freed
+}
+
+// Prune deletes backups beyond the newest keep of each addon, and backups
+// taken before the given time when it isn't zero
+// Folder and SavedVariables backups are pruned separately, like on backup
+func (bm *BackupManager) Prune(keep int, before time.Time) (*PruneResult, error) {
+	all, err := bm.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{}
+	for _, addon := range all {
+		addonDir := filepath.Join(bm.backupDir, addon.Name)
+		for _, set := range []struct {
+			dir    string
+			stamps []string
+		}{
+			{addonDir, addon.Backups},
+			{bm.savedVariablesDir(addon.Name), addon.SavedVariables},
+		} {
+			for i, stamp := range set.stamps {
+				if !expired(i, stamp, keep, before) {
+					continue
+				}
+				size := backupSize(set.dir, stamp)
+				if err := removeBackup(set.dir, stamp); err != nil {
+					return result, fmt.Errorf("failed to delete backup %s: %w", stamp, err)
+				}
+				result.Removed++
+				result.Reclaimed += size
+			}
+		}
+		removeEmptyDirs(bm.savedVariablesDir(addon.Name), addonDir)
+	}
+	return result, nil
+}
+
+// expired reports whether the i-th newest backup, taken at stamp, falls out
+// of the retention of Prune
+func expired(i int, stamp string, keep int, before time.Time) bool {
+	if keep > 0 && i >= keep {
+		return true
+	}
+	if before.IsZero() {
+		return false
+	}
+	taken, err := time.ParseInLocation(BackupTimestampFormat, stamp, time.Local)
+	return err == nil && taken.Before(before)
+}
+
+// DeleteBackups deletes the backups of an addon taken at timestamp, both the
+// folder and SavedVariables ones, or every backup of it when timestamp is empty
+// Returns the bytes freed
+func (bm *BackupManager) DeleteBackups(addonName, timestamp string) (int64, error) {
+	if err := checkBackupName(addonName); err != nil {
+		return 0, err
+	}
+	if timestamp != "" {
+		if err := checkBackupStamp(timestamp); err != nil {
+			return 0, err
+		}
+	}
+
+	addonDir := filepath.Join(bm.backupDir, addonName)
+
+	if timestamp == "" {
+		if _, err := os.Stat(addonDir); err != nil {
+			return 0, fmt.Errorf("%w: %s", ErrBackupNotFound, addonName)
+		}
+		size, gitSize, _ := DirSize(addonDir)
+		if err := os.RemoveAll(addonDir); err != nil {
+			return 0, fmt.Errorf("failed to delete backups of %s: %w", addonName, err)
+		}
+		return size + gitSize, nil
+	}
+
+	var reclaimed int64
+	var found bool
+	for _, dir := range []string{addonDir, bm.savedVariablesDir(addonName)} {
+		if _, err := findBackup(dir, timestamp); err != nil {
+			continue
+		}
+		found = true
+		reclaimed += backupSize(dir, timestamp)
+		if err := removeBackup(dir, timestamp); err != nil {
+			return reclaimed, fmt.Errorf("failed to delete backup %s: %w", timestamp, err)
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: %s %s", ErrBackupNotFound, addonName, timestamp)
+	}
+
+	removeEmptyDirs(bm.savedVariablesDir(addonName), addonDir)
+	return reclaimed, nil
+}
+
+// backupSize returns the disk usage of the backup taken at stamp in dir
+func backupSize(dir, stamp string) int64 {
+	if checkBackupStamp(stamp) != nil {
+		return 0
+	}
+	var total int64
+	for _, path := range backupPaths(dir, stamp) {
+		size, gitSize, _ := DirSize(path)
+		total += size + gitSize
+	}
+	return total
+}
+
+// removeEmptyDirs removes each of dirs if it's empty, in order
+func removeEmptyDirs(dirs ...string) {
+	for _, dir := range dirs {
+		if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+			_ = os.Remove(dir)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package addons
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// newBackupFixture creates folder and SavedVariables backups of addon taken at stamps
+func newBackupFixture(t *testing.T, bm *BackupManager, addon string, stamps ...string) {
+	t.Helper()
+	for _, stamp := range stamps {
+		writePackFile(t, filepath.Join(bm.backupDir, addon, stamp), addon+".toc", "## Title: "+addon+"\n")
+		writePackFile(t, filepath.Join(bm.savedVariablesDir(addon), stamp), "Account/SavedVariables/"+addon+".lua", "x = 1\n")
+	}
+}
+
+func TestBackupPrune(t *testing.T) {
+	bm := NewBackupManager(t.TempDir())
+	newBackupFixture(t, bm, "pfQuest", "20240101-120000", "20240201-120000", "20240301-120000")
+	newBackupFixture(t, bm, "pfUI", "20230101-120000")
+
+	all, err := bm.ListAll()
+	if err != nil {
+		t.Fatalf("ListAll() returned error: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "pfQuest" || all[0].Count() != 6 || all[0].Size == 0 {
+		t.Fatalf("unexpected backups: %+v", all)
+	}
+
+	// Keep the newest 2, and drop anything before 2023-06
+	before := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	result, err := bm.Prune(2, before)
+	if err != nil {
+		t.Fatalf("Prune() returned error: %v", err)
+	}
+	if result.Removed != 4 || result.Reclaimed == 0 {
+		t.Fatalf("unexpected prune result: %+v", result)
+	}
+
+	backups, _ := bm.ListBackups("pfQuest")
+	svBackups, _ := bm.ListSavedVariablesBackups("pfQuest")
+	want := []string{"20240301-120000", "20240201-120000"}
+	if !slices.Equal(backups, want) || !slices.Equal(svBackups, want) {
+		t.Fatalf("unexpected backups left: %v %v", backups, svBackups)
+	}
+	if all, _ := bm.ListAll(); len(all) != 1 {
+		t.Fatalf("pfUI backups should be gone: %+v", all)
+	}
+}
+
+func TestDeleteBackups(t *testing.T) {
+	bm := NewBackupManager(t.TempDir())
+	newBackupFixture(t, bm, "pfQuest", "20240101-120000", "20240201-120000")
+
+	if _, err := bm.DeleteBackups("pfQuest", "20240101-120000"); err != nil {
+		t.Fatalf("DeleteBackups() returned error: %v", err)
+	}
+	if backups, _ := bm.ListSavedVariablesBackups("pfQuest"); !slices.Equal(backups, []string{"20240201-120000"}) {
+		t.Fatalf("SavedVariables backup should go with the folder backup: %v", backups)
+	}
+
+	if _, err := bm.DeleteBackups("pfQuest", "20990101-120000"); !errors.Is(err, ErrBackupNotFound) {
+		t.Fatalf("expected ErrBackupNotFound, got %v", err)
+	}
+
+	if _, err := bm.DeleteBackups("pfQuest", ""); err != nil {
+		t.Fatalf("DeleteBackups() returned error: %v", err)
+	}
+	if all, _ := bm.ListAll(); len(all) != 0 {
+		t.Fatalf("expected no backups left: %+v", all)
+	}
+}
+
+func TestDeleteBackupsRejectsPaths(t *testing.T) {
+	dataDir := t.TempDir()
+	bm := NewBackupManager(dataDir)
+	newBackupFixture(t, bm, "pfQuest", "20240101-120000")
+	writePackFile(t, dataDir, "addons.json", "{}")
+
+	tests := []struct{ name, timestamp string }{
+		{"..", ""},
+		{"../x", ""},
+		{"a/b", ""},
+		{".", ""},
+		{"", ""},
+		{"pfQuest", "../.."},
+		{"pfQuest", "savedvariables"},
+	}
+	for _, tt := range tests {
+		if _, err := bm.DeleteBackups(tt.name, tt.timestamp); !errors.Is(err, ErrInvalidBackup) {
+			t.Errorf("DeleteBackups(%q, %q) = %v, want ErrInvalidBackup", tt.name, tt.timestamp, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dataDir, "addons.json")); err != nil {
+		t.Fatalf("data directory was touched: %v", err)
+	}
+	if backups, _ := bm.ListBackups("pfQuest"); len(backups) != 1 {
+		t.Fatalf("pfQuest backups were touched: %v", backups)
+	}
+}
+
+func TestBackupHelpersRejectPaths(t *testing.T) {
+	dataDir := t.TempDir()
+	bm := NewBackupManager(dataDir)
+	newBackupFixture(t, bm, "pfQuest", "20240101-120000")
+	addonDir := filepath.Join(bm.backupDir, "pfQuest")
+
+	for _, stamp := range []string{"..", "../..", "", "savedvariables"} {
+		if _, err := findBackup(addonDir, stamp); !errors.Is(err, ErrInvalidBackup) {
+			t.Errorf("findBackup(%q) = %v, want ErrInvalidBackup", stamp, err)
+		}
+		if err := removeBackup(addonDir, stamp); !errors.Is(err, ErrInvalidBackup) {
+			t.Errorf("removeBackup(%q) = %v, want ErrInvalidBackup", stamp, err)
+		}
+	}
+	if err := bm.RestoreBackup("..", "20240101-120000", filepath.Join(dataDir, "out")); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("RestoreBackup(..) = %v, want ErrInvalidBackup", err)
+	}
+	if err := bm.DeleteAllBackups("../x"); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("DeleteAllBackups(../x) = %v, want ErrInvalidBackup", err)
+	}
+
+	if backups, _ := bm.ListSavedVariablesBackups("pfQuest"); len(backups) != 1 {
+		t.Fatalf("pfQuest backups were touched: %v", backups)
+	}
+}
+
+func TestBackupArchiveLeavesOutGit(t *testing.T) {
+	src := t.TempDir()
+	repo, err := git.PlainInit(src, false)
+	if err != nil {
+		t.Fatalf("PlainInit() returned error: %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://example.com/pfQuest.git"}}); err != nil {
+		t.Fatalf("CreateRemote() returned error: %v", err)
+	}
+	writePackFile(t, src, "pfQuest.toc", "## Title: pfQuest\n")
+	writePackFile(t, src, "db/units.lua", "units = {}\n")
+
+	bm := NewBackupManager(t.TempDir())
+	backupPath, err := bm.CreateBackup(src, "pfQuest")
+	if err != nil {
+		t.Fatalf("CreateBackup() returned error: %v", err)
+	}
+	if !strings.HasSuffix(backupPath, backupArchiveExt) {
+		t.Fatalf("expected an archive, got %s", backupPath)
+	}
+	backups, _ := bm.ListBackups("pfQuest")
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v", backups)
+	}
+
+	dest := filepath.Join(t.TempDir(), "pfQuest")
+	if err := bm.RestoreBackup("pfQuest", backups[0], dest); err != nil {
+		t.Fatalf("RestoreBackup() returned error: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "db", "units.lua")); err != nil || string(data) != "units = {}\n" {
+		t.Fatalf("unexpected restored file: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, ".git")); !os.IsNotExist(err) {
+		t.Fatalf(".git should be left out of the backup: %v", err)
+	}
+	if url := takeBackupSource(dest); url != "https://example.com/pfQuest.git" {
+		t.Fatalf("unexpected recorded source: %q", url)
+	}
+	if _, err := os.Stat(filepath.Join(dest, backupSourceFile)); !os.IsNotExist(err) {
+		t.Fatalf("source record should be removed once read: %v", err)
+	}
+}
+
+func TestRestoreLegacyFolderBackup(t *testing.T) {
+	bm := NewBackupManager(t.TempDir())
+	newBackupFixture(t, bm, "pfUI", "20240101-120000")
+
+	dest := filepath.Join(t.TempDir(), "pfUI")
+	if err := bm.RestoreBackup("pfUI", "20240101-120000", dest); err != nil {
+		t.Fatalf("RestoreBackup() returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "pfUI.toc")); err != nil {
+		t.Fatalf("expected the folder backup to be restored: %v", err)
+	}
+}
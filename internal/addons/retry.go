@@ -0,0 +1,127 @@
+package addons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+// DefaultRetries is how many times a failed clone or fetch is retried
+const DefaultRetries = 2
+
+// retryBaseDelay is the wait before the first retry, doubled on each attempt
+const retryBaseDelay = 2 * time.Second
+
+// DefaultTimeout bounds each clone or fetch attempt so a stalled remote can't hang forever
+const DefaultTimeout = 5 * time.Minute
+
+var (
+	retries = DefaultRetries
+	timeout = DefaultTimeout
+)
+
+// SetRetries sets how many times transient clone/fetch failures are retried
+func SetRetries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	retries = n
+}
+
+// SetTimeout sets the deadline of each clone/fetch attempt, 0 disables it
+func SetTimeout(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	timeout = d
+}
+
+// withRetry runs a network operation with the configured retry policy, each
+// attempt getting its own deadline derived from ctx
+// In offline mode the operation is never attempted
+func withRetry(ctx context.Context, progressWriter io.Writer, op func(ctx context.Context) error) error {
+	if offline.Enabled() {
+		return offline.ErrOffline
+	}
+	return retry(ctx, retries+1, retryBaseDelay, time.Sleep, progressWriter, func() error {
+		if timeout == 0 {
+			return op(ctx)
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return op(attemptCtx)
+	})
+}
+
+// retry runs op up to attempts times, sleeping base, 2*base, 4*base... between
+// attempts. Only transient network errors are retried, and never once ctx is done
+func retry(ctx context.Context, attempts int, base time.Duration, sleep func(time.Duration), progressWriter io.Writer, op func() error) error {
+	var err error
+	delay := base
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if progressWriter != nil {
+				_, _ = fmt.Fprintf(progressWriter, "retrying (%d/%d)\n", attempt, attempts)
+			}
+			sleep(delay)
+			delay *= 2
+		}
+
+		err = op()
+		if err == nil || !isTransientError(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// isTransientError reports whether err looks like a network hiccup worth retrying
+// Authentication, missing repositories, and cancellations are never retried
+func isTransientError(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, git.NoErrAlreadyUpToDate),
+		errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrRepositoryNotFound),
+		errors.Is(err, transport.ErrEmptyRemoteRepository),
+		errors.Is(err, transport.ErrInvalidAuthMethod),
+		errors.Is(err, context.Canceled):
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	// go-git often flattens transport errors into plain strings
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"connection reset", "unexpected eof", "timeout", "tls handshake", "broken pipe", "temporary failure"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package addons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+func TestRetryBacksOffOnTransientErrors(t *testing.T) {
+	var delays []time.Duration
+	sleep := func(d time.Duration) { delays = append(delays, d) }
+
+	var out bytes.Buffer
+	calls := 0
+	err := retry(context.Background(), 3, time.Second, sleep, &out, func() error {
+		calls++
+		if calls < 3 {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("retry() returned error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+	if len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Fatalf("unexpected backoff delays: %v", delays)
+	}
+	if !strings.Contains(out.String(), "retrying (2/3)") || !strings.Contains(out.String(), "retrying (3/3)") {
+		t.Fatalf("expected retry progress output, got %q", out.String())
+	}
+}
+
+func TestRetryGivesUpAfterAttempts(t *testing.T) {
+	calls := 0
+	err := retry(context.Background(), 3, time.Second, func(time.Duration) {}, nil, func() error {
+		calls++
+		return errors.New("read tcp: connection reset by peer")
+	})
+	if err == nil {
+		t.Fatal("expected error after exhausting attempts")
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	for _, permanent := range []error{
+		transport.ErrAuthenticationRequired,
+		transport.ErrRepositoryNotFound,
+		errors.New("some other failure"),
+	} {
+		calls := 0
+		err := retry(context.Background(), 3, time.Second, func(time.Duration) { t.Fatal("unexpected sleep") }, nil, func() error {
+			calls++
+			return permanent
+		})
+		if !errors.Is(err, permanent) {
+			t.Fatalf("expected %v, got %v", permanent, err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected a single call for %v, got %d", permanent, calls)
+		}
+	}
+}
+
+func TestRetryStopsOnceContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry(ctx, 3, time.Second, func(time.Duration) { t.Fatal("unexpected sleep") }, nil, func() error {
+		calls++
+		cancel()
+		return context.DeadlineExceeded
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls != 1 {
+		t.Fatalf("expected a single canceled attempt, got %d calls and %v", calls, err)
+	}
+}
+
+func TestWithRetryAppliesTimeout(t *testing.T) {
+	defer SetTimeout(DefaultTimeout)
+
+	SetTimeout(time.Minute)
+	err := withRetry(context.Background(), nil, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatal("expected a deadline on the attempt context")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry() returned error: %v", err)
+	}
+
+	SetTimeout(0)
+	_ = withRetry(context.Background(), nil, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			t.Fatal("expected no deadline with the timeout disabled")
+		}
+		return nil
+	})
+}
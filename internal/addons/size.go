@@ -0,0 +1,61 @@
+package addons
+
+import (
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// sizeWorkers is the number of addon folders measured in parallel
+const sizeWorkers = 8
+
+// DirSize sums the sizes of the files under dir
+// Files inside .git directories are counted in gitSize instead of size, since
+// the history of a full clone can dwarf the addon itself
+func DirSize(dir string) (size, gitSize int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if slices.Contains(strings.Split(rel, string(filepath.Separator)), ".git") {
+			gitSize += info.Size()
+		} else {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, gitSize, err
+}
+
+// LoadSizes fills Size and GitSize of each addon, walking their folders in parallel
+// Addons that can't be measured are left at zero
+func (m *Manager) LoadSizes(addons []*Addon) {
+	jobs := make(chan *Addon)
+	var wg sync.WaitGroup
+	for i := 0; i < min(sizeWorkers, len(addons)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addon := range jobs {
+				size, gitSize, err := DirSize(addon.Path)
+				if err != nil {
+					m.log.Debug("Failed to measure addon folder", "name", addon.Name, "error", err)
+					continue
+				}
+				addon.Size, addon.GitSize = size, gitSize
+			}
+		}()
+	}
+	for _, addon := range addons {
+		jobs <- addon
+	}
+	close(jobs)
+	wg.Wait()
+}
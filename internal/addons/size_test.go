@@ -0,0 +1,18 @@
+package addons
+
+import "testing"
+
+func TestDirSizeCountsGitSeparately(t *testing.T) {
+	dir := t.TempDir()
+	writePackFile(t, dir, "Addon.toc", "12345")
+	writePackFile(t, dir, "media/icon.tga", "1234567890")
+	writePackFile(t, dir, ".git/objects/pack/pack-1.pack", "123")
+
+	size, gitSize, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() returned error: %v", err)
+	}
+	if size != 15 || gitSize != 3 {
+		t.Fatalf("DirSize() = %d, %d, want 15, 3", size, gitSize)
+	}
+}
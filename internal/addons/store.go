@@ -7,28 +7,41 @@ import (
 	"sync"
 )
 
+// storeFileName is the name of the addon metadata store
+const storeFileName = "addons.json"
+
 // StoreManager handles persistence of addon metadata
 type StoreManager struct {
-	path  string
-	store *Store
-	mu    sync.RWMutex
+	path       string
+	legacyPath string // Former location, moved to path on Load, see SetStateDir
+	store      *Store
+	mu         sync.RWMutex
 }
 
 // NewStoreManager creates a new store manager
 func NewStoreManager(dataDir string) *StoreManager {
 	return &StoreManager{
-		path: filepath.Join(dataDir, "addons.json"),
+		path: filepath.Join(dataDir, storeFileName),
 		store: &Store{
 			Addons: make(map[string]AddonMetadata),
 		},
 	}
 }
 
+// Path returns the path of the store file
+func (sm *StoreManager) Path() string {
+	return sm.path
+}
+
 // Load reads the store from disk
 func (sm *StoreManager) Load() error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
+	if err := migrateStore(sm.legacyPath, sm.path); err != nil {
+		return err
+	}
+
 	data, err := os.ReadFile(sm.path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -54,6 +67,32 @@ func (sm *StoreManager) Load() error {
 	return nil
 }
 
+// migrateStore moves the store at oldPath to newPath, unless one already
+// exists there. Copies when both aren't on the same filesystem
+func migrateStore(oldPath, newPath string) error {
+	if oldPath == "" || oldPath == newPath {
+		return nil
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return nil
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(oldPath, newPath); err == nil {
+		return nil
+	}
+	if err := copyFile(oldPath, newPath); err != nil {
+		_ = os.Remove(newPath)
+		return err
+	}
+	return os.Remove(oldPath)
+}
+
 // Save writes the store to disk
 func (sm *StoreManager) Save() error {
 	sm.mu.Lock()
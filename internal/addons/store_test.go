@@ -0,0 +1,46 @@
+package addons
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestSetStateDirMovesStore(t *testing.T) {
+	dataDir := t.TempDir()
+	stateDir := filepath.Join(t.TempDir(), "turtle-wow")
+
+	legacy := NewManager(t.TempDir(), dataDir, log.New(io.Discard))
+	legacy.store.Set("pfQuest", AddonMetadata{GitURL: "https://example.com/pfQuest.git"})
+	if err := legacy.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	m := NewManager(t.TempDir(), dataDir, log.New(io.Discard))
+	m.SetStateDir(stateDir)
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if meta, ok := m.store.Get("pfQuest"); !ok || meta.GitURL != "https://example.com/pfQuest.git" {
+		t.Fatalf("store not carried over: %+v", meta)
+	}
+	if m.GetStorePath() != filepath.Join(stateDir, storeFileName) {
+		t.Fatalf("unexpected store path: %s", m.GetStorePath())
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, storeFileName)); !os.IsNotExist(err) {
+		t.Fatalf("legacy store should be moved: %v", err)
+	}
+
+	// A store already in the state dir wins over a stale legacy one
+	writePackFile(t, dataDir, storeFileName, `{"addons":{}}`)
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if _, ok := m.store.Get("pfQuest"); !ok {
+		t.Fatal("legacy store overwrote the migrated one")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 // wowColorCodeRegex matches WoW color escape sequences like |cffRRGGBB and |r
@@ -13,11 +15,42 @@ var wowColorCodeRegex = regexp.MustCompile(`\|c[0-9a-fA-F]{8}|\|r`)
 
 // TOCInfo contains parsed information from a .toc file
 type TOCInfo struct {
-	Title     string
-	Version   string
-	Author    string
-	Notes     string
-	Interface string
+	Title        string
+	Version      string
+	Author       string
+	Notes        string
+	Interface    string
+	Dependencies []string // From ## Dependencies or ## RequiredDeps
+
+	// Fields holds the remaining ## fields, mostly author extensions like
+	// X-Website or X-Category, keyed as written in the file
+	Fields map[string]string
+}
+
+// Field returns the value of an extra ## field, matching the key case-insensitively
+func (t *TOCInfo) Field(key string) string {
+	if v, ok := t.Fields[key]; ok {
+		return v
+	}
+	for k, v := range t.Fields {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// Website returns the addon homepage from ## X-Website (or the X-URL variant)
+func (t *TOCInfo) Website() string {
+	if v := t.Field("X-Website"); v != "" {
+		return v
+	}
+	return t.Field("X-URL")
+}
+
+// Category returns the addon category from ## X-Category
+func (t *TOCInfo) Category() string {
+	return t.Field("X-Category")
 }
 
 // stripWoWColorCodes removes WoW color escape sequences from a string
@@ -25,6 +58,13 @@ func stripWoWColorCodes(s string) string {
 	return wowColorCodeRegex.ReplaceAllString(s, "")
 }
 
+// parseDependencyList splits a dependency value on commas and whitespace
+func parseDependencyList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 // ParseTOC parses a .toc file and extracts metadata
 func ParseTOC(tocPath string) (*TOCInfo, error) {
 	file, err := os.Open(tocPath)
@@ -68,6 +108,16 @@ func ParseTOC(tocPath string) (*TOCInfo, error) {
 			info.Notes = stripWoWColorCodes(value)
 		case "interface":
 			info.Interface = value
+		case "dependencies", "requireddeps":
+			info.Dependencies = append(info.Dependencies, parseDependencyList(value)...)
+		default:
+			if key == "" || value == "" {
+				continue
+			}
+			if info.Fields == nil {
+				info.Fields = make(map[string]string)
+			}
+			info.Fields[key] = stripWoWColorCodes(value)
 		}
 	}
 
@@ -78,10 +128,100 @@ func ParseTOC(tocPath string) (*TOCInfo, error) {
 	return info, nil
 }
 
+// tocFlavorSuffixes are the client flavor suffixes addons append to extra .toc
+// files (e.g. Foo-Vanilla.toc, Foo_TBC.toc), lowercase
+var tocFlavorSuffixes = []string{
+	"vanilla", "classic", "tbc", "bcc", "wrath", "wotlkc", "cata", "mists", "mainline",
+}
+
+// vanillaTOCFlavors are the flavor suffixes targeting the 1.12 client
+var vanillaTOCFlavors = map[string]bool{"vanilla": true, "classic": true}
+
+// tocFlavor splits a .toc filename into the addon name and its lowercase flavor
+// suffix, or "" when the file has none
+func tocFlavor(filename string) (name string, flavor string) {
+	name = strings.TrimSuffix(filename, filepath.Ext(filename))
+	for _, suffix := range tocFlavorSuffixes {
+		if len(name) <= len(suffix)+1 {
+			continue
+		}
+		sep := name[len(name)-len(suffix)-1]
+		if (sep == '-' || sep == '_') && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+			return name[:len(name)-len(suffix)-1], suffix
+		}
+	}
+	return name, ""
+}
+
+// tocFiles returns the names of the .toc files among directory entries
+func tocFiles(entries []os.DirEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".toc") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// pickTOC chooses the .toc file for the Turtle WoW client when an addon ships
+// one per flavor: a -Vanilla/-Classic file first, then one whose Interface
+// matches the client, then the base file without suffix, then the first one
+func pickTOC(dir string, names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+
+	best, bestScore := names[0], -1
+	for _, name := range names {
+		_, flavor := tocFlavor(name)
+		score := 0
+		switch {
+		case vanillaTOCFlavors[flavor]:
+			score = 3
+		case tocInterfaceMatches(filepath.Join(dir, name)):
+			score = 2
+		case flavor == "":
+			score = 1
+		}
+		if score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// tocAddonName returns the addon name for the chosen .toc file. The client only
+// loads <Folder>/<Folder>.toc, so the flavor suffix is dropped only when the
+// base .toc exists next to it, otherwise the file's own name is the folder name
+func tocAddonName(names []string, chosen string) string {
+	base, flavor := tocFlavor(chosen)
+	if flavor != "" {
+		for _, name := range names {
+			if strings.EqualFold(name, base+filepath.Ext(name)) {
+				return base
+			}
+		}
+	}
+	return strings.TrimSuffix(chosen, filepath.Ext(chosen))
+}
+
+// tocInterfaceMatches reports whether a .toc file declares an Interface
+// compatible with the client; files without one don't count as a match
+func tocInterfaceMatches(tocPath string) bool {
+	info, err := ParseTOC(tocPath)
+	if err != nil || strings.TrimSpace(info.Interface) == "" {
+		return false
+	}
+	return IsInterfaceCompatible(info.Interface, ExpectedInterfaceVersion())
+}
+
 // FindTOCFile finds the .toc file in an addon directory
 // Returns the path to the .toc file and the expected addon name
 // It first checks the root directory, then checks immediate subdirectories
 // (for multi-addon repos where the .toc is in a subfolder)
+// When several flavor-specific .toc files exist, the vanilla one is preferred
+// for metadata, see tocAddonName for the returned name
 func FindTOCFile(addonDir string) (tocPath string, addonName string, err error) {
 	entries, err := os.ReadDir(addonDir)
 	if err != nil {
@@ -89,16 +229,9 @@ func FindTOCFile(addonDir string) (tocPath string, addonName string, err error)
 	}
 
 	// First, check the root directory for a .toc file
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		if strings.HasSuffix(strings.ToLower(entry.Name()), ".toc") {
-			tocPath = filepath.Join(addonDir, entry.Name())
-			// Addon name is the .toc filename without extension
-			addonName = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
-			return tocPath, addonName, nil
-		}
+	if names := tocFiles(entries); len(names) > 0 {
+		name := pickTOC(addonDir, names)
+		return filepath.Join(addonDir, name), tocAddonName(names, name), nil
 	}
 
 	// If not found, check immediate subdirectories (for multi-addon repos)
@@ -111,21 +244,46 @@ func FindTOCFile(addonDir string) (tocPath string, addonName string, err error)
 		if err != nil {
 			continue
 		}
-		for _, subEntry := range subEntries {
-			if subEntry.IsDir() {
-				continue
-			}
-			if strings.HasSuffix(strings.ToLower(subEntry.Name()), ".toc") {
-				tocPath = filepath.Join(subDir, subEntry.Name())
-				addonName = strings.TrimSuffix(subEntry.Name(), filepath.Ext(subEntry.Name()))
-				return tocPath, addonName, nil
-			}
+		if names := tocFiles(subEntries); len(names) > 0 {
+			name := pickTOC(subDir, names)
+			return filepath.Join(subDir, name), tocAddonName(names, name), nil
 		}
 	}
 
 	return "", "", os.ErrNotExist
 }
 
+// FindAddonFolders returns the immediate subfolders of a multi-addon repository
+// that are addons of their own (containing <folder>.toc), sorted by name
+// Returns nil when the directory itself has a .toc file
+func FindAddonFolders(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var folders []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() {
+			if strings.HasSuffix(strings.ToLower(name), ".toc") {
+				return nil
+			}
+			continue
+		}
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		// The game only loads folders whose .toc matches the folder name
+		if _, err := os.Stat(filepath.Join(dir, name, name+".toc")); err == nil {
+			folders = append(folders, name)
+		}
+	}
+
+	sort.Strings(folders)
+	return folders
+}
+
 // GetAddonNameFromTOC extracts the expected addon name from a .toc file
 func GetAddonNameFromTOC(addonDir string) (string, error) {
 	_, name, err := FindTOCFile(addonDir)
@@ -0,0 +1,163 @@
+package addons
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFindTOCFileFlavors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantTOC  string
+		wantName string
+	}{
+		{
+			name:     "single toc",
+			files:    map[string]string{"Foo/Foo.toc": "## Interface: 11200\n"},
+			wantTOC:  "Foo/Foo.toc",
+			wantName: "Foo",
+		},
+		{
+			name: "vanilla suffix wins",
+			files: map[string]string{
+				"Foo/Foo.toc":         "## Interface: 30300\n",
+				"Foo/Foo-TBC.toc":     "## Interface: 20400\n",
+				"Foo/Foo-Vanilla.toc": "## Interface: 11200\n",
+			},
+			wantTOC:  "Foo/Foo-Vanilla.toc",
+			wantName: "Foo",
+		},
+		{
+			name: "classic underscore suffix",
+			files: map[string]string{
+				"Foo/Foo_Classic.toc": "## Title: Foo\n",
+				"Foo/Foo_Wrath.toc":   "## Interface: 30403\n",
+			},
+			wantTOC:  "Foo/Foo_Classic.toc",
+			wantName: "Foo_Classic",
+		},
+		{
+			name: "matching interface wins over base",
+			files: map[string]string{
+				"Foo/Foo.toc":     "## Interface: 30300\n",
+				"Foo/Foo-BCC.toc": "## Interface: 20504\n",
+				"Foo/Foo-Era.toc": "## Interface: 11200\n",
+			},
+			wantTOC:  "Foo/Foo-Era.toc",
+			wantName: "Foo-Era",
+		},
+		{
+			name: "falls back to base toc",
+			files: map[string]string{
+				"Foo/Foo-TBC.toc":   "## Interface: 20400\n",
+				"Foo/Foo.toc":       "## Title: Foo\n",
+				"Foo/Foo-Wrath.toc": "## Interface: 30300\n",
+			},
+			wantTOC:  "Foo/Foo.toc",
+			wantName: "Foo",
+		},
+		{
+			name: "flavors in a subfolder",
+			files: map[string]string{
+				"README.md":            "repo",
+				"Foo/Foo-Mainline.toc": "## Interface: 110000\n",
+				"Foo/Foo-Vanilla.toc":  "## Interface: 11200\n",
+				"Foo/libs/Lib/Lib.toc": "## Interface: 11200\n",
+			},
+			wantTOC:  "Foo/Foo-Vanilla.toc",
+			wantName: "Foo-Vanilla",
+		},
+		{
+			name:     "lone flavor toc keeps its name",
+			files:    map[string]string{"Foo/Foo-Classic.toc": "## Interface: 11200\n"},
+			wantTOC:  "Foo/Foo-Classic.toc",
+			wantName: "Foo-Classic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writePackFile(t, dir, name, content)
+			}
+
+			root := filepath.Join(dir, "Foo")
+			if _, ok := tt.files["README.md"]; ok {
+				root = dir
+			}
+
+			tocPath, addonName, err := FindTOCFile(root)
+			if err != nil {
+				t.Fatalf("FindTOCFile() returned error: %v", err)
+			}
+			if want := filepath.Join(dir, tt.wantTOC); tocPath != want {
+				t.Errorf("FindTOCFile() path = %q, want %q", tocPath, want)
+			}
+			if addonName != tt.wantName {
+				t.Errorf("FindTOCFile() name = %q, want %q", addonName, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestTOCFlavor(t *testing.T) {
+	tests := []struct {
+		file, name, flavor string
+	}{
+		{"Foo.toc", "Foo", ""},
+		{"Foo-Vanilla.toc", "Foo", "vanilla"},
+		{"Foo_TBC.toc", "Foo", "tbc"},
+		{"Foo-Mainline.TOC", "Foo", "mainline"},
+		{"Classic.toc", "Classic", ""},
+		{"FooClassic.toc", "FooClassic", ""},
+	}
+
+	for _, tt := range tests {
+		name, flavor := tocFlavor(tt.file)
+		if name != tt.name || flavor != tt.flavor {
+			t.Errorf("tocFlavor(%q) = %q, %q, want %q, %q", tt.file, name, flavor, tt.name, tt.flavor)
+		}
+	}
+}
+
+func TestParseTOCCustomFields(t *testing.T) {
+	dir := t.TempDir()
+	writePackFile(t, dir, "Foo/Foo.toc", `## Interface: 11200
+## Title: |cff00ff00Foo|r
+## Author: Someone
+## X-Website: https://example.com/foo
+## x-category: Quests
+## X-Curse-Project-ID: 12345
+## SavedVariables: FooDB
+## X-Empty:
+Foo.lua
+`)
+
+	info, err := ParseTOC(filepath.Join(dir, "Foo", "Foo.toc"))
+	if err != nil {
+		t.Fatalf("ParseTOC() returned error: %v", err)
+	}
+
+	if info.Title != "Foo" || info.Author != "Someone" {
+		t.Errorf("ParseTOC() typed fields = %q, %q", info.Title, info.Author)
+	}
+	if got := info.Website(); got != "https://example.com/foo" {
+		t.Errorf("Website() = %q", got)
+	}
+	if got := info.Category(); got != "Quests" {
+		t.Errorf("Category() = %q", got)
+	}
+	if got := info.Field("x-curse-project-id"); got != "12345" {
+		t.Errorf("Field(x-curse-project-id) = %q", got)
+	}
+	if got := info.Fields["SavedVariables"]; got != "FooDB" {
+		t.Errorf("Fields[SavedVariables] = %q", got)
+	}
+	for _, key := range []string{"Title", "Author", "Interface", "X-Empty"} {
+		if _, ok := info.Fields[key]; ok {
+			t.Errorf("Fields contains %q, want it left out", key)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package addons
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+// UpdateCheckTTL is how long a cached update check is trusted before fetching again
+const UpdateCheckTTL = 30 * time.Minute
+
+// updateCacheEntry is the last update check result for one addon
+type updateCacheEntry struct {
+	CheckedAt time.Time `json:"checked_at"`
+	HasUpdate bool      `json:"has_update"`
+	Current   string    `json:"current"`
+	Latest    string    `json:"latest"`
+	Ref       string    `json:"ref,omitempty"`
+}
+
+// updateCachePath returns the path of the update check cache
+func (m *Manager) updateCachePath() string {
+	return filepath.Join(m.dataDir, "update-check.json")
+}
+
+// loadUpdateCache reads the update check cache, empty if missing or corrupt
+func (m *Manager) loadUpdateCache() map[string]updateCacheEntry {
+	cache := make(map[string]updateCacheEntry)
+	data, err := os.ReadFile(m.updateCachePath())
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		m.log.Debug("Ignoring corrupt update check cache", "error", err)
+		return make(map[string]updateCacheEntry)
+	}
+	return cache
+}
+
+// saveUpdateCache writes the update check cache
+func (m *Manager) saveUpdateCache(cache map[string]updateCacheEntry) error {
+	if err := os.MkdirAll(m.dataDir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.updateCachePath(), data, 0644)
+}
+
+// cachedResult returns the cached check for an addon if it still describes
+// the checkout on disk, i.e. HEAD and the pinned ref haven't changed since
+func (m *Manager) cachedResult(cache map[string]updateCacheEntry, name string) (updateCacheEntry, bool) {
+	entry, ok := cache[name]
+	if !ok {
+		return entry, false
+	}
+	current, err := GetCurrentCommit(m.RepoPath(name))
+	if err != nil || current[:shortHashLen] != entry.Current {
+		return entry, false
+	}
+	meta, _ := m.store.Get(name)
+	return entry, meta.Ref == entry.Ref
+}
+
+// CachedUpdates returns the last known update check for tracked addons
+// without touching the network, however old it is
+func (m *Manager) CachedUpdates() []CheckUpdatesResult {
+	var results []CheckUpdatesResult
+	cache := m.loadUpdateCache()
+	for _, name := range m.GetUpdatableAddons() {
+		entry, ok := m.cachedResult(cache, name)
+		if !ok {
+			continue
+		}
+		results = append(results, CheckUpdatesResult{
+			Name:      name,
+			Current:   entry.Current,
+			Latest:    entry.Latest,
+			Ref:       entry.Ref,
+			HasUpdate: entry.HasUpdate,
+		})
+	}
+	return results
+}
+
+// CheckAllUpdatesCached checks tracked addons for updates, fetching only those
+// whose cached check is older than UpdateCheckTTL (or all of them with force)
+// Failed checks aren't cached so they're retried next time
+func (m *Manager) CheckAllUpdatesCached(ctx context.Context, force bool) []CheckUpdatesResult {
+	if offline.Enabled() {
+		return m.CachedUpdates()
+	}
+
+	cache := m.loadUpdateCache()
+	var results []CheckUpdatesResult
+	var stale []string
+	for _, name := range m.GetUpdatableAddons() {
+		entry, ok := m.cachedResult(cache, name)
+		if force || !ok || time.Since(entry.CheckedAt) > UpdateCheckTTL {
+			stale = append(stale, name)
+			continue
+		}
+		results = append(results, CheckUpdatesResult{
+			Name:      name,
+			Current:   entry.Current,
+			Latest:    entry.Latest,
+			Ref:       entry.Ref,
+			HasUpdate: entry.HasUpdate,
+		})
+	}
+
+	m.log.Debug("Checking addon updates", "stale", len(stale), "cached", len(results))
+	if len(stale) == 0 {
+		return results
+	}
+
+	now := time.Now()
+	for _, result := range m.CheckUpdates(ctx, stale) {
+		results = append(results, result)
+		if result.Error != nil {
+			delete(cache, result.Name)
+			continue
+		}
+		cache[result.Name] = updateCacheEntry{
+			CheckedAt: now,
+			HasUpdate: result.HasUpdate,
+			Current:   result.Current,
+			Latest:    result.Latest,
+			Ref:       result.Ref,
+		}
+	}
+
+	if err := m.saveUpdateCache(cache); err != nil {
+		m.log.Warn("Failed to save update check cache", "error", err)
+	}
+	return results
+}
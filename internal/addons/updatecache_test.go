@@ -0,0 +1,72 @@
+package addons
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestCheckAllUpdatesCachedSkipsFreshAddons(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	m.store.Set("Fixture", AddonMetadata{})
+
+	if results := m.CachedUpdates(); len(results) != 0 {
+		t.Fatalf("expected an empty cache, got %+v", results)
+	}
+	results := m.CheckAllUpdatesCached(context.Background(), false)
+	if len(results) != 1 || results[0].HasUpdate {
+		t.Fatalf("expected Fixture up to date, got %+v", results)
+	}
+
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	// Within the TTL the cached result is used without fetching
+	results = m.CheckAllUpdatesCached(context.Background(), false)
+	if len(results) != 1 || results[0].HasUpdate {
+		t.Fatalf("expected the cached result, got %+v", results)
+	}
+
+	// An expired entry is fetched again
+	cache := m.loadUpdateCache()
+	entry := cache["Fixture"]
+	entry.CheckedAt = entry.CheckedAt.Add(-2 * UpdateCheckTTL)
+	cache["Fixture"] = entry
+	if err := m.saveUpdateCache(cache); err != nil {
+		t.Fatalf("saveUpdateCache() returned error: %v", err)
+	}
+	results = m.CheckAllUpdatesCached(context.Background(), false)
+	if len(results) != 1 || !results[0].HasUpdate {
+		t.Fatalf("expected a stale entry to be refetched, got %+v", results)
+	}
+
+	results = m.CachedUpdates()
+	if len(results) != 1 || !results[0].HasUpdate {
+		t.Fatalf("expected the refreshed result to be cached, got %+v", results)
+	}
+}
+
+func TestCheckAllUpdatesCachedForce(t *testing.T) {
+	src, srcRepo := newFixtureRepo(t, 1, 1024)
+
+	m := NewManager(t.TempDir(), t.TempDir(), log.New(io.Discard))
+	if err := CloneRepo(context.Background(), src, filepath.Join(m.GetAddonsDir(), "Fixture"), "", ShallowDepth, nil); err != nil {
+		t.Fatalf("CloneRepo() returned error: %v", err)
+	}
+	m.store.Set("Fixture", AddonMetadata{})
+
+	m.CheckAllUpdatesCached(context.Background(), false)
+	commitFixture(t, src, srcRepo, 1024, "new commit")
+
+	results := m.CheckAllUpdatesCached(context.Background(), true)
+	if len(results) != 1 || !results[0].HasUpdate {
+		t.Fatalf("expected force to bypass the cache, got %+v", results)
+	}
+}
@@ -0,0 +1,54 @@
+package addons
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// webHosts are the forges whose web page URLs NormalizeRepoURL understands
+var webHosts = []string{"github.com", "gitlab.com"}
+
+// nonRepoOwners are first path segments on the forges that aren't users or
+// groups, e.g. github.com/topics/wow
+var nonRepoOwners = []string{"topics", "explore", "settings", "notifications", "login", "signup", "search", "marketplace", "orgs", "users", "dashboard"}
+
+// NormalizeRepoURL turns a GitHub or GitLab web page URL, as copied from the
+// browser, into the repository URL: query, fragment and paths below the
+// repository like /tree/master, /blob/..., /releases or GitLab's /-/... are
+// dropped. An @ref suffix is kept. Other hosts, non-HTTP URLs and URLs with
+// credentials are returned unchanged, pages that aren't a repository (e.g.
+// /topics/...) are an error
+func NormalizeRepoURL(rawURL string) (string, error) {
+	repoURL, ref := SplitGitRef(strings.TrimSpace(rawURL))
+	u, err := url.Parse(repoURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.User != nil {
+		// Credentials are rejected by Install, don't drop them silently
+		return rawURL, nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if !slices.Contains(webHosts, host) {
+		return rawURL, nil
+	}
+
+	path := strings.Trim(u.Path, "/")
+	if host == "gitlab.com" {
+		// GitLab repos can sit in subgroups, pages below them start with /-/
+		path, _, _ = strings.Cut(path, "/-/")
+		path = strings.TrimSuffix(path, "/-")
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" || slices.Contains(nonRepoOwners, strings.ToLower(parts[0])) {
+		return "", fmt.Errorf("%w: %s is not a repository page", ErrInvalidURL, rawURL)
+	}
+	if host == "github.com" {
+		parts = parts[:2]
+	}
+
+	normalized := "https://" + host + "/" + strings.Join(parts, "/")
+	if ref != "" {
+		normalized += "@" + ref
+	}
+	return normalized, nil
+}
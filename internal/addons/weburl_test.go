@@ -0,0 +1,54 @@
+package addons
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeRepoURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest"},
+		{"https://github.com/shagu/pfQuest/", "https://github.com/shagu/pfQuest"},
+		{"https://github.com/shagu/pfQuest.git", "https://github.com/shagu/pfQuest.git"},
+		{"https://github.com/shagu/pfQuest/tree/master", "https://github.com/shagu/pfQuest"},
+		{"https://github.com/shagu/pfQuest/blob/master/README.md", "https://github.com/shagu/pfQuest"},
+		{"https://github.com/shagu/pfQuest/releases", "https://github.com/shagu/pfQuest"},
+		{"https://www.github.com/shagu/pfQuest?tab=readme#install", "https://github.com/shagu/pfQuest"},
+		{"http://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest"},
+		{"https://github.com/shagu/pfQuest/tree/master@v4.0.0", "https://github.com/shagu/pfQuest@v4.0.0"},
+		{"https://gitlab.com/group/sub/addon/-/tree/main", "https://gitlab.com/group/sub/addon"},
+		{"https://gitlab.com/user/addon/-/releases", "https://gitlab.com/user/addon"},
+		{"https://gitlab.com/user/addon", "https://gitlab.com/user/addon"},
+		// Left alone
+		{"https://codeberg.org/user/addon/src/branch/main", "https://codeberg.org/user/addon/src/branch/main"},
+		{"git@github.com:shagu/pfQuest.git", "git@github.com:shagu/pfQuest.git"},
+		{"/home/user/addon.zip", "/home/user/addon.zip"},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeRepoURL(tt.in)
+		if err != nil {
+			t.Errorf("NormalizeRepoURL(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeRepoURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRepoURLRejectsNonRepoPages(t *testing.T) {
+	for _, in := range []string{
+		"https://github.com/topics/world-of-warcraft",
+		"https://github.com/explore",
+		"https://github.com/shagu",
+		"https://gitlab.com/explore/projects",
+	} {
+		if _, err := NormalizeRepoURL(in); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("NormalizeRepoURL(%q) error = %v, want ErrInvalidURL", in, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PreferenceKeys are the launcher preferences that can be read and set
+var PreferenceKeys = []string{"language", "linuxLaunchArgs", "mirror", "clientDir", "safeDir"}
+
+// Languages are the language codes the launcher ships translations for
+var Languages = []string{"de", "en", "es", "fr", "pt", "ru", "zh"}
+
+var (
+	ErrUnknownPreference  = errors.New("unknown preference")
+	ErrInvalidPreference  = errors.New("invalid preference value")
+	ErrReadOnlyPreference = errors.New("preference is managed by turtlectl")
+)
+
+// launchArgsPlaceholder is replaced with the game executable by the launcher
+const launchArgsPlaceholder = "$WoW.exe$"
+
+// PreferencesPath returns the launcher's preferences.json path
+func (l *Launcher) PreferencesPath() string {
+	return filepath.Join(l.DataDir, "preferences.json")
+}
+
+// GetPreference returns a preference from preferences.json, creating the
+// defaults first if needed
+func (l *Launcher) GetPreference(key string) (string, error) {
+	key, err := preferenceKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	prefs, err := l.readPrefs()
+	if os.IsNotExist(err) {
+		if err := l.InitPreferences(); err != nil {
+			return "", err
+		}
+		prefs, err = l.readPrefs()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	value, _ := prefs[key].(string)
+	return value, nil
+}
+
+// SetPreference validates and saves a preference, keeping unknown fields
+func (l *Launcher) SetPreference(key, value string) error {
+	key, err := preferenceKey(key)
+	if err != nil {
+		return err
+	}
+	if err := l.validatePreference(key, value); err != nil {
+		return err
+	}
+
+	if err := l.InitPreferences(); err != nil {
+		return err
+	}
+	prefs, err := l.readPrefs()
+	if err != nil {
+		return err
+	}
+
+	prefs[key] = value
+	return l.writePrefs(prefs)
+}
+
+// KnownMirrors returns the mirror names from the last AppImage check, or just
+// DefaultMirror if it was never checked
+func (l *Launcher) KnownMirrors() []string {
+	mirrors := []string{DefaultMirror}
+	if info, err := l.CachedAppImageInfo(); err == nil {
+		for name := range info.Mirrors {
+			if !slices.Contains(mirrors, name) {
+				mirrors = append(mirrors, name)
+			}
+		}
+	}
+	sort.Strings(mirrors)
+	return mirrors
+}
+
+// preferenceKey resolves key case-insensitively to its preferences.json name
+func preferenceKey(key string) (string, error) {
+	for _, k := range PreferenceKeys {
+		if strings.EqualFold(k, key) {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (valid: %s)", ErrUnknownPreference, key, strings.Join(PreferenceKeys, ", "))
+}
+
+func (l *Launcher) validatePreference(key, value string) error {
+	switch key {
+	case "language":
+		if !slices.Contains(Languages, value) {
+			return fmt.Errorf("%w: language %s (valid: %s)", ErrInvalidPreference, value, strings.Join(Languages, ", "))
+		}
+	case "mirror":
+		if mirrors := l.KnownMirrors(); !slices.Contains(mirrors, value) {
+			return fmt.Errorf("%w: mirror %s (valid: %s, run turtlectl update to refresh the list)", ErrInvalidPreference, value, strings.Join(mirrors, ", "))
+		}
+	case "linuxLaunchArgs":
+		if !strings.Contains(value, launchArgsPlaceholder) {
+			return fmt.Errorf("%w: linuxLaunchArgs must contain %s", ErrInvalidPreference, launchArgsPlaceholder)
+		}
+	case "clientDir", "safeDir":
+		// InitPreferences rewrites both from the game directory on every launch
+		return fmt.Errorf("%w: %s follows the game directory, use --game-dir or TURTLE_WOW_GAME_DIR", ErrReadOnlyPreference, key)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package launcher
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetPreferenceKeepsUnknownFields(t *testing.T) {
+	l := newTestLauncher(t, `{"language": "en", "mirror": "bunny", "someLauncherField": 42}`)
+
+	if err := l.SetPreference("Language", "ru"); err != nil {
+		t.Fatalf("SetPreference() error: %v", err)
+	}
+
+	if got, err := l.GetPreference("language"); err != nil || got != "ru" {
+		t.Fatalf("GetPreference(language) = %q, %v", got, err)
+	}
+	prefs, err := l.readPrefs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prefs["someLauncherField"] != float64(42) {
+		t.Errorf("unknown field lost: %v", prefs)
+	}
+}
+
+func TestSetPreferenceValidates(t *testing.T) {
+	l := newTestLauncher(t, `{}`)
+
+	tests := []struct {
+		key, value string
+		want       error
+	}{
+		{"colour", "blue", ErrUnknownPreference},
+		{"language", "klingon", ErrInvalidPreference},
+		{"mirror", "nowhere", ErrInvalidPreference},
+		{"linuxLaunchArgs", "wine game.exe", ErrInvalidPreference},
+		{"clientDir", "/tmp", ErrReadOnlyPreference},
+	}
+	for _, tt := range tests {
+		if err := l.SetPreference(tt.key, tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("SetPreference(%q, %q) = %v, want %v", tt.key, tt.value, err, tt.want)
+		}
+	}
+
+	if err := l.SetPreference("mirror", DefaultMirror); err != nil {
+		t.Errorf("SetPreference(mirror, %s) error: %v", DefaultMirror, err)
+	}
+}
@@ -0,0 +1,358 @@
+package launcher
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+const (
+	// IconName is the icon theme name the desktop file refers to
+	IconName = "turtle-wow"
+
+	// desktopFileName is the desktop entry installed in DesktopDir
+	desktopFileName = "turtle-wow.desktop"
+
+	// iconThemeSize is the hicolor size of IconPath, where an AppImage
+	// without sized icons gets its TurtleWoW.png
+	iconThemeSize = "256x256"
+)
+
+// DesktopFilePath returns the installed desktop entry
+func (l *Launcher) DesktopFilePath() string {
+	return filepath.Join(l.DesktopDir, desktopFileName)
+}
+
+// IconPath returns the 256x256 icon in the hicolor theme under IconDir
+func (l *Launcher) IconPath() string {
+	return l.themeIconPath(iconThemeSize)
+}
+
+// themeIconPath returns the icon of a hicolor size directory ("48x48")
+func (l *Launcher) themeIconPath(size string) string {
+	return filepath.Join(l.IconDir, "hicolor", size, "apps", IconName+".png")
+}
+
+// legacyIconPath is where the icon lived before it moved into the hicolor theme
+func (l *Launcher) legacyIconPath() string {
+	return filepath.Join(l.IconDir, IconName+".png")
+}
+
+// desktopEntry renders the desktop file, the icon is looked up by theme name
+// so desktop environments pick up icon changes
+func (l *Launcher) desktopEntry() string {
+	return fmt.Sprintf(`[Desktop Entry]
+Name=Turtle WoW
+Comment=Turtle WoW (via turtlectl)
+Exec=%s launch
+Icon=%s
+Terminal=false
+Type=Application
+Categories=Game;
+Keywords=wow;warcraft;mmo;turtle;
+`, l.ScriptPath, IconName)
+}
+
+// DesktopExec returns the turtlectl path in the installed desktop file's Exec
+func (l *Launcher) DesktopExec() (string, error) {
+	data, err := os.ReadFile(l.DesktopFilePath())
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if exec, ok := strings.CutPrefix(strings.TrimSpace(line), "Exec="); ok {
+			return strings.TrimSuffix(exec, " launch"), nil
+		}
+	}
+	return "", fmt.Errorf("no Exec line in %s", l.DesktopFilePath())
+}
+
+// StaleDesktopExec reports whether the installed desktop file runs a
+// different turtlectl than this one, e.g. after the binary moved, and returns
+// the path it runs. False when no desktop file is installed
+func (l *Launcher) StaleDesktopExec() (string, bool) {
+	execPath, err := l.DesktopExec()
+	if err != nil || l.ScriptPath == "" || execPath == l.ScriptPath {
+		return execPath, false
+	}
+
+	// Same binary through a symlink or a different spelling of the path
+	if installed, err := os.Stat(execPath); err == nil {
+		if current, err := os.Stat(l.ScriptPath); err == nil && os.SameFile(installed, current) {
+			return execPath, false
+		}
+	}
+	return execPath, true
+}
+
+// ExtractIcon installs the AppImage's icons into the hicolor icon theme, one
+// per size it ships, and returns the largest. AppImages without sized icons
+// get their top-level TurtleWoW.png as the 256x256 one
+func (l *Launcher) ExtractIcon() (string, error) {
+	// Check if icons already exist
+	if iconPath := l.largestIcon(); iconPath != "" {
+		l.log.Debug("Icon already exists", "path", iconPath)
+		return iconPath, nil
+	}
+
+	// Check if AppImage exists
+	if _, err := os.Stat(l.AppImagePath); os.IsNotExist(err) {
+		return "", fmt.Errorf("AppImage not found at %s", l.AppImagePath)
+	}
+
+	l.log.Debug("Extracting icons from AppImage", "appimage", l.AppImagePath)
+
+	// Create temp directory for extraction
+	tmpDir, err := os.MkdirTemp("", "turtle-wow-extract-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// Extract only the icons using --appimage-extract with patterns
+	for _, pattern := range []string{"usr/share/icons/*", "TurtleWoW.png"} {
+		if err := l.appImageExtract(tmpDir, pattern); err != nil {
+			l.log.Debug("Pattern extraction failed", "pattern", pattern, "error", err)
+		}
+	}
+	root := filepath.Join(tmpDir, "squashfs-root")
+	if len(appImageIcons(root)) == 0 {
+		l.log.Debug("No icons from pattern extraction, trying full extraction")
+		// Fallback: extract everything and find the icons
+		if err := l.appImageExtract(tmpDir, ""); err != nil {
+			return "", fmt.Errorf("failed to extract AppImage: %w", err)
+		}
+	}
+
+	return l.installIcons(root)
+}
+
+// appImageExtract runs --appimage-extract in dir, limited to pattern if set
+func (l *Launcher) appImageExtract(dir, pattern string) error {
+	args := []string{"--appimage-extract"}
+	if pattern != "" {
+		args = append(args, pattern)
+	}
+	cmd := exec.Command(l.AppImagePath, args...)
+	cmd.Dir = dir
+	cmd.Stdout = io.Discard
+	cmd.Stderr = io.Discard
+	return cmd.Run()
+}
+
+// appImageIcons finds the PNG icons in an extracted AppImage by hicolor size
+// ("48x48"), preferring files named like the game when an apps dir has several
+func appImageIcons(root string) map[string]string {
+	icons := make(map[string]string)
+	matches, _ := filepath.Glob(filepath.Join(root, "usr", "share", "icons", "hicolor", "*", "apps", "*.png"))
+	for _, path := range matches {
+		size := filepath.Base(filepath.Dir(filepath.Dir(path)))
+		if iconSize(size) == 0 {
+			continue
+		}
+		if _, ok := icons[size]; ok && !strings.Contains(strings.ToLower(filepath.Base(path)), "turtle") {
+			continue
+		}
+		icons[size] = path
+	}
+
+	if _, ok := icons[iconThemeSize]; !ok {
+		if _, err := os.Stat(filepath.Join(root, "TurtleWoW.png")); err == nil {
+			icons[iconThemeSize] = filepath.Join(root, "TurtleWoW.png")
+		}
+	}
+	return icons
+}
+
+// iconSize parses a hicolor size directory like "48x48", 0 if it isn't one
+func iconSize(dir string) int {
+	w, h, ok := strings.Cut(dir, "x")
+	if !ok || w != h {
+		return 0
+	}
+	n, _ := strconv.Atoi(w)
+	return n
+}
+
+// installIcons copies the icons of an extracted AppImage into the theme and
+// returns the largest
+func (l *Launcher) installIcons(root string) (string, error) {
+	icons := appImageIcons(root)
+	if len(icons) == 0 {
+		return "", fmt.Errorf("icon not found in AppImage")
+	}
+
+	for size, src := range icons {
+		dst := l.themeIconPath(size)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", fmt.Errorf("failed to create icon dir: %w", err)
+		}
+		if err := copyFile(src, dst); err != nil {
+			return "", fmt.Errorf("failed to copy icon: %w", err)
+		}
+	}
+
+	iconPath := l.largestIcon()
+	l.log.Info("Icons extracted from AppImage", "sizes", len(icons), "path", iconPath)
+	return iconPath, nil
+}
+
+// installedIcons lists the icons installed in the theme, any size
+func (l *Launcher) installedIcons() []string {
+	matches, _ := filepath.Glob(l.themeIconPath("*"))
+	return matches
+}
+
+// largestIcon returns the biggest installed icon, empty without any
+func (l *Launcher) largestIcon() string {
+	var largest string
+	best := 0
+	for _, path := range l.installedIcons() {
+		if size := iconSize(filepath.Base(filepath.Dir(filepath.Dir(path)))); size > best {
+			largest, best = path, size
+		}
+	}
+	return largest
+}
+
+// copyFile copies src to dst, replacing dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// InstallDesktop installs the icon and desktop file, rewriting the desktop
+// file when its Exec or Icon is outdated (e.g. turtlectl moved)
+func (l *Launcher) InstallDesktop() error {
+	l.log.Info("Installing desktop integration")
+
+	// Create directories
+	if err := os.MkdirAll(l.DesktopDir, 0755); err != nil {
+		return fmt.Errorf("failed to create desktop dir: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.IconPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create icon dir: %w", err)
+	}
+
+	// Extract icon from AppImage
+	if _, err := l.ExtractIcon(); err != nil {
+		l.log.Warn("Failed to extract icon from AppImage, using fallback", "error", err)
+		l.downloadFallbackIcon()
+	}
+
+	// The icon used to be referenced by absolute path outside the theme
+	if err := os.Remove(l.legacyIconPath()); err == nil {
+		l.log.Debug("Removed legacy icon", "path", l.legacyIconPath())
+	}
+	l.updateIconCache()
+
+	desktopPath := l.DesktopFilePath()
+	desktopContent := l.desktopEntry()
+
+	existing, err := os.ReadFile(desktopPath)
+	if err == nil && string(existing) == desktopContent {
+		l.log.Info("Desktop file up to date", "path", desktopPath)
+		return nil
+	}
+	if err == nil {
+		l.log.Info("Updating outdated desktop file", "path", desktopPath)
+	}
+
+	l.log.Debug("Writing desktop file", "path", desktopPath)
+	if err := os.WriteFile(desktopPath, []byte(desktopContent), 0644); err != nil {
+		return fmt.Errorf("failed to write desktop file: %w", err)
+	}
+
+	// Update desktop database
+	l.log.Debug("Updating desktop database")
+	_ = exec.Command("update-desktop-database", l.DesktopDir).Run()
+
+	l.log.Info("Desktop file installed", "path", desktopPath)
+	return nil
+}
+
+// downloadFallbackIcon fetches the website favicon when the AppImage icon
+// can't be extracted
+func (l *Launcher) downloadFallbackIcon() {
+	iconPath := l.IconPath()
+	if _, err := os.Stat(iconPath); err == nil || offline.Enabled() {
+		return
+	}
+
+	l.log.Debug("Downloading fallback icon")
+	resp, err := http.Get("https://turtle-wow.org/favicon.ico")
+	if err != nil {
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	out, err := os.Create(iconPath)
+	if err != nil {
+		return
+	}
+	_, _ = io.Copy(out, resp.Body)
+	_ = out.Close()
+	l.log.Debug("Fallback icon downloaded", "path", iconPath)
+}
+
+// updateIconCache refreshes the hicolor icon cache when GTK's tool is installed
+func (l *Launcher) updateIconCache() {
+	if _, err := exec.LookPath("gtk-update-icon-cache"); err != nil {
+		return
+	}
+	l.log.Debug("Updating icon cache")
+	_ = exec.Command("gtk-update-icon-cache", "-f", "-t", filepath.Join(l.IconDir, "hicolor")).Run()
+}
+
+// removeDesktopFiles deletes the desktop file and icons, current and legacy
+func (l *Launcher) removeDesktopFiles() {
+	desktopPath := l.DesktopFilePath()
+	if err := os.Remove(desktopPath); err != nil && !os.IsNotExist(err) {
+		l.log.Warn("Failed to remove desktop file", "error", err)
+	} else {
+		l.log.Debug("Removed desktop file", "path", desktopPath)
+	}
+
+	for _, iconPath := range append(l.installedIcons(), l.legacyIconPath()) {
+		if err := os.Remove(iconPath); err != nil && !os.IsNotExist(err) {
+			l.log.Warn("Failed to remove icon", "error", err)
+		} else {
+			l.log.Debug("Removed icon", "path", iconPath)
+		}
+	}
+
+	l.updateIconCache()
+	_ = exec.Command("update-desktop-database", l.DesktopDir).Run()
+}
+
+func (l *Launcher) UninstallDesktop() error {
+	l.log.Info("Removing desktop integration")
+
+	l.removeDesktopFiles()
+
+	l.log.Info("Desktop integration removed")
+	return nil
+}
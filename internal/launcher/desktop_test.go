@@ -0,0 +1,126 @@
+package launcher
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+func TestInstallDesktopRewritesOutdatedEntry(t *testing.T) {
+	offline.Set(true)
+	t.Cleanup(func() { offline.Set(false) })
+
+	l := &Launcher{
+		log:          log.New(io.Discard),
+		DesktopDir:   t.TempDir(),
+		IconDir:      t.TempDir(),
+		AppImagePath: filepath.Join(t.TempDir(), "TurtleWoW.AppImage"),
+		ScriptPath:   "/usr/bin/turtlectl",
+	}
+
+	// Left over from the absolute-path icon
+	if err := os.WriteFile(l.legacyIconPath(), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.InstallDesktop(); err != nil {
+		t.Fatalf("InstallDesktop() error: %v", err)
+	}
+	data, err := os.ReadFile(l.DesktopFilePath())
+	if err != nil {
+		t.Fatalf("desktop file not written: %v", err)
+	}
+	if !strings.Contains(string(data), "Exec=/usr/bin/turtlectl launch\n") || !strings.Contains(string(data), "Icon="+IconName+"\n") {
+		t.Errorf("unexpected desktop file:\n%s", data)
+	}
+	if _, err := os.Stat(l.legacyIconPath()); !os.IsNotExist(err) {
+		t.Errorf("legacy icon not removed: %v", err)
+	}
+
+	// turtlectl moved
+	l.ScriptPath = "/home/me/bin/turtlectl"
+	if err := l.InstallDesktop(); err != nil {
+		t.Fatalf("InstallDesktop() error: %v", err)
+	}
+	data, _ = os.ReadFile(l.DesktopFilePath())
+	if !strings.Contains(string(data), "Exec=/home/me/bin/turtlectl launch\n") {
+		t.Errorf("Exec not updated:\n%s", data)
+	}
+}
+
+func TestStaleDesktopExec(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "turtlectl")
+	if err := os.WriteFile(binary, []byte("bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "turtlectl-link")
+	if err := os.Symlink(binary, link); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Launcher{log: log.New(io.Discard), DesktopDir: t.TempDir(), ScriptPath: binary}
+	if _, stale := l.StaleDesktopExec(); stale {
+		t.Error("reported stale without a desktop file")
+	}
+
+	l.ScriptPath = link
+	if err := os.WriteFile(l.DesktopFilePath(), []byte(l.desktopEntry()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	l.ScriptPath = binary
+	if execPath, stale := l.StaleDesktopExec(); stale || execPath != link {
+		t.Errorf("symlink to the same binary reported stale: %s, %v", execPath, stale)
+	}
+
+	l.ScriptPath = filepath.Join(t.TempDir(), "turtlectl")
+	if execPath, stale := l.StaleDesktopExec(); !stale || execPath != link {
+		t.Errorf("moved binary not detected: %s, %v", execPath, stale)
+	}
+}
+
+func TestInstallIconsAllSizes(t *testing.T) {
+	root := t.TempDir()
+	for _, icon := range []string{"48x48/apps/TurtleWoW.png", "128x128/apps/TurtleWoW.png", "128x128/apps/other.png", "scalable/apps/TurtleWoW.png"} {
+		path := filepath.Join(root, "usr", "share", "icons", "hicolor", icon)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(icon), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "TurtleWoW.png"), []byte("top-level"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Launcher{log: log.New(io.Discard), IconDir: t.TempDir()}
+	largest, err := l.installIcons(root)
+	if err != nil {
+		t.Fatalf("installIcons() error: %v", err)
+	}
+	if largest != l.IconPath() {
+		t.Errorf("largest icon = %s, want %s", largest, l.IconPath())
+	}
+
+	want := map[string]string{
+		"48x48":   "48x48/apps/TurtleWoW.png",
+		"128x128": "128x128/apps/TurtleWoW.png",
+		"256x256": "top-level",
+	}
+	for size, content := range want {
+		data, err := os.ReadFile(l.themeIconPath(size))
+		if err != nil || string(data) != content {
+			t.Errorf("%s icon = %q, %v; want %q", size, data, err, content)
+		}
+	}
+	if n := len(l.installedIcons()); n != len(want) {
+		t.Errorf("installed %d icons, want %d", n, len(want))
+	}
+}
@@ -2,6 +2,8 @@ package launcher
 
 import (
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -39,11 +41,14 @@ func (l *Launcher) setupWaylandEnv() {
 	}
 }
 
-// setupGPUEnv detects GPU vendor and sets appropriate environment variables
+// setupGPUEnv detects the GPUs and sets appropriate environment variables
 func (l *Launcher) setupGPUEnv() {
-	gpuVendor := detectGPUVendor()
+	gpus := detectGPUs(sysRoot)
+	hybrid := gpus.Hybrid()
 
-	switch gpuVendor {
+	l.log.Debug("GPUs detected", "count", len(gpus.All), "primary", gpus.Primary.Card, "hybrid", hybrid)
+
+	switch gpus.Primary.Vendor {
 	case "amd":
 		l.log.Info("AMD GPU detected, applying optimizations")
 
@@ -55,13 +60,20 @@ func (l *Launcher) setupGPUEnv() {
 		// See: https://wiki.archlinux.org/title/AMDGPU#ACO_compiler
 		_ = os.Setenv("RADV_PERFTEST", "gpl")
 
+		// Render on the discrete GPU with Mesa PRIME
+		// See: https://wiki.archlinux.org/title/PRIME#PRIME_GPU_offloading
+		if hybrid {
+			setDefaultEnv("DRI_PRIME", "1")
+		}
+
 		l.log.Debug("AMD GPU environment set",
 			"AMD_VULKAN_ICD", "RADV",
 			"RADV_PERFTEST", "gpl",
+			"DRI_PRIME", os.Getenv("DRI_PRIME"),
 		)
 
 	case "nvidia":
-		l.log.Info("NVIDIA GPU detected, applying optimizations")
+		l.log.Info("NVIDIA GPU detected, applying optimizations", "hybrid", hybrid)
 
 		// Force GBM backend for NVIDIA (required for Wayland on NVIDIA >= 495)
 		// See: https://wiki.archlinux.org/title/Wayland#Requirements
@@ -75,6 +87,19 @@ func (l *Launcher) setupGPUEnv() {
 			)
 		}
 
+		// Offload rendering from the integrated GPU
+		// See: https://wiki.archlinux.org/title/PRIME#PRIME_render_offload
+		if hybrid {
+			setDefaultEnv("__NV_PRIME_RENDER_OFFLOAD", "1")
+			setDefaultEnv("__GLX_VENDOR_LIBRARY_NAME", "nvidia")
+			setDefaultEnv("__VK_LAYER_NV_optimus", "NVIDIA_only")
+
+			l.log.Debug("NVIDIA PRIME offload environment set",
+				"__NV_PRIME_RENDER_OFFLOAD", os.Getenv("__NV_PRIME_RENDER_OFFLOAD"),
+				"__VK_LAYER_NV_optimus", os.Getenv("__VK_LAYER_NV_optimus"),
+			)
+		}
+
 	case "intel":
 		l.log.Info("Intel GPU detected")
 		// Intel generally works well with defaults
@@ -86,45 +111,130 @@ func (l *Launcher) setupGPUEnv() {
 	}
 }
 
-// detectGPUVendor attempts to detect the GPU vendor from /sys
-func detectGPUVendor() string {
-	// Check common GPU vendor IDs in sysfs
-	vendorPaths := []string{
-		"/sys/class/drm/card0/device/vendor",
-		"/sys/class/drm/card1/device/vendor",
+// setDefaultEnv sets key unless the user already did
+func setDefaultEnv(key, value string) {
+	if _, ok := os.LookupEnv(key); !ok {
+		_ = os.Setenv(key, value)
 	}
+}
+
+// sysRoot is prepended to /sys and /proc paths, tests point it at a fake tree
+var sysRoot = "/"
 
-	for _, path := range vendorPaths {
-		data, err := os.ReadFile(path)
+// GPU is a single graphics card found in sysfs
+type GPU struct {
+	Card     string // e.g. card1, empty when only found through kernel modules
+	Vendor   string // amd, nvidia, intel or unknown
+	Discrete bool
+}
+
+// GPUInfo lists every detected GPU and the one the game should render on
+type GPUInfo struct {
+	Primary GPU
+	All     []GPU
+}
+
+// Hybrid reports whether the primary GPU is a discrete one next to another GPU
+func (g GPUInfo) Hybrid() bool {
+	return len(g.All) > 1 && g.Primary.Discrete
+}
+
+// gpuVendors maps PCI vendor IDs to vendor names
+var gpuVendors = map[string]string{
+	"0x1002": "amd",
+	"0x10de": "nvidia",
+	"0x8086": "intel",
+}
+
+// detectGPUs enumerates /sys/class/drm/card* and picks the primary GPU
+// The discrete GPU wins unless DRI_PRIME or __NV_PRIME_RENDER_OFFLOAD say otherwise
+func detectGPUs(root string) GPUInfo {
+	var info GPUInfo
+
+	cards, _ := filepath.Glob(filepath.Join(root, "sys/class/drm/card*"))
+	sort.Strings(cards)
+	for _, cardDir := range cards {
+		card := filepath.Base(cardDir)
+		// Skip connectors like card0-DP-1
+		if strings.Contains(card, "-") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(cardDir, "device", "vendor"))
 		if err != nil {
 			continue
 		}
 
-		vendor := strings.TrimSpace(string(data))
-		switch vendor {
-		case "0x1002": // AMD
-			return "amd"
-		case "0x10de": // NVIDIA
-			return "nvidia"
-		case "0x8086": // Intel
-			return "intel"
+		vendor, ok := gpuVendors[strings.TrimSpace(string(data))]
+		if !ok {
+			vendor = "unknown"
 		}
+		bootVGA, _ := os.ReadFile(filepath.Join(cardDir, "device", "boot_vga"))
+
+		info.All = append(info.All, GPU{
+			Card:   card,
+			Vendor: vendor,
+			// NVIDIA is always discrete, AMD only when it isn't the boot GPU (APUs are)
+			Discrete: vendor == "nvidia" || vendor == "amd" && strings.TrimSpace(string(bootVGA)) != "1",
+		})
 	}
 
-	// Fallback: check for loaded kernel modules
-	modules, err := os.ReadFile("/proc/modules")
-	if err == nil {
-		moduleStr := string(modules)
-		if strings.Contains(moduleStr, "amdgpu") || strings.Contains(moduleStr, "radeon") {
-			return "amd"
+	if len(info.All) == 0 {
+		// Fallback: check for loaded kernel modules
+		info.Primary = GPU{Vendor: vendorFromModules(root)}
+		if info.Primary.Vendor != "unknown" {
+			info.All = []GPU{info.Primary}
 		}
-		if strings.Contains(moduleStr, "nvidia") {
-			return "nvidia"
+		return info
+	}
+
+	info.Primary = pickPrimaryGPU(info.All)
+	return info
+}
+
+// pickPrimaryGPU honors explicit offload settings, then prefers a discrete GPU
+func pickPrimaryGPU(gpus []GPU) GPU {
+	if os.Getenv("__NV_PRIME_RENDER_OFFLOAD") == "1" {
+		for _, gpu := range gpus {
+			if gpu.Vendor == "nvidia" {
+				return gpu
+			}
 		}
-		if strings.Contains(moduleStr, "i915") {
-			return "intel"
+	}
+
+	// DRI_PRIME=0 asks for the default (integrated) GPU, any other value for another one
+	if prime, ok := os.LookupEnv("DRI_PRIME"); ok && prime == "0" {
+		for _, gpu := range gpus {
+			if !gpu.Discrete {
+				return gpu
+			}
 		}
 	}
 
+	for _, gpu := range gpus {
+		if gpu.Discrete {
+			return gpu
+		}
+	}
+	return gpus[0]
+}
+
+// vendorFromModules guesses the vendor from loaded kernel modules
+func vendorFromModules(root string) string {
+	modules, err := os.ReadFile(filepath.Join(root, "proc/modules"))
+	if err != nil {
+		return "unknown"
+	}
+
+	moduleStr := string(modules)
+	if strings.Contains(moduleStr, "amdgpu") || strings.Contains(moduleStr, "radeon") {
+		return "amd"
+	}
+	if strings.Contains(moduleStr, "nvidia") {
+		return "nvidia"
+	}
+	if strings.Contains(moduleStr, "i915") {
+		return "intel"
+	}
 	return "unknown"
 }
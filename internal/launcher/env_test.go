@@ -0,0 +1,127 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeCard writes a sysfs card entry under root
+func fakeCard(t *testing.T, root, card, vendor, bootVGA string) {
+	t.Helper()
+	dir := filepath.Join(root, "sys/class/drm", card, "device")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vendor"), []byte(vendor+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if bootVGA != "" {
+		if err := os.WriteFile(filepath.Join(dir, "boot_vga"), []byte(bootVGA+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDetectGPUsPrefersDiscrete(t *testing.T) {
+	// Empty values don't select a GPU
+	t.Setenv("DRI_PRIME", "")
+	t.Setenv("__NV_PRIME_RENDER_OFFLOAD", "")
+
+	tests := []struct {
+		name    string
+		cards   [][3]string
+		primary string
+		hybrid  bool
+	}{
+		{
+			name:    "intel and nvidia laptop",
+			cards:   [][3]string{{"card0", "0x8086", "1"}, {"card1", "0x10de", "0"}},
+			primary: "nvidia",
+			hybrid:  true,
+		},
+		{
+			name:    "amd apu and amd dgpu",
+			cards:   [][3]string{{"card0", "0x1002", "1"}, {"card2", "0x1002", "0"}},
+			primary: "amd",
+			hybrid:  true,
+		},
+		{
+			name:    "single amd desktop",
+			cards:   [][3]string{{"card0", "0x1002", "1"}},
+			primary: "amd",
+		},
+		{
+			name:    "card numbers beyond card1",
+			cards:   [][3]string{{"card3", "0x8086", "1"}},
+			primary: "intel",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, c := range tt.cards {
+				fakeCard(t, root, c[0], c[1], c[2])
+			}
+			// Connectors have no device/vendor and must be ignored
+			if err := os.MkdirAll(filepath.Join(root, "sys/class/drm/card0-eDP-1"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			info := detectGPUs(root)
+			if len(info.All) != len(tt.cards) {
+				t.Fatalf("expected %d GPUs, got %+v", len(tt.cards), info.All)
+			}
+			if info.Primary.Vendor != tt.primary {
+				t.Errorf("primary = %q, want %q", info.Primary.Vendor, tt.primary)
+			}
+			if info.Hybrid() != tt.hybrid {
+				t.Errorf("Hybrid() = %v, want %v", info.Hybrid(), tt.hybrid)
+			}
+		})
+	}
+}
+
+func TestDetectGPUsHonorsDRIPrime(t *testing.T) {
+	root := t.TempDir()
+	fakeCard(t, root, "card0", "0x8086", "1")
+	fakeCard(t, root, "card1", "0x1002", "0")
+
+	t.Setenv("DRI_PRIME", "0")
+	info := detectGPUs(root)
+	if info.Primary.Card != "card0" || info.Hybrid() {
+		t.Fatalf("DRI_PRIME=0 should keep the integrated GPU, got %+v", info.Primary)
+	}
+}
+
+func TestDetectGPUsHonorsNVPrimeOffload(t *testing.T) {
+	root := t.TempDir()
+	fakeCard(t, root, "card0", "0x1002", "0")
+	fakeCard(t, root, "card1", "0x10de", "0")
+
+	t.Setenv("__NV_PRIME_RENDER_OFFLOAD", "1")
+	if info := detectGPUs(root); info.Primary.Card != "card1" {
+		t.Fatalf("expected NVIDIA card1 with render offload, got %+v", info.Primary)
+	}
+}
+
+func TestDetectGPUsFallsBackToModules(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "proc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modules := "nvidia_drm 90112 2 - Live 0x0\nnvidia 54476800 1 nvidia_drm, Live 0x0\n"
+	if err := os.WriteFile(filepath.Join(root, "proc/modules"), []byte(modules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info := detectGPUs(root)
+	if info.Primary.Vendor != "nvidia" || info.Primary.Card != "" {
+		t.Fatalf("expected nvidia from modules, got %+v", info.Primary)
+	}
+
+	if info := detectGPUs(t.TempDir()); info.Primary.Vendor != "unknown" || len(info.All) != 0 {
+		t.Fatalf("expected unknown with empty root, got %+v", info)
+	}
+}
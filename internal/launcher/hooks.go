@@ -0,0 +1,112 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const (
+	// preLaunchDir and postLaunchDir hold hook executables, run in name order
+	preLaunchDir  = "pre-launch.d"
+	postLaunchDir = "post-launch.d"
+)
+
+// HooksDir returns the hooks directory: TURTLECTL_HOOKS_DIR or <DataDir>/hooks
+func (l *Launcher) HooksDir() string {
+	if dir := os.Getenv("TURTLECTL_HOOKS_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(l.DataDir, "hooks")
+}
+
+// findHooks returns executable files in a hooks subdirectory, sorted by name
+func (l *Launcher) findHooks(sub string) []string {
+	dir := filepath.Join(l.HooksDir(), sub)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var hooks []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			l.log.Debug("Skipping non-executable hook", "path", filepath.Join(dir, entry.Name()))
+			continue
+		}
+		hooks = append(hooks, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(hooks)
+	return hooks
+}
+
+// hookEnv returns the environment passed to hooks
+func (l *Launcher) hookEnv(extra ...string) []string {
+	return append(os.Environ(),
+		append([]string{
+			"TURTLE_GAME_DIR=" + l.GameDir,
+			"TURTLE_DATA_DIR=" + l.DataDir,
+			"TURTLE_APPIMAGE=" + l.AppImagePath,
+			"TURTLE_PROFILE=" + l.Profile,
+		}, extra...)...)
+}
+
+// runHooks runs hooks in order with inherited stdio
+// With stopOnError a failing hook aborts the rest
+func (l *Launcher) runHooks(hooks []string, stopOnError bool, extraEnv ...string) error {
+	for _, hook := range hooks {
+		l.log.Info("Running hook", "path", hook)
+
+		cmd := exec.Command(hook)
+		cmd.Dir = l.GameDir
+		cmd.Env = l.hookEnv(extraEnv...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			if stopOnError {
+				return fmt.Errorf("hook %s failed: %w", filepath.Base(hook), err)
+			}
+			l.log.Warn("Hook failed", "path", hook, "error", err)
+		}
+	}
+	return nil
+}
+
+// launchWithHooks runs pre-launch hooks, the game as a child process, then
+// post-launch hooks with TURTLE_EXIT_CODE set
+func (l *Launcher) launchWithHooks(binary string, cmdArgs []string, pre, post []string) error {
+	if err := l.runHooks(pre, true); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(binary, cmdArgs[1:]...)
+	cmd.Dir = l.workDir()
+	cmd.Env = l.launchEnv()
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	runErr := cmd.Run()
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if runErr != nil {
+		exitCode = -1
+	}
+
+	_ = l.runHooks(post, false, "TURTLE_EXIT_CODE="+strconv.Itoa(exitCode))
+
+	return runErr
+}
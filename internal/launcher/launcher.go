@@ -2,17 +2,22 @@ package launcher
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/charmbracelet/log"
+
+	"github.com/bnema/turtlectl/internal/offline"
+	"github.com/bnema/turtlectl/internal/settings"
 )
 
 const (
@@ -20,6 +25,9 @@ const (
 	AppImageAPIURL = "https://launcher.turtlecraft.gg/api/launcher/TurtleWoW.AppImage"
 	// DefaultMirror is the default CDN mirror to use
 	DefaultMirror = "bunny"
+
+	// oldServerHost marks preferences written for the previous launcher backend
+	oldServerHost = "launcher.turtle-wow.org"
 )
 
 // AppImageInfo represents the API response for AppImage metadata
@@ -34,13 +42,31 @@ type AppImageInfo struct {
 
 type Launcher struct {
 	log          *log.Logger
+	Profile      string
 	DataDir      string
+	StateDir     string // Addon store and logs, see ProfileStateDir
 	CacheDir     string
 	GameDir      string
 	AppImagePath string
 	DesktopDir   string
 	IconDir      string
 	ScriptPath   string
+
+	// Mirror is the preferred download mirror, overriding preferences.json
+	Mirror string
+
+	// Wine and Proton select the runner, see ConfigureRunner
+	Wine   string
+	Proton string
+
+	// NoChdir keeps the current directory instead of running in GameDir
+	NoChdir bool
+
+	// wrapper prefixes the launch command, see ConfigureWrappers
+	wrapper []string
+
+	// sandbox is the bwrap command the launch runs in, see ConfigureSandbox
+	sandbox []string
 }
 
 type Preferences struct {
@@ -51,14 +77,35 @@ type Preferences struct {
 	SafeDir         string `json:"safeDir"`
 }
 
+var (
+	ErrGameDirMissing  = errors.New("game directory not found")
+	ErrAppImageMissing = errors.New("AppImage not found")
+)
+
+// gameDirOverride is set from --game-dir and wins over TURTLE_WOW_GAME_DIR
+var gameDirOverride string
+
+// SetGameDir overrides the game directory for launchers created afterwards
+// Precedence: SetGameDir (--game-dir) > TURTLE_WOW_GAME_DIR > ~/Games/turtle-wow
+func SetGameDir(dir string) {
+	dir = settings.ExpandHome(strings.TrimSpace(dir))
+	if dir != "" {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+	}
+	gameDirOverride = dir
+}
+
 func New(logger *log.Logger) *Launcher {
 	homeDir, _ := os.UserHomeDir()
 
-	dataDir := os.Getenv("XDG_DATA_HOME")
-	if dataDir == "" {
-		dataDir = filepath.Join(homeDir, ".local", "share")
-	}
-	dataDir = filepath.Join(dataDir, "turtle-wow")
+	// Profiles namespace the data dir (config, backups) and the state dir
+	// (addon store); the cache, and so the AppImage, is shared
+	profile := ActiveProfile()
+	baseDataHome := dataHome(homeDir)
+	dataDir := ProfileDataDir(profile)
+	stateDir := ProfileStateDir(profile)
 
 	cacheDir := os.Getenv("XDG_CACHE_HOME")
 	if cacheDir == "" {
@@ -66,19 +113,24 @@ func New(logger *log.Logger) *Launcher {
 	}
 	cacheDir = filepath.Join(cacheDir, "turtle-wow")
 
-	gameDir := os.Getenv("TURTLE_WOW_GAME_DIR")
+	gameDir := gameDirOverride
+	if gameDir == "" {
+		gameDir = os.Getenv("TURTLE_WOW_GAME_DIR")
+	}
 	if gameDir == "" {
 		gameDir = filepath.Join(homeDir, "Games", "turtle-wow")
 	}
 
-	desktopDir := filepath.Join(dataDir, "..", "applications")
-	iconDir := filepath.Join(dataDir, "..", "icons")
+	desktopDir := filepath.Join(baseDataHome, "applications")
+	iconDir := filepath.Join(baseDataHome, "icons")
 
 	scriptPath, _ := os.Executable()
 
 	l := &Launcher{
 		log:          logger,
+		Profile:      profile,
 		DataDir:      dataDir,
+		StateDir:     stateDir,
 		CacheDir:     cacheDir,
 		GameDir:      gameDir,
 		AppImagePath: filepath.Join(cacheDir, "TurtleWoW.AppImage"),
@@ -88,7 +140,9 @@ func New(logger *log.Logger) *Launcher {
 	}
 
 	l.log.Debug("Launcher initialized",
+		"profile", l.Profile,
 		"data_dir", l.DataDir,
+		"state_dir", l.StateDir,
 		"cache_dir", l.CacheDir,
 		"game_dir", l.GameDir,
 		"appimage_path", l.AppImagePath,
@@ -167,6 +221,39 @@ func (l *Launcher) fetchAppImageInfo() (*AppImageInfo, error) {
 	return &info, nil
 }
 
+// appImageInfoPath is where the AppImage metadata of the installed file is cached
+func (l *Launcher) appImageInfoPath() string {
+	return filepath.Join(l.CacheDir, "appimage-info.json")
+}
+
+// saveAppImageInfo caches the metadata matching the local AppImage
+func (l *Launcher) saveAppImageInfo(info *AppImageInfo) {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(l.appImageInfoPath(), data, 0644); err != nil {
+		l.log.Debug("Failed to cache AppImage info", "error", err)
+	}
+}
+
+// CachedAppImageInfo returns the metadata saved when the AppImage was last checked
+func (l *Launcher) CachedAppImageInfo() (*AppImageInfo, error) {
+	return readAppImageInfo(l.appImageInfoPath())
+}
+
+func readAppImageInfo(path string) (*AppImageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var info AppImageInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
 // UpdateResult contains information about an AppImage update check
 type UpdateResult struct {
 	NeedsUpdate   bool
@@ -174,6 +261,12 @@ type UpdateResult struct {
 	LocalSize     int64
 	RemoteSize    int64
 	Version       []string
+	Skipped       bool   // Update check skipped (offline mode)
+	Verified      bool   // Download matched the API-provided hash
+	HashAlgorithm string // Algorithm used for verification (empty if not verified)
+	Mirror        string // Mirror the AppImage was downloaded from
+	RolledBack    bool   // Update check skipped after a rollback
+	DownloadErr   error  // Update found but every download failed, existing AppImage kept
 }
 
 func (l *Launcher) UpdateAppImage() error {
@@ -197,6 +290,25 @@ func (l *Launcher) UpdateAppImageWithProgress(onProgress DownloadProgress) (*Upd
 		l.log.Debug("No local AppImage found")
 	}
 
+	// Offline: use whatever AppImage is there, never hit the API
+	if offline.Enabled() {
+		if localExists {
+			l.log.Info("Offline, skipping launcher update check")
+			result.AlreadyLatest = true
+			result.Skipped = true
+			return result, nil
+		}
+		return nil, fmt.Errorf("%w: no AppImage downloaded yet", offline.ErrOffline)
+	}
+
+	// Rolled back: keep the older AppImage until an explicit update
+	if l.RolledBack() && localExists {
+		l.log.Info("Rolled back, skipping launcher update check")
+		result.AlreadyLatest = true
+		result.RolledBack = true
+		return result, nil
+	}
+
 	// Fetch AppImage info from API
 	appInfo, err := l.fetchAppImageInfo()
 	if err != nil {
@@ -224,17 +336,24 @@ func (l *Launcher) UpdateAppImageWithProgress(onProgress DownloadProgress) (*Upd
 			"version", appInfo.Tags,
 		)
 
-		if err := l.downloadAppImageWithProgress(appInfo, onProgress); err != nil {
+		mirror, algo, err := l.downloadFromMirrors(appInfo, onProgress)
+		if err != nil {
 			if localExists {
 				l.log.Warn("Download failed, using existing AppImage", "error", err)
+				result.DownloadErr = err
 				return result, nil
 			}
 			return nil, err
 		}
 
-		l.log.Info("Launcher updated successfully", "version", appInfo.Tags)
+		l.saveAppImageInfo(appInfo)
+		result.Mirror = mirror
+		result.Verified = algo != ""
+		result.HashAlgorithm = algo
+		l.log.Info("Launcher updated successfully", "version", appInfo.Tags, "mirror", mirror, "verified", algo)
 	} else {
 		result.AlreadyLatest = true
+		l.saveAppImageInfo(appInfo)
 		l.log.Info("Launcher is up to date",
 			"size", formatBytes(result.LocalSize),
 			"version", appInfo.Tags,
@@ -247,32 +366,96 @@ func (l *Launcher) UpdateAppImageWithProgress(onProgress DownloadProgress) (*Upd
 // DownloadProgress is a callback for download progress updates
 type DownloadProgress func(downloaded, total int64)
 
-func (l *Launcher) downloadAppImageWithProgress(info *AppImageInfo, onProgress DownloadProgress) error {
-	// Get download URL from mirror
-	downloadURL, ok := info.Mirrors[DefaultMirror]
-	if !ok {
-		// Fallback to first available mirror
-		for name, url := range info.Mirrors {
-			l.log.Debug("Using fallback mirror", "mirror", name)
-			downloadURL = url
-			break
+// defaultMirror replaces DefaultMirror, see SetDefaultMirror
+var defaultMirror string
+
+// SetDefaultMirror sets the mirror used when neither Mirror nor
+// preferences.json pick one, e.g. from the config file
+func SetDefaultMirror(name string) {
+	defaultMirror = name
+}
+
+// preferredMirror returns the mirror to try first: Mirror, then preferences.json,
+// then SetDefaultMirror, then DefaultMirror
+func (l *Launcher) preferredMirror() string {
+	if l.Mirror != "" {
+		return l.Mirror
+	}
+
+	data, err := os.ReadFile(filepath.Join(l.DataDir, "preferences.json"))
+	if err == nil {
+		var prefs Preferences
+		if json.Unmarshal(data, &prefs) == nil && prefs.Mirror != "" {
+			return prefs.Mirror
 		}
 	}
 
-	if downloadURL == "" {
-		return fmt.Errorf("no download mirrors available")
+	if defaultMirror != "" {
+		return defaultMirror
+	}
+	return DefaultMirror
+}
+
+// mirrorOrder lists mirror names to try: preferred first, then DefaultMirror,
+// then the rest alphabetically so the order is stable
+func mirrorOrder(mirrors map[string]string, preferred string) []string {
+	var order []string
+	for _, name := range []string{preferred, DefaultMirror} {
+		if _, ok := mirrors[name]; ok && !slices.Contains(order, name) {
+			order = append(order, name)
+		}
 	}
 
-	l.log.Debug("Starting download", "url", downloadURL, "mirror", DefaultMirror)
+	var rest []string
+	for name := range mirrors {
+		if !slices.Contains(order, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(order, rest...)
+}
+
+// downloadFromMirrors tries each mirror in turn until a download succeeds
+// Returns the mirror used and the hash algorithm verified
+func (l *Launcher) downloadFromMirrors(info *AppImageInfo, onProgress DownloadProgress) (string, string, error) {
+	preferred := l.preferredMirror()
+	if _, ok := info.Mirrors[preferred]; !ok {
+		l.log.Warn("Preferred mirror not available", "mirror", preferred)
+	}
+
+	order := mirrorOrder(info.Mirrors, preferred)
+	if len(order) == 0 {
+		return "", "", fmt.Errorf("no download mirrors available")
+	}
+
+	var lastErr error
+	for _, name := range order {
+		algo, err := l.downloadAppImageWithProgress(info, name, info.Mirrors[name], onProgress)
+		if err == nil {
+			return name, algo, nil
+		}
+		l.log.Warn("Download from mirror failed", "mirror", name, "error", err)
+		lastErr = err
+	}
+
+	return "", "", fmt.Errorf("all %d mirrors failed, last error: %w", len(order), lastErr)
+}
+
+// downloadAppImageWithProgress downloads the AppImage from one mirror and verifies it against info.Hash
+// Returns the hash algorithm used, or "" if the hash couldn't be checked
+func (l *Launcher) downloadAppImageWithProgress(info *AppImageInfo, mirror, downloadURL string, onProgress DownloadProgress) (string, error) {
+	l.log.Debug("Starting download", "url", downloadURL, "mirror", mirror)
 
 	resp, err := http.Get(downloadURL)
 	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+		return "", fmt.Errorf("failed to download: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status: %d", resp.StatusCode)
+		return "", fmt.Errorf("download failed with status: %d", resp.StatusCode)
 	}
 
 	tmpPath := l.AppImagePath + ".tmp"
@@ -280,7 +463,7 @@ func (l *Launcher) downloadAppImageWithProgress(info *AppImageInfo, onProgress D
 
 	out, err := os.Create(tmpPath)
 	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+		return "", fmt.Errorf("failed to create temp file: %w", err)
 	}
 
 	var written int64
@@ -293,24 +476,47 @@ func (l *Launcher) downloadAppImageWithProgress(info *AppImageInfo, onProgress D
 	_ = out.Close()
 	if err != nil {
 		_ = os.Remove(tmpPath)
-		return fmt.Errorf("failed to write file: %w", err)
+		return "", fmt.Errorf("failed to write file: %w", err)
 	}
 
 	l.log.Debug("Download complete", "bytes_written", written)
 
+	// Verify before replacing the working AppImage
+	if err := verifyAppImage(tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	algo, err := verifyFileHash(tmpPath, info.Hash)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if algo == "" {
+		l.log.Warn("Unrecognized AppImage hash format, skipping verification", "hash", info.Hash)
+	} else {
+		l.log.Debug("AppImage hash verified", "algorithm", algo)
+	}
+
+	// Keep the working AppImage for --rollback
+	if err := l.keepPreviousAppImage(); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to keep previous AppImage: %w", err)
+	}
+
 	// Move temp file to final location
 	if err := os.Rename(tmpPath, l.AppImagePath); err != nil {
 		_ = os.Remove(tmpPath)
-		return fmt.Errorf("failed to move file: %w", err)
+		l.restorePreviousAppImage()
+		return "", fmt.Errorf("failed to move file: %w", err)
 	}
 
 	// Make executable
 	if err := os.Chmod(l.AppImagePath, 0755); err != nil {
-		return fmt.Errorf("failed to make executable: %w", err)
+		return "", fmt.Errorf("failed to make executable: %w", err)
 	}
 
 	l.log.Debug("AppImage ready", "path", l.AppImagePath)
-	return nil
+	return algo, nil
 }
 
 // copyWithProgress copies from src to dst while reporting progress
@@ -373,10 +579,16 @@ func (l *Launcher) CleanConfig() error {
 	content := string(data)
 
 	// Check for old server URL
-	if strings.Contains(content, "launcher.turtle-wow.org") {
-		l.log.Warn("Found old server URL in config, removing")
-		if err := os.Remove(prefsPath); err != nil {
-			return fmt.Errorf("failed to remove old config: %w", err)
+	if strings.Contains(content, oldServerHost) {
+		l.log.Warn("Found old server URL in config, backing up and resetting")
+		backupPath := fmt.Sprintf("%s.bak.%d", prefsPath, os.Getpid())
+		if err := os.WriteFile(backupPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to backup old config: %w", err)
+		}
+		l.log.Info("Old preferences backed up", "path", backupPath)
+
+		if err := l.reseedPreferences(data); err != nil {
+			return fmt.Errorf("failed to reset old config: %w", err)
 		}
 		return nil
 	}
@@ -417,6 +629,46 @@ func (l *Launcher) CleanConfig() error {
 	return nil
 }
 
+// defaultPreferences returns what a fresh preferences.json contains
+func (l *Launcher) defaultPreferences() Preferences {
+	return Preferences{
+		Language:        "en",
+		LinuxLaunchArgs: defaultLaunchArgs,
+		Mirror:          DefaultMirror,
+		ClientDir:       l.GameDir + "/",
+		SafeDir:         l.GameDir + "/",
+	}
+}
+
+// reseedPreferences replaces preferences.json with the defaults, carrying over
+// the user's language, launch args, mirror and turtlectl settings from old
+// unless they point at the old server
+func (l *Launcher) reseedPreferences(old []byte) error {
+	defaults, err := json.Marshal(l.defaultPreferences())
+	if err != nil {
+		return err
+	}
+	var prefs map[string]interface{}
+	if err := json.Unmarshal(defaults, &prefs); err != nil {
+		return err
+	}
+
+	var oldPrefs map[string]interface{}
+	if err := json.Unmarshal(old, &oldPrefs); err != nil {
+		l.log.Warn("Old preferences unreadable, starting from defaults", "error", err)
+	}
+	for key, value := range oldPrefs {
+		keep := key == "language" || key == "linuxLaunchArgs" || key == "mirror" || strings.HasPrefix(key, "turtlectl")
+		if str, ok := value.(string); !keep || ok && strings.Contains(str, oldServerHost) {
+			continue
+		}
+		prefs[key] = value
+	}
+
+	l.log.Debug("Preferences reseeded", "kept", len(prefs))
+	return l.writePrefs(prefs)
+}
+
 func (l *Launcher) InitPreferences() error {
 	prefsPath := filepath.Join(l.DataDir, "preferences.json")
 	l.log.Debug("Initializing preferences", "path", prefsPath)
@@ -424,15 +676,7 @@ func (l *Launcher) InitPreferences() error {
 	if _, err := os.Stat(prefsPath); os.IsNotExist(err) {
 		l.log.Info("Creating default preferences")
 
-		prefs := Preferences{
-			Language:        "en",
-			LinuxLaunchArgs: "wine $WoW.exe$",
-			Mirror:          "bunny",
-			ClientDir:       l.GameDir + "/",
-			SafeDir:         l.GameDir + "/",
-		}
-
-		data, err := json.MarshalIndent(prefs, "", "    ")
+		data, err := json.MarshalIndent(l.defaultPreferences(), "", "    ")
 		if err != nil {
 			return fmt.Errorf("failed to marshal preferences: %w", err)
 		}
@@ -477,182 +721,114 @@ func (l *Launcher) InitPreferences() error {
 func (l *Launcher) Launch(args []string) error {
 	l.log.Info("Launching Turtle WoW",
 		"appimage", l.AppImagePath,
-		"workdir", l.GameDir,
+		"workdir", l.workDir(),
 		"args", args,
 	)
 
-	// Change to game directory
-	if err := os.Chdir(l.GameDir); err != nil {
-		return fmt.Errorf("failed to change to game directory: %w", err)
+	if err := l.checkLaunchable(); err != nil {
+		return err
 	}
 
-	l.log.Debug("Changed to game directory", "path", l.GameDir)
-
-	// Build command args
-	cmdArgs := append([]string{l.AppImagePath}, args...)
+	// Build command args, prefixed by gamemoderun when enabled
+	binary, cmdArgs := l.launchCommand(l.withFUSEFallback(args))
 
 	l.log.Debug("Executing AppImage", "command", cmdArgs)
 
-	// Use syscall.Exec to replace current process
-	return syscall.Exec(l.AppImagePath, cmdArgs, os.Environ())
-}
-
-// ExtractIcon extracts the TurtleWoW.png icon from the AppImage
-func (l *Launcher) ExtractIcon() (string, error) {
-	iconPath := filepath.Join(l.IconDir, "turtle-wow.png")
-
-	// Check if icon already exists
-	if _, err := os.Stat(iconPath); err == nil {
-		l.log.Debug("Icon already exists", "path", iconPath)
-		return iconPath, nil
-	}
-
-	// Check if AppImage exists
-	if _, err := os.Stat(l.AppImagePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("AppImage not found at %s", l.AppImagePath)
+	// Hooks need us to outlive the game, otherwise replace the process
+	pre, post := l.findHooks(preLaunchDir), l.findHooks(postLaunchDir)
+	if len(pre) > 0 || len(post) > 0 {
+		l.log.Debug("Launching with hooks", "pre", len(pre), "post", len(post))
+		return l.launchWithHooks(binary, cmdArgs, pre, post)
 	}
 
-	l.log.Debug("Extracting icon from AppImage", "appimage", l.AppImagePath)
-
-	// Create temp directory for extraction
-	tmpDir, err := os.MkdirTemp("", "turtle-wow-extract-")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp dir: %w", err)
-	}
-	defer func() { _ = os.RemoveAll(tmpDir) }()
-
-	// Extract only the icon file using --appimage-extract with pattern
-	cmd := exec.Command(l.AppImagePath, "--appimage-extract", "TurtleWoW.png")
-	cmd.Dir = tmpDir
-	cmd.Stdout = io.Discard
-	cmd.Stderr = io.Discard
-
-	if err := cmd.Run(); err != nil {
-		l.log.Debug("Pattern extraction failed, trying full extraction", "error", err)
-		// Fallback: extract everything and find the icon
-		cmd = exec.Command(l.AppImagePath, "--appimage-extract")
-		cmd.Dir = tmpDir
-		cmd.Stdout = io.Discard
-		cmd.Stderr = io.Discard
-		if err := cmd.Run(); err != nil {
-			return "", fmt.Errorf("failed to extract AppImage: %w", err)
+	// Change to game directory, the exec'd launcher inherits it
+	var prevDir string
+	if !l.NoChdir {
+		prevDir, _ = os.Getwd()
+		if err := os.Chdir(l.GameDir); err != nil {
+			return fmt.Errorf("failed to change to game directory: %w", err)
 		}
+		l.log.Debug("Changed to game directory", "path", l.GameDir)
 	}
 
-	// Find the extracted icon
-	extractedIcon := filepath.Join(tmpDir, "squashfs-root", "TurtleWoW.png")
-	if _, err := os.Stat(extractedIcon); os.IsNotExist(err) {
-		return "", fmt.Errorf("icon not found in AppImage")
+	// Use syscall.Exec to replace current process, only returns on failure
+	err := syscall.Exec(binary, cmdArgs, l.launchEnv())
+	if prevDir != "" {
+		_ = os.Chdir(prevDir)
 	}
+	return fmt.Errorf("failed to execute %s: %w", binary, err)
+}
 
-	// Ensure icon directory exists
-	if err := os.MkdirAll(l.IconDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create icon dir: %w", err)
+// workDir is the directory the game runs in, empty keeps the current one
+func (l *Launcher) workDir() string {
+	if l.NoChdir {
+		return ""
 	}
+	return l.GameDir
+}
 
-	// Copy icon to final location
-	src, err := os.Open(extractedIcon)
-	if err != nil {
-		return "", fmt.Errorf("failed to open extracted icon: %w", err)
+// checkLaunchable makes sure the game directory and an executable AppImage
+// exist, fixing the executable bit lost by e.g. a partial download
+func (l *Launcher) checkLaunchable() error {
+	if !l.NoChdir {
+		if info, err := os.Stat(l.GameDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("%w: %s (run turtlectl install first)", ErrGameDirMissing, l.GameDir)
+		}
 	}
-	defer func() { _ = src.Close() }()
 
-	dst, err := os.Create(iconPath)
+	info, err := os.Stat(l.AppImagePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create icon file: %w", err)
+		return fmt.Errorf("%w: %s (run turtlectl install first)", ErrAppImageMissing, l.AppImagePath)
 	}
-	defer func() { _ = dst.Close() }()
-
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("failed to copy icon: %w", err)
+	if info.Mode()&0111 == 0 {
+		l.log.Warn("AppImage not executable, fixing permissions", "path", l.AppImagePath)
+		if err := os.Chmod(l.AppImagePath, 0755); err != nil {
+			return fmt.Errorf("failed to make AppImage executable: %w", err)
+		}
 	}
-
-	l.log.Info("Icon extracted from AppImage", "path", iconPath)
-	return iconPath, nil
+	return nil
 }
 
-func (l *Launcher) InstallDesktop() error {
-	l.log.Info("Installing desktop integration")
-
-	// Create directories
-	if err := os.MkdirAll(l.DesktopDir, 0755); err != nil {
-		return fmt.Errorf("failed to create desktop dir: %w", err)
-	}
-	if err := os.MkdirAll(l.IconDir, 0755); err != nil {
-		return fmt.Errorf("failed to create icon dir: %w", err)
+// launchEnv returns the environment for the AppImage
+// Named profiles point XDG_DATA_HOME at the profile so the launcher's own
+// config lives in DataDir
+func (l *Launcher) launchEnv() []string {
+	env := os.Environ()
+	if l.Profile == DefaultProfile {
+		return env
 	}
 
-	// Extract icon from AppImage
-	iconPath, err := l.ExtractIcon()
-	if err != nil {
-		l.log.Warn("Failed to extract icon from AppImage, using fallback", "error", err)
-		// Fallback: download from web
-		iconPath = filepath.Join(l.IconDir, "turtle-wow.png")
-		if _, statErr := os.Stat(iconPath); os.IsNotExist(statErr) {
-			l.log.Debug("Downloading fallback icon")
-			resp, dlErr := http.Get("https://turtle-wow.org/favicon.ico")
-			if dlErr == nil {
-				defer func() { _ = resp.Body.Close() }()
-				if resp.StatusCode == http.StatusOK {
-					out, createErr := os.Create(iconPath)
-					if createErr == nil {
-						_, _ = io.Copy(out, resp.Body)
-						_ = out.Close()
-						l.log.Debug("Fallback icon downloaded", "path", iconPath)
-					}
-				}
-			}
+	// Drop any existing value, getenv returns the first match
+	filtered := env[:0]
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, "XDG_DATA_HOME=") {
+			filtered = append(filtered, kv)
 		}
 	}
-
-	// Create desktop file
-	desktopPath := filepath.Join(l.DesktopDir, "turtle-wow.desktop")
-	desktopContent := fmt.Sprintf(`[Desktop Entry]
-Name=Turtle WoW
-Comment=Turtle WoW (via turtlectl)
-Exec=%s launch
-Icon=%s
-Terminal=false
-Type=Application
-Categories=Game;
-Keywords=wow;warcraft;mmo;turtle;
-`, l.ScriptPath, iconPath)
-
-	l.log.Debug("Writing desktop file", "path", desktopPath)
-	if err := os.WriteFile(desktopPath, []byte(desktopContent), 0644); err != nil {
-		return fmt.Errorf("failed to write desktop file: %w", err)
-	}
-
-	// Update desktop database
-	l.log.Debug("Updating desktop database")
-	_ = exec.Command("update-desktop-database", l.DesktopDir).Run()
-
-	l.log.Info("Desktop file installed", "path", desktopPath)
-	return nil
+	return append(filtered, "XDG_DATA_HOME="+filepath.Dir(l.DataDir))
 }
 
-func (l *Launcher) UninstallDesktop() error {
-	l.log.Info("Removing desktop integration")
-
-	desktopPath := filepath.Join(l.DesktopDir, "turtle-wow.desktop")
-	iconPath := filepath.Join(l.IconDir, "turtle-wow.png")
-
-	if err := os.Remove(desktopPath); err != nil && !os.IsNotExist(err) {
-		l.log.Warn("Failed to remove desktop file", "error", err)
-	} else {
-		l.log.Debug("Removed desktop file", "path", desktopPath)
-	}
-
-	if err := os.Remove(iconPath); err != nil && !os.IsNotExist(err) {
-		l.log.Warn("Failed to remove icon", "error", err)
-	} else {
-		l.log.Debug("Removed icon", "path", iconPath)
+// RemoveAppImage deletes the AppImage, the one kept for rollback and their
+// cached metadata. Returns the files removed
+func (l *Launcher) RemoveAppImage() ([]string, error) {
+	var removed []string
+	for _, path := range []string{
+		l.AppImagePath,
+		l.appImageInfoPath(),
+		l.prevAppImagePath(),
+		l.prevAppImageInfoPath(),
+		l.rollbackPinPath(),
+	} {
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		l.log.Debug("Removed", "path", path)
+		removed = append(removed, path)
 	}
-
-	_ = exec.Command("update-desktop-database", l.DesktopDir).Run()
-
-	l.log.Info("Desktop integration removed")
-	return nil
+	return removed, nil
 }
 
 func (l *Launcher) Clean(includeGameFiles bool) error {
@@ -668,6 +844,12 @@ func (l *Launcher) Clean(includeGameFiles bool) error {
 	}
 	l.log.Debug("Removed data directory", "path", l.DataDir)
 
+	// Remove state directory (addon store, logs)
+	if err := os.RemoveAll(l.StateDir); err != nil {
+		return fmt.Errorf("failed to remove state directory: %w", err)
+	}
+	l.log.Debug("Removed state directory", "path", l.StateDir)
+
 	// Remove cache directory (AppImage, WebKit cache, etc.)
 	if err := os.RemoveAll(l.CacheDir); err != nil {
 		return fmt.Errorf("failed to remove cache directory: %w", err)
@@ -675,22 +857,7 @@ func (l *Launcher) Clean(includeGameFiles bool) error {
 	l.log.Debug("Removed cache directory", "path", l.CacheDir)
 
 	// Remove desktop integration
-	desktopFile := filepath.Join(l.DesktopDir, "turtle-wow.desktop")
-	if err := os.Remove(desktopFile); err != nil && !os.IsNotExist(err) {
-		l.log.Warn("Failed to remove desktop file", "error", err)
-	} else {
-		l.log.Debug("Removed desktop file", "path", desktopFile)
-	}
-
-	iconFile := filepath.Join(l.IconDir, "turtle-wow.png")
-	if err := os.Remove(iconFile); err != nil && !os.IsNotExist(err) {
-		l.log.Warn("Failed to remove icon", "error", err)
-	} else {
-		l.log.Debug("Removed icon", "path", iconFile)
-	}
-
-	// Update desktop database
-	_ = exec.Command("update-desktop-database", l.DesktopDir).Run()
+	l.removeDesktopFiles()
 
 	// Optionally remove game files
 	if includeGameFiles {
@@ -712,12 +879,14 @@ func (l *Launcher) Clean(includeGameFiles bool) error {
 
 		l.log.Info("Full purge complete",
 			"removed_data", l.DataDir,
+			"removed_state", l.StateDir,
 			"removed_cache", l.CacheDir,
 			"removed_game", l.GameDir,
 		)
 	} else {
 		l.log.Info("Clean complete",
 			"removed_data", l.DataDir,
+			"removed_state", l.StateDir,
 			"removed_cache", l.CacheDir,
 		)
 		l.log.Info("Game files preserved", "game_dir", l.GameDir)
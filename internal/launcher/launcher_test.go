@@ -0,0 +1,98 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanConfigBacksUpOldServerPreferences(t *testing.T) {
+	old := `{
+    "language": "de",
+    "linuxLaunchArgs": "wine64 $WoW.exe$",
+    "mirror": "bunny",
+    "apiUrl": "https://launcher.turtle-wow.org/api",
+    "turtlectlWine": "/usr/bin/wine64"
+}`
+	l := newTestLauncher(t, old)
+	l.GameDir = "/games/turtle"
+
+	if err := l.CleanConfig(); err != nil {
+		t.Fatalf("CleanConfig() error: %v", err)
+	}
+
+	backup, err := os.ReadFile(fmt.Sprintf("%s.bak.%d", l.PreferencesPath(), os.Getpid()))
+	if err != nil {
+		t.Fatalf("backup not written: %v", err)
+	}
+	if string(backup) != old {
+		t.Errorf("backup differs from the original:\n%s", backup)
+	}
+
+	prefs, err := l.readPrefs()
+	if err != nil {
+		t.Fatalf("preferences not reseeded: %v", err)
+	}
+	want := map[string]interface{}{
+		"language":        "de",
+		"linuxLaunchArgs": "wine64 $WoW.exe$",
+		"mirror":          "bunny",
+		"clientDir":       "/games/turtle/",
+		"turtlectlWine":   "/usr/bin/wine64",
+	}
+	for key, value := range want {
+		if prefs[key] != value {
+			t.Errorf("%s = %v, want %v", key, prefs[key], value)
+		}
+	}
+	if _, ok := prefs["apiUrl"]; ok {
+		t.Errorf("old server URL carried over: %v", prefs)
+	}
+}
+
+func TestCheckLaunchable(t *testing.T) {
+	l := newTestLauncher(t, "")
+	l.GameDir = filepath.Join(t.TempDir(), "missing")
+	l.AppImagePath = filepath.Join(t.TempDir(), "TurtleWoW.AppImage")
+
+	if err := l.checkLaunchable(); !errors.Is(err, ErrGameDirMissing) {
+		t.Fatalf("expected ErrGameDirMissing, got %v", err)
+	}
+
+	// --no-cd doesn't need the game directory
+	l.NoChdir = true
+	if err := l.checkLaunchable(); !errors.Is(err, ErrAppImageMissing) {
+		t.Fatalf("expected ErrAppImageMissing, got %v", err)
+	}
+
+	// A partial download can leave the AppImage without its executable bit
+	if err := os.WriteFile(l.AppImagePath, []byte("appimage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.checkLaunchable(); err != nil {
+		t.Fatalf("checkLaunchable() error: %v", err)
+	}
+	if info, err := os.Stat(l.AppImagePath); err != nil || info.Mode()&0111 == 0 {
+		t.Errorf("AppImage not made executable: %v", err)
+	}
+}
+
+func TestWithFUSEFallback(t *testing.T) {
+	l := newTestLauncher(t, "")
+
+	args := []string{extractAndRunArg, "--foo"}
+	if got := l.withFUSEFallback(args); len(got) != 2 {
+		t.Errorf("bypass added twice: %v", got)
+	}
+
+	got := l.withFUSEFallback([]string{"--foo"})
+	if _, problem := fuseProblem(); problem != "" {
+		if len(got) != 2 || got[0] != extractAndRunArg {
+			t.Errorf("expected %s without FUSE, got %v", extractAndRunArg, got)
+		}
+	} else if len(got) != 1 {
+		t.Errorf("args changed with FUSE available: %v", got)
+	}
+}
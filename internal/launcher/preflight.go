@@ -0,0 +1,180 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CheckStatus is the outcome of a preflight check
+type CheckStatus int
+
+const (
+	CheckOK CheckStatus = iota
+	CheckWarn
+	CheckFail
+)
+
+// extractAndRunArg makes the AppImage runtime extract itself instead of mounting with FUSE
+const extractAndRunArg = "--appimage-extract-and-run"
+
+// Check is a single preflight result, Hint tells the user how to fix it
+type Check struct {
+	Name   string
+	Status CheckStatus
+	Detail string
+	Hint   string
+}
+
+// Preflight checks the runtime dependencies the launcher needs
+// args are the AppImage arguments, used to tell whether FUSE is bypassed
+func (l *Launcher) Preflight(args []string) []Check {
+	return []Check{
+		l.checkAppImage(),
+		checkFUSE(args),
+		l.checkWine(),
+		checkDesktopDatabase(),
+	}
+}
+
+// PreflightFailed reports whether any check would stop the game from starting
+func PreflightFailed(checks []Check) bool {
+	for _, c := range checks {
+		if c.Status == CheckFail {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Launcher) checkAppImage() Check {
+	c := Check{Name: "AppImage"}
+	info, err := os.Stat(l.AppImagePath)
+	switch {
+	case err != nil:
+		c.Status = CheckWarn
+		c.Detail = "not downloaded yet"
+		c.Hint = "Fix with: turtlectl install"
+	case info.Mode()&0111 == 0:
+		// Launch restores the executable bit itself
+		c.Status = CheckWarn
+		c.Detail = "not executable: " + l.AppImagePath
+		c.Hint = "Fixed on launch, or fix with: chmod +x " + l.AppImagePath
+	default:
+		c.Detail = l.AppImagePath
+	}
+	return c
+}
+
+// checkFUSE warns without FUSE, Launch then falls back to extracting the
+// AppImage, which works but starts slower
+func checkFUSE(args []string) Check {
+	c := Check{Name: "FUSE"}
+	if how, ok := fuseBypassed(args); ok {
+		c.Detail = "bypassed with " + how
+		return c
+	}
+
+	path, problem := fuseProblem()
+	if problem != "" {
+		c.Status = CheckWarn
+		c.Detail = problem + ", the AppImage is extracted on each launch instead"
+		c.Hint = "Install fuse (libfuse2 or fuse3) for your distribution for faster starts"
+		return c
+	}
+	c.Detail = path
+	return c
+}
+
+// fuseBypassed reports whether args or the environment already make the
+// AppImage skip FUSE, and how
+func fuseBypassed(args []string) (string, bool) {
+	for _, arg := range args {
+		if arg == extractAndRunArg {
+			return extractAndRunArg, true
+		}
+	}
+	if os.Getenv("APPIMAGE_EXTRACT_AND_RUN") == "1" {
+		return "APPIMAGE_EXTRACT_AND_RUN=1", true
+	}
+	return "", false
+}
+
+// fuseProblem returns the fusermount binary, or why the AppImage can't mount
+// itself with FUSE
+func fuseProblem() (string, string) {
+	if _, err := os.Stat("/dev/fuse"); err != nil {
+		return "", "/dev/fuse not found"
+	}
+	for _, bin := range []string{"fusermount3", "fusermount"} {
+		if path, err := exec.LookPath(bin); err == nil {
+			return path, ""
+		}
+	}
+	return "", "fusermount not found in PATH"
+}
+
+// withFUSEFallback makes the AppImage extract itself and run when FUSE is
+// unavailable, e.g. in containers and on minimal distros, or sandboxed
+func (l *Launcher) withFUSEFallback(args []string) []string {
+	if _, ok := fuseBypassed(args); ok {
+		return args
+	}
+	_, problem := fuseProblem()
+	if len(l.sandbox) > 0 {
+		problem = "no FUSE mounts in the sandbox"
+	}
+	if problem == "" {
+		return args
+	}
+	l.log.Info("FUSE unavailable, running the AppImage with "+extractAndRunArg, "reason", problem)
+	return append([]string{extractAndRunArg}, args...)
+}
+
+// checkWine makes sure the runner in linuxLaunchArgs exists
+// Without preferences.json the launcher falls back to defaultLaunchArgs
+func (l *Launcher) checkWine() Check {
+	c := Check{Name: "Wine"}
+
+	launchArgs := defaultLaunchArgs
+	if prefs, err := l.readPrefs(); err == nil {
+		if s, ok := prefs["linuxLaunchArgs"].(string); ok && s != "" {
+			launchArgs = s
+		}
+	}
+
+	fields := strings.Fields(launchArgs)
+	lower := strings.ToLower(launchArgs)
+	if len(fields) == 0 || !strings.Contains(lower, "wine") && !strings.Contains(lower, "proton") {
+		c.Detail = "not used by launch args"
+		return c
+	}
+
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		// A bare "wine" may still be found inside the AppImage, a saved path can't
+		c.Status = CheckWarn
+		if filepath.IsAbs(fields[0]) {
+			c.Status = CheckFail
+		}
+		c.Detail = fields[0] + " not found"
+		c.Hint = "Install wine for your distribution, or pick a runner with: turtlectl launch --wine <path> / --proton <dir>"
+		return c
+	}
+	c.Detail = path
+	return c
+}
+
+func checkDesktopDatabase() Check {
+	c := Check{Name: "Desktop database"}
+	path, err := exec.LookPath("update-desktop-database")
+	if err != nil {
+		c.Status = CheckWarn
+		c.Detail = "update-desktop-database not found, the menu entry may not show up until next login"
+		c.Hint = "Install desktop-file-utils for your distribution"
+		return c
+	}
+	c.Detail = path
+	return c
+}
@@ -0,0 +1,108 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile uses the original, un-namespaced paths
+const DefaultProfile = "default"
+
+// profilesDirName holds one XDG-style data home per named profile
+const profilesDirName = "turtle-wow-profiles"
+
+var ErrInvalidProfile = errors.New("invalid profile name (use letters, digits, '-' or '_')")
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// activeProfile is set from --profile and wins over TURTLECTL_PROFILE
+var activeProfile string
+
+// SetProfile selects the profile for launchers created afterwards
+// An empty name falls back to TURTLECTL_PROFILE
+func SetProfile(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("TURTLECTL_PROFILE"))
+	}
+	if name != "" && name != DefaultProfile && !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %s", ErrInvalidProfile, name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the selected profile: SetProfile > TURTLECTL_PROFILE > default
+func ActiveProfile() string {
+	name := activeProfile
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("TURTLECTL_PROFILE"))
+	}
+	if name == "" || !profileNamePattern.MatchString(name) {
+		return DefaultProfile
+	}
+	return name
+}
+
+// dataHome returns $XDG_DATA_HOME or ~/.local/share
+func dataHome(homeDir string) string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(homeDir, ".local", "share")
+}
+
+// stateHome returns $XDG_STATE_HOME or ~/.local/state
+func stateHome(homeDir string) string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return dir
+	}
+	return filepath.Join(homeDir, ".local", "state")
+}
+
+// profileDataHome returns the data home for a profile
+// Named profiles get their own data home so the AppImage, which keeps its
+// config under $XDG_DATA_HOME/turtle-wow, sees separate settings too
+func profileDataHome(base, profile string) string {
+	if profile == DefaultProfile {
+		return base
+	}
+	return filepath.Join(base, profilesDirName, profile)
+}
+
+// ListProfiles returns the default profile followed by named profiles on disk
+func ListProfiles() ([]string, error) {
+	homeDir, _ := os.UserHomeDir()
+	entries, err := os.ReadDir(filepath.Join(dataHome(homeDir), profilesDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && profileNamePattern.MatchString(entry.Name()) && entry.Name() != DefaultProfile {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// ProfileDataDir returns the data directory used by a profile
+func ProfileDataDir(profile string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(profileDataHome(dataHome(homeDir), profile), "turtle-wow")
+}
+
+// ProfileStateDir returns the state directory used by a profile, holding
+// its addon store. Named profiles are namespaced like their data dir
+func ProfileStateDir(profile string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(profileDataHome(stateHome(homeDir), profile), "turtle-wow")
+}
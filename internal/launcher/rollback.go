@@ -0,0 +1,128 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var ErrNoPreviousAppImage = errors.New("no previous AppImage to roll back to")
+
+// RollbackResult describes the AppImages after a rollback
+type RollbackResult struct {
+	Version  []string // Tags of the AppImage now in use
+	Replaced []string // Tags of the AppImage rolled back from, kept as .prev
+}
+
+// prevAppImagePath is where the AppImage replaced by the last update is kept
+func (l *Launcher) prevAppImagePath() string {
+	return l.AppImagePath + ".prev"
+}
+
+// prevAppImageInfoPath caches the metadata matching prevAppImagePath
+func (l *Launcher) prevAppImageInfoPath() string {
+	return filepath.Join(l.CacheDir, "appimage-info.prev.json")
+}
+
+// rollbackPinPath marks a rollback so launch doesn't update straight back
+func (l *Launcher) rollbackPinPath() string {
+	return filepath.Join(l.CacheDir, "appimage-rollback")
+}
+
+// keepPreviousAppImage moves the current AppImage and its metadata to .prev,
+// replacing any older one so only one previous version takes disk space
+func (l *Launcher) keepPreviousAppImage() error {
+	if _, err := os.Stat(l.AppImagePath); err != nil {
+		return nil
+	}
+	if err := os.Rename(l.AppImagePath, l.prevAppImagePath()); err != nil {
+		return err
+	}
+	if err := os.Rename(l.appImageInfoPath(), l.prevAppImageInfoPath()); err != nil {
+		// Unknown version is better than one describing another file
+		_ = os.Remove(l.prevAppImageInfoPath())
+	}
+	l.log.Debug("Previous AppImage kept", "path", l.prevAppImagePath())
+	return nil
+}
+
+// restorePreviousAppImage undoes keepPreviousAppImage after a failed replace
+func (l *Launcher) restorePreviousAppImage() {
+	if err := os.Rename(l.prevAppImagePath(), l.AppImagePath); err == nil {
+		_ = os.Rename(l.prevAppImageInfoPath(), l.appImageInfoPath())
+	}
+}
+
+// CachedPreviousAppImageInfo returns the metadata of the AppImage kept for rollback
+func (l *Launcher) CachedPreviousAppImageInfo() (*AppImageInfo, error) {
+	return readAppImageInfo(l.prevAppImageInfoPath())
+}
+
+// HasPreviousAppImage reports whether there is an AppImage to roll back to
+func (l *Launcher) HasPreviousAppImage() bool {
+	_, err := os.Stat(l.prevAppImagePath())
+	return err == nil
+}
+
+// RolledBack reports whether updates are held back after a rollback
+func (l *Launcher) RolledBack() bool {
+	_, err := os.Stat(l.rollbackPinPath())
+	return err == nil
+}
+
+// ClearRollback lets launch update the AppImage again
+func (l *Launcher) ClearRollback() {
+	_ = os.Remove(l.rollbackPinPath())
+}
+
+// Rollback swaps the current AppImage with the previous one, so rolling back
+// twice returns to the newer version. Launch stops updating until
+// ClearRollback, otherwise it would download the regressed version again
+func (l *Launcher) Rollback() (*RollbackResult, error) {
+	if !l.HasPreviousAppImage() {
+		return nil, ErrNoPreviousAppImage
+	}
+
+	result := &RollbackResult{}
+	if info, err := l.CachedAppImageInfo(); err == nil {
+		result.Replaced = info.Tags
+	}
+	if info, err := l.CachedPreviousAppImageInfo(); err == nil {
+		result.Version = info.Tags
+	}
+
+	l.log.Info("Rolling back AppImage", "from", result.Replaced, "to", result.Version)
+
+	if err := swapFiles(l.AppImagePath, l.prevAppImagePath()); err != nil {
+		return nil, fmt.Errorf("failed to swap AppImages: %w", err)
+	}
+	if err := swapFiles(l.appImageInfoPath(), l.prevAppImageInfoPath()); err != nil {
+		l.log.Debug("Failed to swap AppImage info", "error", err)
+	}
+
+	if err := os.Chmod(l.AppImagePath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to make executable: %w", err)
+	}
+	if err := os.WriteFile(l.rollbackPinPath(), nil, 0644); err != nil {
+		l.log.Warn("Failed to hold back updates after rollback", "error", err)
+	}
+
+	return result, nil
+}
+
+// swapFiles exchanges two paths, either of which may be missing
+func swapFiles(a, b string) error {
+	tmp := a + ".swap"
+	if err := os.Rename(a, tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(b, a); err != nil && !os.IsNotExist(err) {
+		_ = os.Rename(tmp, a)
+		return err
+	}
+	if err := os.Rename(tmp, b); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
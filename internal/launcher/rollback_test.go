@@ -0,0 +1,101 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRollbackSwapsAppImages(t *testing.T) {
+	l := newTestLauncher(t, "")
+	l.CacheDir = t.TempDir()
+	l.AppImagePath = filepath.Join(l.CacheDir, "TurtleWoW.AppImage")
+
+	if _, err := l.Rollback(); !errors.Is(err, ErrNoPreviousAppImage) {
+		t.Fatalf("expected ErrNoPreviousAppImage, got %v", err)
+	}
+
+	// Simulate two updates: old, then new replacing it
+	writeAppImage(t, l, "old", "1.0")
+	if err := l.keepPreviousAppImage(); err != nil {
+		t.Fatal(err)
+	}
+	writeAppImage(t, l, "new", "2.0")
+
+	result, err := l.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+	if len(result.Version) != 1 || result.Version[0] != "1.0" || len(result.Replaced) != 1 || result.Replaced[0] != "2.0" {
+		t.Errorf("unexpected versions: %+v", result)
+	}
+	assertFile(t, l.AppImagePath, "old")
+	assertFile(t, l.prevAppImagePath(), "new")
+	if info, err := l.CachedAppImageInfo(); err != nil || info.Tags[0] != "1.0" {
+		t.Errorf("current info not swapped: %+v, %v", info, err)
+	}
+	if stat, err := os.Stat(l.AppImagePath); err != nil || stat.Mode()&0111 == 0 {
+		t.Errorf("rolled back AppImage not executable: %v", err)
+	}
+	if !l.RolledBack() {
+		t.Error("expected updates to be held back after rollback")
+	}
+
+	// Rolling back again returns to the newer version
+	if _, err := l.Rollback(); err != nil {
+		t.Fatalf("second Rollback() error: %v", err)
+	}
+	assertFile(t, l.AppImagePath, "new")
+
+	l.ClearRollback()
+	if l.RolledBack() {
+		t.Error("ClearRollback() left updates held back")
+	}
+}
+
+func writeAppImage(t *testing.T, l *Launcher, content, version string) {
+	t.Helper()
+	if err := os.WriteFile(l.AppImagePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	l.saveAppImageInfo(&AppImageInfo{Size: int64(len(content)), Tags: []string{version}})
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != want {
+		t.Errorf("%s = %q, %v, want %q", filepath.Base(path), data, err, want)
+	}
+}
+
+func TestRemoveAppImage(t *testing.T) {
+	l := newTestLauncher(t, "")
+	l.CacheDir = t.TempDir()
+	l.AppImagePath = filepath.Join(l.CacheDir, "TurtleWoW.AppImage")
+
+	writeAppImage(t, l, "old", "1.0")
+	if err := l.keepPreviousAppImage(); err != nil {
+		t.Fatal(err)
+	}
+	writeAppImage(t, l, "new", "2.0")
+	keep := filepath.Join(l.CacheDir, "registry.json")
+	if err := os.WriteFile(keep, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := l.RemoveAppImage()
+	if err != nil {
+		t.Fatalf("RemoveAppImage() error: %v", err)
+	}
+	if len(removed) != 4 {
+		t.Errorf("expected the AppImages and their info removed, got %v", removed)
+	}
+	if l.HasPreviousAppImage() {
+		t.Error("previous AppImage kept")
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Errorf("unrelated cache file removed: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/bnema/turtlectl/internal/settings"
+)
+
+const (
+	// defaultLaunchArgs is what the bundled launcher uses out of the box
+	defaultLaunchArgs = "wine $WoW.exe$"
+
+	// RunnerDefault clears a saved wine/proton choice
+	RunnerDefault = "default"
+)
+
+var ErrRunnerNotFound = errors.New("wine/proton runner not found")
+
+// ConfigureRunner applies the wine or proton choice to preferences.json and the
+// environment. Wine and Proton (from flags) are saved so later launches, e.g.
+// from the desktop entry, keep using them. Without any choice the bundled
+// launcher's own linuxLaunchArgs are left alone
+func (l *Launcher) ConfigureRunner() error {
+	prefs, err := l.readPrefs()
+	if os.IsNotExist(err) && l.Wine == "" && l.Proton == "" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	wine, _ := prefs["turtlectlWine"].(string)
+	proton, _ := prefs["turtlectlProton"].(string)
+	reset := false
+
+	switch l.Wine {
+	case "":
+	case RunnerDefault:
+		wine, reset = "", true
+	default:
+		wine, proton = l.Wine, ""
+	}
+	switch l.Proton {
+	case "":
+	case RunnerDefault:
+		proton, reset = "", true
+	default:
+		proton, wine = l.Proton, ""
+	}
+
+	launchArgs := ""
+	switch {
+	case wine != "":
+		path, err := exec.LookPath(settings.ExpandHome(wine))
+		if err != nil {
+			return fmt.Errorf("%w: wine %s", ErrRunnerNotFound, wine)
+		}
+		wine = path
+		launchArgs = path + " $WoW.exe$"
+		_ = os.Setenv("WINE", path)
+
+	case proton != "":
+		dir, err := filepath.Abs(settings.ExpandHome(proton))
+		if err != nil {
+			return err
+		}
+		script := filepath.Join(dir, "proton")
+		if info, err := os.Stat(script); err != nil || info.Mode()&0111 == 0 {
+			return fmt.Errorf("%w: no executable proton script in %s", ErrRunnerNotFound, dir)
+		}
+		proton = dir
+		launchArgs = script + " run $WoW.exe$"
+		if err := l.setupProtonEnv(dir); err != nil {
+			return err
+		}
+
+	case reset:
+		launchArgs = defaultLaunchArgs
+	}
+
+	setOrDelete(prefs, "turtlectlWine", wine)
+	setOrDelete(prefs, "turtlectlProton", proton)
+	if launchArgs != "" {
+		prefs["linuxLaunchArgs"] = launchArgs
+		l.log.Info("Using launch command", "args", launchArgs)
+	}
+
+	return l.writePrefs(prefs)
+}
+
+// readPrefs reads preferences.json as a map so unknown launcher keys survive
+func (l *Launcher) readPrefs() (map[string]interface{}, error) {
+	data, err := os.ReadFile(l.PreferencesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var prefs map[string]interface{}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return nil, fmt.Errorf("failed to parse preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// writePrefs writes preferences.json in the launcher's indentation
+func (l *Launcher) writePrefs(prefs map[string]interface{}) error {
+	data, err := json.MarshalIndent(prefs, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.PreferencesPath(), data, 0644)
+}
+
+// setupProtonEnv sets the Steam compat variables proton needs outside Steam
+func (l *Launcher) setupProtonEnv(dir string) error {
+	compatData := filepath.Join(l.DataDir, "proton-prefix")
+	if err := os.MkdirAll(compatData, 0755); err != nil {
+		return fmt.Errorf("failed to create proton prefix: %w", err)
+	}
+
+	steamDir := os.Getenv("STEAM_COMPAT_CLIENT_INSTALL_PATH")
+	if steamDir == "" {
+		homeDir, _ := os.UserHomeDir()
+		steamDir = filepath.Join(homeDir, ".steam", "steam")
+	}
+
+	_ = os.Setenv("PROTON", dir)
+	_ = os.Setenv("STEAM_COMPAT_DATA_PATH", compatData)
+	_ = os.Setenv("STEAM_COMPAT_CLIENT_INSTALL_PATH", steamDir)
+
+	l.log.Debug("Proton environment set",
+		"PROTON", dir,
+		"STEAM_COMPAT_DATA_PATH", compatData,
+		"STEAM_COMPAT_CLIENT_INSTALL_PATH", steamDir,
+	)
+	return nil
+}
+
+func setOrDelete(prefs map[string]interface{}, key, value string) {
+	if value == "" {
+		delete(prefs, key)
+		return
+	}
+	prefs[key] = value
+}
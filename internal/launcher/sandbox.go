@@ -0,0 +1,133 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bnema/turtlectl/internal/settings"
+)
+
+// Bind prefixes in sandbox bind specs, a plain path is bound read-write
+const (
+	bindReadOnly = "ro:"
+	bindDevice   = "dev:"
+)
+
+// sandboxBinds replaces DefaultSandboxBinds, see SetSandboxBinds
+var sandboxBinds []string
+
+// SetSandboxBinds replaces the default sandbox binds, e.g. from the config
+// file. Each is a path, or ro:path / dev:path; ~ and $VARS are expanded and
+// missing paths are skipped
+func SetSandboxBinds(binds []string) {
+	sandboxBinds = binds
+}
+
+// DefaultSandboxBinds lists what the sandbox can see: the system read-only,
+// the GPU, display and audio sockets, and only the game, data and cache
+// directories of the home directory
+func (l *Launcher) DefaultSandboxBinds() []string {
+	return []string{
+		"ro:/usr", "ro:/etc", "ro:/opt", "ro:/bin", "ro:/sbin",
+		"ro:/lib", "ro:/lib32", "ro:/lib64", "ro:/sys",
+		"dev:/dev/dri",
+		"/tmp/.X11-unix",
+		"ro:$XAUTHORITY",
+		"$XDG_RUNTIME_DIR/$WAYLAND_DISPLAY",
+		"$XDG_RUNTIME_DIR/pulse",
+		"$XDG_RUNTIME_DIR/pipewire-0",
+		l.GameDir,
+		l.DataDir,
+		l.CacheDir,
+	}
+}
+
+// ConfigureSandbox resolves the sandbox choice for this launch
+// nil keeps the saved preference, otherwise the value is saved for later
+// launches. Without bwrap the game runs unsandboxed and a warning is returned
+func (l *Launcher) ConfigureSandbox(enabled *bool) ([]string, error) {
+	prefs, err := l.readPrefs()
+	if err != nil {
+		if os.IsNotExist(err) && enabled == nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	useSandbox, _ := prefs["turtlectlSandbox"].(bool)
+	if enabled != nil {
+		useSandbox = *enabled
+		setOrDeleteBool(prefs, "turtlectlSandbox", useSandbox)
+		if err := l.writePrefs(prefs); err != nil {
+			return nil, err
+		}
+	}
+
+	l.sandbox = nil
+	if !useSandbox {
+		return nil, nil
+	}
+
+	path, err := exec.LookPath("bwrap")
+	if err != nil {
+		warning := "bwrap not found, launching without the sandbox (install bubblewrap)"
+		l.log.Warn(warning)
+		return []string{warning}, nil
+	}
+	l.sandbox = append([]string{path}, l.bwrapArgs()...)
+	l.log.Info("Using bubblewrap sandbox", "path", path)
+	return nil, nil
+}
+
+// bwrapArgs builds the bwrap options: private /proc, /dev and /tmp, an empty
+// home, then the configured binds on top
+func (l *Launcher) bwrapArgs() []string {
+	args := []string{
+		"--die-with-parent",
+		"--unshare-pid",
+		"--proc", "/proc",
+		"--dev", "/dev",
+		"--tmpfs", "/tmp",
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		args = append(args, "--tmpfs", home)
+	}
+
+	binds := sandboxBinds
+	if binds == nil {
+		binds = l.DefaultSandboxBinds()
+	}
+	for _, spec := range binds {
+		if bind := bwrapBind(spec); bind != nil {
+			args = append(args, bind...)
+		}
+	}
+	return args
+}
+
+// bwrapBind turns a bind spec into bwrap options, nil when the path doesn't
+// exist or expands to nothing
+func bwrapBind(spec string) []string {
+	option := "--bind"
+	switch {
+	case strings.HasPrefix(spec, bindReadOnly):
+		option, spec = "--ro-bind", strings.TrimPrefix(spec, bindReadOnly)
+	case strings.HasPrefix(spec, bindDevice):
+		option, spec = "--dev-bind", strings.TrimPrefix(spec, bindDevice)
+	}
+
+	// An unset variable leaves e.g. "$XDG_RUNTIME_DIR/" or "", never bind those
+	if strings.Contains(spec, "$") {
+		expanded := os.ExpandEnv(spec)
+		if expanded == "" || strings.HasSuffix(expanded, "/") || !strings.HasPrefix(expanded, "/") {
+			return nil
+		}
+		spec = expanded
+	}
+	spec = settings.ExpandHome(spec)
+	if _, err := os.Stat(spec); err != nil {
+		return nil
+	}
+	return []string{option, spec, spec}
+}
@@ -0,0 +1,44 @@
+package launcher
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBwrapBind(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TURTLE_TEST_DIR", dir)
+	t.Setenv("TURTLE_TEST_UNSET", "")
+
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{dir, []string{"--bind", dir, dir}},
+		{"ro:" + dir, []string{"--ro-bind", dir, dir}},
+		{"dev:$TURTLE_TEST_DIR", []string{"--dev-bind", dir, dir}},
+		{"$TURTLE_TEST_UNSET", nil},
+		{"$TURTLE_TEST_DIR/$TURTLE_TEST_UNSET", nil},
+		{filepath.Join(dir, "missing"), nil},
+	}
+	for _, tt := range tests {
+		if got := bwrapBind(tt.spec); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bwrapBind(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestLaunchCommandSandboxed(t *testing.T) {
+	l := &Launcher{
+		AppImagePath: "/cache/TurtleWoW.AppImage",
+		wrapper:      []string{"/usr/bin/gamemoderun"},
+		sandbox:      []string{"/usr/bin/bwrap", "--unshare-pid"},
+	}
+
+	binary, argv := l.launchCommand([]string{"--foo"})
+	want := []string{"/usr/bin/bwrap", "--unshare-pid", "--", "/usr/bin/gamemoderun", "/cache/TurtleWoW.AppImage", "--foo"}
+	if binary != "/usr/bin/bwrap" || !reflect.DeepEqual(argv, want) {
+		t.Errorf("launchCommand() = %s %v, want %v", binary, argv, want)
+	}
+}
@@ -0,0 +1,119 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const (
+	// MaxFSRStrength is the weakest sharpening wine accepts, 0 is the sharpest
+	MaxFSRStrength = 5
+
+	// presentModeDefault clears a saved present mode
+	presentModeDefault = "default"
+)
+
+// PresentModes are the values Mesa accepts for MESA_VK_WSI_PRESENT_MODE
+var PresentModes = []string{"immediate", "mailbox", "fifo", "relaxed"}
+
+var ErrInvalidScaling = errors.New("invalid scaling option")
+
+// ScalingOptions are the FSR and present mode choices from flags
+// nil keeps the saved preference
+type ScalingOptions struct {
+	FSR         *bool
+	FSRStrength *int
+	PresentMode *string
+}
+
+// scalingSettings is what ends up in the environment
+type scalingSettings struct {
+	FSR         bool
+	FSRStrength int // -1 leaves wine's default
+	PresentMode string
+}
+
+// ConfigureScaling saves the FSR and present mode choices to preferences.json
+// and sets the environment. Nothing is set unless the user opted in, since
+// these can break some setups
+func (l *Launcher) ConfigureScaling(opts ScalingOptions) error {
+	if opts.FSRStrength != nil && (*opts.FSRStrength < 0 || *opts.FSRStrength > MaxFSRStrength) {
+		return fmt.Errorf("%w: FSR strength %d (0-%d)", ErrInvalidScaling, *opts.FSRStrength, MaxFSRStrength)
+	}
+	if opts.PresentMode != nil && *opts.PresentMode != presentModeDefault && !slices.Contains(PresentModes, *opts.PresentMode) {
+		return fmt.Errorf("%w: present mode %s", ErrInvalidScaling, *opts.PresentMode)
+	}
+
+	changed := opts.FSR != nil || opts.FSRStrength != nil || opts.PresentMode != nil
+	prefs, err := l.readPrefs()
+	if err != nil {
+		if os.IsNotExist(err) && !changed {
+			return nil
+		}
+		return err
+	}
+
+	if opts.FSR != nil {
+		setOrDeleteBool(prefs, "turtlectlFsr", *opts.FSR)
+	}
+	if opts.FSRStrength != nil {
+		prefs["turtlectlFsrStrength"] = *opts.FSRStrength
+	}
+	if opts.PresentMode != nil {
+		mode := *opts.PresentMode
+		if mode == presentModeDefault {
+			mode = ""
+		}
+		setOrDelete(prefs, "turtlectlPresentMode", mode)
+	}
+	if changed {
+		if err := l.writePrefs(prefs); err != nil {
+			return err
+		}
+	}
+
+	settings := scalingSettings{FSRStrength: -1}
+	settings.FSR, _ = prefs["turtlectlFsr"].(bool)
+	if opts.FSRStrength != nil {
+		settings.FSRStrength = *opts.FSRStrength
+	} else if strength, ok := prefs["turtlectlFsrStrength"].(float64); ok {
+		settings.FSRStrength = int(strength)
+	}
+	settings.PresentMode, _ = prefs["turtlectlPresentMode"].(string)
+
+	l.setupScalingEnv(settings)
+	return nil
+}
+
+// setupScalingEnv sets the FSR and present mode variables that are enabled
+func (l *Launcher) setupScalingEnv(s scalingSettings) {
+	if s.FSR {
+		l.log.Info("Enabling wine FSR upscaling")
+
+		// Upscale lower fullscreen resolutions with AMD FSR in wine's Vulkan layer
+		// See: https://github.com/GloriousEggroll/proton-ge-custom#fsr
+		_ = os.Setenv("WINE_FULLSCREEN_FSR", "1")
+		if s.FSRStrength >= 0 {
+			_ = os.Setenv("WINE_FULLSCREEN_FSR_STRENGTH", fmt.Sprint(s.FSRStrength))
+		}
+
+		l.log.Debug("FSR environment set",
+			"WINE_FULLSCREEN_FSR", "1",
+			"WINE_FULLSCREEN_FSR_STRENGTH", os.Getenv("WINE_FULLSCREEN_FSR_STRENGTH"),
+		)
+	}
+
+	if s.PresentMode != "" {
+		l.log.Info("Setting Vulkan present mode", "mode", s.PresentMode)
+
+		// immediate allows tearing, fifo is vsync, mailbox is vsync without the latency
+		// See: https://docs.mesa3d.org/envvars.html#envvar-MESA_VK_WSI_PRESENT_MODE
+		_ = os.Setenv("MESA_VK_WSI_PRESENT_MODE", s.PresentMode)
+
+		l.log.Debug("Present mode environment set",
+			"MESA_VK_WSI_PRESENT_MODE", s.PresentMode,
+		)
+	}
+}
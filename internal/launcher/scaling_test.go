@@ -0,0 +1,120 @@
+package launcher
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+var scalingVars = []string{"WINE_FULLSCREEN_FSR", "WINE_FULLSCREEN_FSR_STRENGTH", "MESA_VK_WSI_PRESENT_MODE"}
+
+// clearScalingEnv unsets the scaling variables and restores them after the test
+func clearScalingEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range scalingVars {
+		t.Setenv(key, "")
+		_ = os.Unsetenv(key)
+	}
+}
+
+func newTestLauncher(t *testing.T, prefs string) *Launcher {
+	t.Helper()
+	l := &Launcher{DataDir: t.TempDir(), log: log.New(io.Discard)}
+	if prefs != "" {
+		if err := os.WriteFile(filepath.Join(l.DataDir, "preferences.json"), []byte(prefs), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return l
+}
+
+func TestSetupScalingEnvDisabled(t *testing.T) {
+	clearScalingEnv(t)
+
+	l := newTestLauncher(t, "")
+	l.setupScalingEnv(scalingSettings{FSRStrength: 3})
+
+	for _, key := range scalingVars {
+		if value, ok := os.LookupEnv(key); ok {
+			t.Errorf("%s set to %q without opting in", key, value)
+		}
+	}
+}
+
+func TestSetupScalingEnvEnabled(t *testing.T) {
+	clearScalingEnv(t)
+
+	l := newTestLauncher(t, "")
+	l.setupScalingEnv(scalingSettings{FSR: true, FSRStrength: 3, PresentMode: "mailbox"})
+
+	want := map[string]string{
+		"WINE_FULLSCREEN_FSR":          "1",
+		"WINE_FULLSCREEN_FSR_STRENGTH": "3",
+		"MESA_VK_WSI_PRESENT_MODE":     "mailbox",
+	}
+	for key, value := range want {
+		if got := os.Getenv(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestConfigureScalingPersists(t *testing.T) {
+	clearScalingEnv(t)
+
+	l := newTestLauncher(t, `{"linuxLaunchArgs": "wine $WoW.exe$"}`)
+	fsr, strength, mode := true, 4, "immediate"
+	if err := l.ConfigureScaling(ScalingOptions{FSR: &fsr, FSRStrength: &strength, PresentMode: &mode}); err != nil {
+		t.Fatalf("ConfigureScaling() error: %v", err)
+	}
+
+	// A later launch without flags picks up the saved choices
+	clearScalingEnv(t)
+	if err := l.ConfigureScaling(ScalingOptions{}); err != nil {
+		t.Fatalf("ConfigureScaling() error: %v", err)
+	}
+	if os.Getenv("WINE_FULLSCREEN_FSR") != "1" || os.Getenv("WINE_FULLSCREEN_FSR_STRENGTH") != "4" || os.Getenv("MESA_VK_WSI_PRESENT_MODE") != "immediate" {
+		t.Fatalf("saved scaling not applied, env: %q %q %q",
+			os.Getenv("WINE_FULLSCREEN_FSR"), os.Getenv("WINE_FULLSCREEN_FSR_STRENGTH"), os.Getenv("MESA_VK_WSI_PRESENT_MODE"))
+	}
+
+	// Turning both off clears them again
+	clearScalingEnv(t)
+	fsr, mode = false, presentModeDefault
+	if err := l.ConfigureScaling(ScalingOptions{FSR: &fsr, PresentMode: &mode}); err != nil {
+		t.Fatalf("ConfigureScaling() error: %v", err)
+	}
+	for _, key := range scalingVars {
+		if value, ok := os.LookupEnv(key); ok {
+			t.Errorf("%s set to %q after disabling", key, value)
+		}
+	}
+
+	prefs, err := l.readPrefs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := prefs["turtlectlFsr"]; ok {
+		t.Errorf("turtlectlFsr still saved: %v", prefs)
+	}
+	if prefs["linuxLaunchArgs"] != "wine $WoW.exe$" {
+		t.Errorf("unrelated preferences lost: %v", prefs)
+	}
+}
+
+func TestConfigureScalingRejectsInvalid(t *testing.T) {
+	l := newTestLauncher(t, "{}")
+
+	strength := 9
+	if err := l.ConfigureScaling(ScalingOptions{FSRStrength: &strength}); !errors.Is(err, ErrInvalidScaling) {
+		t.Errorf("expected ErrInvalidScaling for strength 9, got %v", err)
+	}
+	mode := "vsync"
+	if err := l.ConfigureScaling(ScalingOptions{PresentMode: &mode}); !errors.Is(err, ErrInvalidScaling) {
+		t.Errorf("expected ErrInvalidScaling for mode %q, got %v", mode, err)
+	}
+}
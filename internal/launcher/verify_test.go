@@ -0,0 +1,41 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyAppImage(t *testing.T) {
+	appImage := append([]byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0}, 'A', 'I', 2, 0)
+	plainELF := []byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"appimage", appImage, false},
+		{"html error page", []byte("<!DOCTYPE html><html>502 Bad Gateway</html>"), true},
+		{"plain elf", plainELF, true},
+		{"truncated", appImage[:6], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "launcher.AppImage")
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatalf("WriteFile() returned error: %v", err)
+			}
+
+			err := verifyAppImage(path)
+			if tt.wantErr && !errors.Is(err, ErrCorruptDownload) {
+				t.Fatalf("expected ErrCorruptDownload, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("verifyAppImage() returned error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package launcher
+
+import (
+	"os"
+	"os/exec"
+)
+
+// ConfigureWrappers resolves the gamemode and mangohud choices for this launch
+// nil keeps the saved preference, otherwise the value is saved for later launches.
+// Missing tools are skipped and returned as warnings
+func (l *Launcher) ConfigureWrappers(gamemode, mangohud *bool) ([]string, error) {
+	prefs, err := l.readPrefs()
+	if err != nil {
+		if os.IsNotExist(err) && gamemode == nil && mangohud == nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	useGamemode, _ := prefs["turtlectlGamemode"].(bool)
+	useMangohud, _ := prefs["turtlectlMangohud"].(bool)
+	if gamemode != nil {
+		useGamemode = *gamemode
+		setOrDeleteBool(prefs, "turtlectlGamemode", useGamemode)
+	}
+	if mangohud != nil {
+		useMangohud = *mangohud
+		setOrDeleteBool(prefs, "turtlectlMangohud", useMangohud)
+	}
+	if gamemode != nil || mangohud != nil {
+		if err := l.writePrefs(prefs); err != nil {
+			return nil, err
+		}
+	}
+
+	var warnings []string
+	l.wrapper = nil
+	if useGamemode {
+		if path, err := exec.LookPath("gamemoderun"); err == nil {
+			l.wrapper = []string{path}
+			l.log.Info("Using gamemode", "path", path)
+		} else {
+			warnings = append(warnings, "gamemoderun not found, launching without gamemode (install gamemode)")
+		}
+	}
+
+	if useMangohud {
+		// MANGOHUD=1 enables the Vulkan layer; the mangohud binary means it's installed
+		if _, err := exec.LookPath("mangohud"); err == nil {
+			_ = os.Setenv("MANGOHUD", "1")
+			l.log.Info("Using MangoHud")
+		} else {
+			warnings = append(warnings, "mangohud not found, launching without MangoHud (install mangohud)")
+		}
+	}
+
+	for _, w := range warnings {
+		l.log.Warn(w)
+	}
+	return warnings, nil
+}
+
+// launchCommand returns the binary and argv to run, including any wrapper,
+// all inside the sandbox when enabled
+func (l *Launcher) launchCommand(args []string) (string, []string) {
+	argv := append([]string{l.AppImagePath}, args...)
+	if len(l.wrapper) > 0 {
+		argv = append(append([]string{}, l.wrapper...), argv...)
+	}
+	if len(l.sandbox) > 0 {
+		argv = append(append(append([]string{}, l.sandbox...), "--"), argv...)
+	}
+	return argv[0], argv
+}
+
+func setOrDeleteBool(prefs map[string]interface{}, key string, value bool) {
+	if !value {
+		delete(prefs, key)
+		return
+	}
+	prefs[key] = true
+}
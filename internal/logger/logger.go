@@ -1,11 +1,15 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
 )
 
 func init() {
@@ -14,33 +18,82 @@ func init() {
 	log.SetLevel(log.FatalLevel)
 }
 
+const (
+	// MaxLogSize is the size above which the log file is rotated on Init
+	MaxLogSize = 5 * 1024 * 1024
+
+	// MaxLogBackups is how many rotated files (turtlectl.log.1, .2, ...) are kept
+	MaxLogBackups = 2
+)
+
+// Log formats accepted by SetFormat
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var ErrInvalidFormat = errors.New("invalid log format")
+
 var (
 	// Log is the global logger instance
 	Log *log.Logger
 
+	// formatter is applied to the file and stderr output, see SetFormat
+	formatter log.Formatter = log.TextFormatter
+
 	// logFile is the file handle for the log file
 	logFile *os.File
+
+	// noColor drops colors from stderr output, see DisableColor
+	noColor bool
 )
 
+// SetFormat picks the log output format for Init, falling back to
+// TURTLECTL_LOG_FORMAT when format is empty. Only logs are affected, not the TUI
+func SetFormat(format string) error {
+	if format == "" {
+		format = os.Getenv("TURTLECTL_LOG_FORMAT")
+	}
+
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		formatter = log.TextFormatter
+	case FormatJSON:
+		formatter = log.JSONFormatter
+	default:
+		return fmt.Errorf("%w: %s (use %s or %s)", ErrInvalidFormat, format, FormatText, FormatJSON)
+	}
+	return nil
+}
+
+// DisableColor makes loggers created by Init print without colors
+func DisableColor() {
+	noColor = true
+}
+
+// newLogger creates a logger writing to w in the configured format
+func newLogger(w io.Writer) *log.Logger {
+	l := log.NewWithOptions(w, log.Options{
+		ReportTimestamp: true,
+		Formatter:       formatter,
+	})
+	if noColor {
+		l.SetColorProfile(termenv.Ascii)
+	}
+	return l
+}
+
 // Init initializes the logger with the given verbosity level
 // When verbose is false, logs go to file only
 // When verbose is true, logs go to both file and stderr
 func Init(verbose bool) error {
-	// Get log file path
-	cacheDir := os.Getenv("XDG_CACHE_HOME")
-	if cacheDir == "" {
-		homeDir, _ := os.UserHomeDir()
-		cacheDir = filepath.Join(homeDir, ".cache")
-	}
-	logDir := filepath.Join(cacheDir, "turtle-wow")
-	logPath := filepath.Join(logDir, "turtlectl.log")
+	logPath := GetLogPath()
+	logDir := filepath.Dir(logPath)
 
 	// Ensure log directory exists
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		// Fall back to stderr only if we can't create log dir
-		Log = log.NewWithOptions(os.Stderr, log.Options{
-			ReportTimestamp: true,
-		})
+		Log = newLogger(os.Stderr)
 		if verbose {
 			Log.SetLevel(log.DebugLevel)
 		} else {
@@ -49,14 +102,18 @@ func Init(verbose bool) error {
 		return nil
 	}
 
+	// Logs used to live in the cache dir, carry them over once
+	migrateLog(legacyLogPath(), logPath)
+
+	// Rotate before appending so the file can't grow forever
+	rotateLog(logPath)
+
 	// Open log file (append mode)
 	var err error
 	logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
 		// Fall back to stderr only
-		Log = log.NewWithOptions(os.Stderr, log.Options{
-			ReportTimestamp: true,
-		})
+		Log = newLogger(os.Stderr)
 		if verbose {
 			Log.SetLevel(log.DebugLevel)
 		} else {
@@ -75,9 +132,7 @@ func Init(verbose bool) error {
 		output = logFile
 	}
 
-	Log = log.NewWithOptions(output, log.Options{
-		ReportTimestamp: true,
-	})
+	Log = newLogger(output)
 
 	if verbose {
 		Log.SetLevel(log.DebugLevel)
@@ -88,6 +143,37 @@ func Init(verbose bool) error {
 	return nil
 }
 
+// rotateLog shifts path to path.1, path.1 to path.2 and so on once it exceeds
+// MaxLogSize, dropping the oldest. Failures are ignored, logging still works
+func rotateLog(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < MaxLogSize {
+		return
+	}
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", path, MaxLogBackups))
+	for i := MaxLogBackups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	_ = os.Rename(path, path+".1")
+}
+
+// migrateLog moves the log at oldPath and its rotated files to newPath,
+// unless a log already exists there. Failures are ignored, like rotation
+func migrateLog(oldPath, newPath string) {
+	if _, err := os.Stat(newPath); err == nil {
+		return
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		return
+	}
+
+	_ = os.Rename(oldPath, newPath)
+	for i := 1; i <= MaxLogBackups; i++ {
+		_ = os.Rename(fmt.Sprintf("%s.%d", oldPath, i), fmt.Sprintf("%s.%d", newPath, i))
+	}
+}
+
 // Close closes the log file
 func Close() {
 	if logFile != nil {
@@ -95,8 +181,19 @@ func Close() {
 	}
 }
 
-// GetLogPath returns the path to the log file
+// GetLogPath returns the path to the log file, under $XDG_STATE_HOME
+// (~/.local/state) as the XDG spec puts logs there
 func GetLogPath() string {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		homeDir, _ := os.UserHomeDir()
+		stateDir = filepath.Join(homeDir, ".local", "state")
+	}
+	return filepath.Join(stateDir, "turtle-wow", "turtlectl.log")
+}
+
+// legacyLogPath returns where the log file lived before moving to the state dir
+func legacyLogPath() string {
 	cacheDir := os.Getenv("XDG_CACHE_HOME")
 	if cacheDir == "" {
 		homeDir, _ := os.UserHomeDir()
@@ -0,0 +1,46 @@
+// Package offline tracks whether turtlectl is allowed to use the network
+package offline
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrOffline is returned by operations that need the network in offline mode
+var ErrOffline = errors.New("offline mode: network access is disabled (--offline, offline in the config file or TURTLECTL_OFFLINE)")
+
+var (
+	// forced is the --offline flag when given, which wins over everything
+	forced *bool
+
+	// fallback is the config file setting, which wins over TURTLECTL_OFFLINE
+	fallback *bool
+)
+
+// Set enables or disables offline mode, e.g. from an explicit --offline or
+// --offline=false, overriding the config file and TURTLECTL_OFFLINE
+func Set(enabled bool) {
+	forced = &enabled
+}
+
+// SetDefault sets offline mode from the config file, used when Set wasn't
+// called, instead of TURTLECTL_OFFLINE
+func SetDefault(enabled bool) {
+	fallback = &enabled
+}
+
+// Enabled reports whether offline mode is on via Set, SetDefault or TURTLECTL_OFFLINE
+func Enabled() bool {
+	if forced != nil {
+		return *forced
+	}
+	if fallback != nil {
+		return *fallback
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("TURTLECTL_OFFLINE"))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package offline
+
+import "testing"
+
+func TestEnabledPrecedence(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   *bool
+		config *bool
+		env    string
+		want   bool
+	}{
+		{"nothing set", nil, nil, "", false},
+		{"env only", nil, nil, "1", true},
+		{"config over env", nil, ptr(false), "1", false},
+		{"config true", nil, ptr(true), "", true},
+		{"flag false over config true", ptr(false), ptr(true), "1", false},
+		{"flag true over config false", ptr(true), ptr(false), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forced, fallback = nil, nil
+			t.Cleanup(func() { forced, fallback = nil, nil })
+			t.Setenv("TURTLECTL_OFFLINE", tt.env)
+
+			if tt.config != nil {
+				SetDefault(*tt.config)
+			}
+			if tt.flag != nil {
+				Set(*tt.flag)
+			}
+			if got := Enabled(); got != tt.want {
+				t.Fatalf("Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr(b bool) *bool {
+	return &b
+}
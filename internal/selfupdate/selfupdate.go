@@ -0,0 +1,332 @@
+// Package selfupdate replaces the turtlectl binary with the latest GitHub release
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/turtlectl/internal/offline"
+)
+
+// ReleaseURL is the GitHub API endpoint for the latest turtlectl release
+const ReleaseURL = "https://api.github.com/repos/bnema/turtlectl/releases/latest"
+
+// binaryName is the executable inside release archives
+const binaryName = "turtlectl"
+
+var (
+	ErrNoAsset       = errors.New("no release download for this platform")
+	ErrNoChecksum    = errors.New("release has no checksum for the download, refusing to install it unverified")
+	ErrChecksum      = errors.New("downloaded binary checksum mismatch")
+	ErrPermission    = errors.New("no permission to replace the turtlectl binary")
+	ErrNotExecutable = errors.New("downloaded file is not a Linux executable")
+)
+
+// Release is the latest published release
+type Release struct {
+	Version string  `json:"tag_name"`
+	URL     string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+var client = &http.Client{Timeout: 2 * time.Minute}
+
+// Latest fetches the latest release from GitHub
+func Latest(ctx context.Context) (*Release, error) {
+	if offline.Enabled() {
+		return nil, offline.ErrOffline
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ReleaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "turtlectl/1.0 (Turtle WoW addon manager)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the latest release: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check the latest release: status %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse the latest release: %w", err)
+	}
+	return &release, nil
+}
+
+// platformNames are the spellings of GOOS and GOARCH used in asset names
+func platformNames() ([]string, []string) {
+	arches := []string{runtime.GOARCH}
+	switch runtime.GOARCH {
+	case "amd64":
+		arches = append(arches, "x86_64")
+	case "arm64":
+		arches = append(arches, "aarch64")
+	}
+	return []string{runtime.GOOS}, arches
+}
+
+// isChecksum reports whether an asset holds checksums
+func isChecksum(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "checksum") || strings.HasSuffix(lower, ".sha256")
+}
+
+// isSignature reports whether an asset is a detached signature
+func isSignature(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".sig") || strings.HasSuffix(lower, ".asc") || strings.HasSuffix(lower, ".pem")
+}
+
+// isArchive reports whether an asset is a .tar.gz, see extractBinary
+func isArchive(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz")
+}
+
+// Binary returns the asset for this OS and architecture: a raw binary named
+// like turtlectl_linux_amd64, or a .tar.gz holding one
+// Packages such as .deb or .rpm and other files are skipped
+func (r *Release) Binary() (*Asset, error) {
+	oses, arches := platformNames()
+	for i, a := range r.Assets {
+		lower := strings.ToLower(a.Name)
+		if isChecksum(lower) || isSignature(lower) || !containsAny(lower, oses) {
+			continue
+		}
+		// The name must end with the architecture, before the archive extension
+		base := strings.TrimSuffix(strings.TrimSuffix(lower, ".tar.gz"), ".tgz")
+		if !hasAnySuffix(base, arches) {
+			continue
+		}
+		return &r.Assets[i], nil
+	}
+	return nil, fmt.Errorf("%w (%s/%s) in %s", ErrNoAsset, runtime.GOOS, runtime.GOARCH, r.Version)
+}
+
+func hasAnySuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksumFor returns the expected SHA-256 of asset from a "<asset>.sha256"
+// file or a checksums.txt listing in sha256sum format
+func (r *Release) checksumFor(ctx context.Context, asset *Asset) (string, error) {
+	for _, a := range r.Assets {
+		if !isChecksum(a.Name) {
+			continue
+		}
+		data, err := download(ctx, a.URL)
+		if err != nil {
+			return "", err
+		}
+		if sum := findChecksum(data, asset.Name, a.Name == asset.Name+".sha256"); sum != "" {
+			return sum, nil
+		}
+	}
+	return "", ErrNoChecksum
+}
+
+// findChecksum finds name in sha256sum output; a file for the asset alone may
+// hold just the digest
+func findChecksum(data []byte, name string, single bool) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		digest := strings.ToLower(fields[0])
+		if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+			continue
+		}
+		if single && len(fields) == 1 {
+			return digest
+		}
+		if len(fields) >= 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return digest
+		}
+	}
+	return ""
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "turtlectl/1.0 (Turtle WoW addon manager)")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Download fetches and verifies the binary for this platform
+func (r *Release) Download(ctx context.Context) ([]byte, error) {
+	asset, err := r.Binary()
+	if err != nil {
+		return nil, err
+	}
+	expected, err := r.checksumFor(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := download(ctx, asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != expected {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrChecksum, expected, got)
+	}
+
+	if isArchive(asset.Name) {
+		if data, err = extractBinary(data); err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.HasPrefix(data, []byte{0x7f, 'E', 'L', 'F'}) {
+		return nil, ErrNotExecutable
+	}
+	return data, nil
+}
+
+// extractBinary returns the turtlectl executable from a .tar.gz
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open release archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in release archive", binaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == binaryName {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// Replace atomically swaps the executable at path for data: written next to
+// it, made executable, then renamed over it
+func Replace(path string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".turtlectl-update-*")
+	if err != nil {
+		return permissionError(path, err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		return fmt.Errorf("failed to make the new binary executable: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return permissionError(path, err)
+	}
+	return nil
+}
+
+// permissionError explains how to update a binary the user can't write
+func permissionError(path string, err error) error {
+	if errors.Is(err, os.ErrPermission) {
+		return fmt.Errorf("%w %s (run with sudo, or update it with the package manager that installed it)", ErrPermission, path)
+	}
+	return fmt.Errorf("failed to replace %s: %w", path, err)
+}
+
+// Newer reports whether latest is a newer version than current, comparing
+// vMAJOR.MINOR.PATCH. ok is false when either isn't a release version (e.g.
+// "dev" or a git describe with commits after a tag)
+func Newer(latest, current string) (newer, ok bool) {
+	l, lok := parseVersion(latest)
+	c, cok := parseVersion(current)
+	if !lok || !cok {
+		return false, false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i], true
+		}
+	}
+	return false, true
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	fields := strings.Split(strings.TrimPrefix(strings.TrimSpace(v), "v"), ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
@@ -0,0 +1,96 @@
+package selfupdate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		newer, ok       bool
+	}{
+		{"v1.2.0", "v1.1.9", true, true},
+		{"v1.2.0", "1.2.0", false, true},
+		{"v1.10.0", "v1.9.3", true, true},
+		{"v1.0.0", "v1.2.0", false, true},
+		{"v1.2.0", "dev", false, false},
+		{"v1.2.0", "v1.1.0-3-gabcdef-dirty", false, false},
+	}
+	for _, tt := range tests {
+		newer, ok := Newer(tt.latest, tt.current)
+		if newer != tt.newer || ok != tt.ok {
+			t.Errorf("Newer(%q, %q) = %v, %v; want %v, %v", tt.latest, tt.current, newer, ok, tt.newer, tt.ok)
+		}
+	}
+}
+
+func TestReleaseBinary(t *testing.T) {
+	arch := runtime.GOARCH
+	r := &Release{Version: "v1.2.0", Assets: []Asset{
+		{Name: "checksums.txt"},
+		{Name: "turtlectl_" + runtime.GOOS + "_" + arch + ".tar.gz.sig"},
+		{Name: "turtlectl_" + runtime.GOOS + "_" + arch + ".tar.gz"},
+	}}
+	asset, err := r.Binary()
+	if err != nil || asset.Name != "turtlectl_"+runtime.GOOS+"_"+arch+".tar.gz" {
+		t.Fatalf("Binary() = %+v, %v", asset, err)
+	}
+
+	r.Assets = []Asset{
+		{Name: "turtlectl_" + runtime.GOOS + "_" + arch + ".deb"},
+		{Name: "turtlectl_" + runtime.GOOS + "_" + arch + ".rpm"},
+		{Name: "turtlectl_" + runtime.GOOS + "_" + arch},
+	}
+	if asset, err := r.Binary(); err != nil || asset.Name != "turtlectl_"+runtime.GOOS+"_"+arch {
+		t.Fatalf("Binary() = %+v, %v, want the raw binary over packages", asset, err)
+	}
+
+	r.Assets = []Asset{{Name: "turtlectl_" + runtime.GOOS + "_" + arch + ".deb"}, {Name: "turtlectl_plan9_mips"}}
+	if _, err := r.Binary(); !errors.Is(err, ErrNoAsset) {
+		t.Errorf("expected ErrNoAsset, got %v", err)
+	}
+}
+
+func TestFindChecksum(t *testing.T) {
+	sum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	listing := []byte(sum + "  turtlectl_linux_amd64\n" + sum[:10] + "  broken\n")
+	if got := findChecksum(listing, "turtlectl_linux_amd64", false); got != sum {
+		t.Errorf("checksums.txt lookup = %q", got)
+	}
+	if got := findChecksum(listing, "turtlectl_linux_arm64", false); got != "" {
+		t.Errorf("found a checksum for a missing asset: %q", got)
+	}
+	if got := findChecksum([]byte(sum+"\n"), "turtlectl_linux_amd64", true); got != sum {
+		t.Errorf("single digest lookup = %q", got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "turtlectl")
+	if err := os.WriteFile(path, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Replace(link, []byte("new")); err != nil {
+		t.Fatalf("Replace() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "new" {
+		t.Errorf("binary not replaced: %q, %v", data, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode()&0111 == 0 {
+		t.Errorf("new binary not executable: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("temp file left behind: %v", entries)
+	}
+}
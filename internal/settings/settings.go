@@ -0,0 +1,259 @@
+// Package settings loads the turtlectl config file, which sets defaults for
+// flags and environment variables
+//
+// The file is a flat subset of TOML: one key = value per line, with strings,
+// integers, booleans and arrays of strings, and # comments
+package settings
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileName is the name of the config file in the turtlectl config dir
+const FileName = "config.toml"
+
+var ErrInvalid = errors.New("invalid config file")
+
+// Settings are the defaults read from the config file
+// Unset values are zero, or nil for booleans
+type Settings struct {
+	Mirror            string   // mirror: download mirror when preferences.json has none
+	GameDir           string   // game_dir: game directory, ~ is expanded
+	UpdateConcurrency int      // update_concurrency: addons updated in parallel
+	NerdFonts         *bool    // nerd_fonts: Nerd Font or ASCII icons, detected when unset
+	Offline           *bool    // offline: never touch the network
+	Registry          []string // registry: addon registry URLs, "default" for the built-in one
+	SandboxBinds      []string // sandbox_binds: paths visible in launch --sandbox, replacing the defaults
+}
+
+// Keys lists the keys accepted in the config file
+var Keys = []string{"mirror", "game_dir", "update_concurrency", "nerd_fonts", "offline", "registry", "sandbox_binds"}
+
+// Path returns the default config file location,
+// $XDG_CONFIG_HOME/turtlectl/config.toml (~/.config by default)
+func Path() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, _ := os.UserHomeDir()
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "turtlectl", FileName)
+}
+
+// Load reads the config file at path
+// A missing file is returned as an fs.ErrNotExist error
+func Load(path string) (*Settings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	s, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse reads settings from r
+func Parse(r io.Reader) (*Settings, error) {
+	s := &Settings{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			return nil, fmt.Errorf("%w: line %d: tables are not supported", ErrInvalid, n)
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: expected key = value", ErrInvalid, n)
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalid, n, err)
+		}
+		if err := s.set(key, value); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalid, n, err)
+		}
+	}
+	return s, scanner.Err()
+}
+
+// set assigns a parsed value to the setting named key
+func (s *Settings) set(key string, value any) error {
+	switch key {
+	case "mirror":
+		return assign(key, value, &s.Mirror)
+	case "game_dir":
+		if err := assign(key, value, &s.GameDir); err != nil {
+			return err
+		}
+		s.GameDir = ExpandHome(s.GameDir)
+	case "update_concurrency":
+		if err := assign(key, value, &s.UpdateConcurrency); err != nil {
+			return err
+		}
+		if s.UpdateConcurrency < 1 {
+			return fmt.Errorf("update_concurrency must be at least 1")
+		}
+	case "nerd_fonts":
+		s.NerdFonts = new(bool)
+		return assign(key, value, s.NerdFonts)
+	case "offline":
+		s.Offline = new(bool)
+		return assign(key, value, s.Offline)
+	case "registry":
+		// A single URL or a list
+		if url, ok := value.(string); ok {
+			value = []string{url}
+		}
+		return assign(key, value, &s.Registry)
+	case "sandbox_binds":
+		return assign(key, value, &s.SandboxBinds)
+	default:
+		return fmt.Errorf("unknown key %q (known: %s)", key, strings.Join(Keys, ", "))
+	}
+	return nil
+}
+
+// assign stores value in dst if it has the type of dst
+func assign[T any](key string, value any, dst *T) error {
+	v, ok := value.(T)
+	if !ok {
+		return fmt.Errorf("%s must be a %s", key, typeName(*dst))
+	}
+	*dst = v
+	return nil
+}
+
+// typeName names the TOML type matching v for error messages
+func typeName(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case int:
+		return "number"
+	case bool:
+		return "boolean (true or false)"
+	default:
+		return "list of strings"
+	}
+}
+
+// parseValue parses a string, integer, boolean or array of strings, followed
+// by an optional comment
+func parseValue(raw string) (any, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	switch raw[0] {
+	case '"', '\'':
+		str, rest, err := parseString(raw)
+		if err != nil {
+			return nil, err
+		}
+		return str, checkTrailing(rest)
+	case '[':
+		return parseArray(raw[1:])
+	}
+
+	raw, _, _ = strings.Cut(raw, "#")
+	raw = strings.TrimSpace(raw)
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(raw, "_", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q (quote strings)", raw)
+	}
+	return n, nil
+}
+
+// parseString parses the quoted string at the start of raw and returns the
+// rest of the line. "Basic" strings take escapes, 'literal' ones don't
+func parseString(raw string) (string, string, error) {
+	quote := raw[0]
+	for i := 1; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\\' && quote == '"':
+			i++
+		case raw[i] == quote:
+			if quote == '\'' {
+				return raw[1:i], raw[i+1:], nil
+			}
+			str, err := strconv.Unquote(raw[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid string %s", raw[:i+1])
+			}
+			return str, raw[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// parseArray parses the strings of an array up to its closing bracket, raw
+// starting right after the opening one. Arrays must fit on one line
+func parseArray(raw string) ([]string, error) {
+	values := []string{}
+	for {
+		raw = strings.TrimSpace(raw)
+		if strings.HasPrefix(raw, "]") {
+			return values, checkTrailing(raw[1:])
+		}
+		if raw == "" || (raw[0] != '"' && raw[0] != '\'') {
+			return nil, fmt.Errorf("arrays must hold quoted strings and end with ]")
+		}
+
+		str, rest, err := parseString(raw)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, str)
+
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, ",") {
+			rest = rest[1:]
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("expected , or ] after %q", str)
+		}
+		raw = rest
+	}
+}
+
+// checkTrailing rejects anything but a comment after a value
+func checkTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+	return nil
+}
+
+// ExpandHome replaces a leading ~/ with the user's home directory
+func ExpandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
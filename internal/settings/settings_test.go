@@ -0,0 +1,87 @@
+package settings
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	s, err := Parse(strings.NewReader(`
+# turtlectl defaults
+mirror = "bunny"   # preferred CDN
+game_dir = '~/Games/turtle #2'
+update_concurrency = 8
+nerd_fonts = true
+offline = false
+registry = ["default", "https://example.com/guild.json",]
+sandbox_binds = ["ro:/usr", "~/Games/turtle-wow"]
+`))
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if s.Mirror != "bunny" || s.UpdateConcurrency != 8 {
+		t.Errorf("unexpected settings: %+v", s)
+	}
+	if want := filepath.Join(home, "Games/turtle #2"); s.GameDir != want {
+		t.Errorf("GameDir = %q, want %q", s.GameDir, want)
+	}
+	if s.NerdFonts == nil || !*s.NerdFonts || s.Offline == nil || *s.Offline {
+		t.Errorf("unexpected booleans: nerd_fonts=%v offline=%v", s.NerdFonts, s.Offline)
+	}
+	if want := []string{"default", "https://example.com/guild.json"}; !reflect.DeepEqual(s.Registry, want) {
+		t.Errorf("Registry = %v, want %v", s.Registry, want)
+	}
+	if want := []string{"ro:/usr", "~/Games/turtle-wow"}; !reflect.DeepEqual(s.SandboxBinds, want) {
+		t.Errorf("SandboxBinds = %v, want %v", s.SandboxBinds, want)
+	}
+}
+
+func TestParseSingleRegistry(t *testing.T) {
+	s, err := Parse(strings.NewReader(`registry = "https://example.com/guild.json"`))
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(s.Registry, []string{"https://example.com/guild.json"}) {
+		t.Errorf("unexpected registry: %v", s.Registry)
+	}
+	if s.NerdFonts != nil || s.Offline != nil {
+		t.Errorf("unset booleans should stay nil: %+v", s)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"colour = true", "unknown key"},
+		{"mirror = bunny", "quote strings"},
+		{"mirror = 3", "must be a string"},
+		{"offline = \"yes\"", "must be a boolean"},
+		{"update_concurrency = 0", "at least 1"},
+		{"mirror = \"bunny", "unterminated"},
+		{"mirror = \"bunny\" extra", "unexpected"},
+		{"registry = [\"a\" \"b\"]", "expected , or ]"},
+		{"[addons]", "tables are not supported"},
+		{"mirror", "expected key = value"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(strings.NewReader(tt.input))
+		if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), FileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a not-exist error, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+// Package steam adds turtlectl to Steam as a non-Steam game shortcut
+package steam
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlatpakAppID is the Flatpak Steam application
+const FlatpakAppID = "com.valvesoftware.Steam"
+
+// flatpakSpawn runs host commands from inside the Flatpak sandbox
+const flatpakSpawn = "/usr/bin/flatpak-spawn"
+
+// ErrNoSteam is returned when no Steam userdata directory exists
+var ErrNoSteam = errors.New("no Steam user found (start Steam and log in once)")
+
+// Shortcut is a non-Steam game entry
+type Shortcut struct {
+	AppName  string
+	Exe      string // Unquoted path to the executable
+	Args     string // Launch options, e.g. "launch"
+	Icon     string // Image shown in the library
+	StartDir string // Working directory, the executable's by default
+}
+
+// User is a Steam account's userdata directory
+type User struct {
+	ID      string
+	Dir     string
+	Flatpak bool // Belongs to Flatpak Steam, which runs host programs through flatpak-spawn
+	ModTime time.Time
+}
+
+// ShortcutsPath is the user's shortcuts.vdf
+func (u User) ShortcutsPath() string {
+	return filepath.Join(u.Dir, "config", "shortcuts.vdf")
+}
+
+// steamRoots lists where native and Flatpak Steam keep their data under home
+func steamRoots(home string) []struct {
+	dir     string
+	flatpak bool
+} {
+	flatpakHome := filepath.Join(home, ".var", "app", FlatpakAppID)
+	return []struct {
+		dir     string
+		flatpak bool
+	}{
+		{filepath.Join(home, ".local", "share", "Steam"), false},
+		{filepath.Join(home, ".steam", "steam"), false},
+		{filepath.Join(home, ".steam", "root"), false},
+		{filepath.Join(flatpakHome, ".local", "share", "Steam"), true},
+		{filepath.Join(flatpakHome, "data", "Steam"), true},
+	}
+}
+
+// FindUsers lists the Steam users under home, most recently used first
+// ~/.steam/steam usually links to ~/.local/share/Steam, each user is listed once
+func FindUsers(home string) []User {
+	var users []User
+	seen := make(map[string]bool)
+	for _, root := range steamRoots(home) {
+		userdata := filepath.Join(root.dir, "userdata")
+		resolved, err := filepath.EvalSymlinks(userdata)
+		if err != nil || seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+
+		entries, err := os.ReadDir(resolved)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			// Account ids are numbers, 0 is the anonymous user
+			if id, err := strconv.ParseUint(e.Name(), 10, 32); err != nil || id == 0 || !e.IsDir() {
+				continue
+			}
+			user := User{ID: e.Name(), Dir: filepath.Join(resolved, e.Name()), Flatpak: root.flatpak}
+			if info, err := os.Stat(filepath.Join(user.Dir, "config")); err == nil {
+				user.ModTime = info.ModTime()
+			}
+			users = append(users, user)
+		}
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].ModTime.After(users[j].ModTime)
+	})
+	return users
+}
+
+// AppID is the id Steam derives for a non-Steam shortcut, also used to name
+// its artwork in config/grid
+func AppID(exe, appName string) uint32 {
+	return crc32.ChecksumIEEE([]byte(exe+appName)) | 0x80000000
+}
+
+// command returns the executable, working directory and launch options u's
+// Steam runs, going through flatpak-spawn for Flatpak Steam
+func (s Shortcut) command(u User) (exe, startDir, options string) {
+	startDir = s.StartDir
+	if startDir == "" {
+		startDir = filepath.Dir(s.Exe)
+	}
+	if u.Flatpak {
+		return flatpakSpawn, startDir, strings.TrimSpace("--host " + strconv.Quote(s.Exe) + " " + s.Args)
+	}
+	return s.Exe, startDir, s.Args
+}
+
+// AddShortcut adds the shortcut to u's shortcuts.vdf, or updates the one with
+// the same AppName. An existing file is backed up first, its path is returned
+func AddShortcut(u User, s Shortcut) (backup string, updated bool, err error) {
+	exe, startDir, options := s.command(u)
+	path := u.ShortcutsPath()
+
+	root := NewMap("")
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if root, err = ParseVDF(bytes.NewReader(data)); err != nil {
+			return "", false, fmt.Errorf("%s: %w", path, err)
+		}
+		backup = fmt.Sprintf("%s.bak.%d", path, time.Now().Unix())
+		if err := os.WriteFile(backup, data, 0644); err != nil {
+			return "", false, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return "", false, err
+	}
+
+	shortcuts := root.Child("shortcuts")
+	if shortcuts == nil {
+		shortcuts = NewMap("shortcuts")
+		root.Children = append(root.Children, shortcuts)
+	}
+
+	entry := findShortcut(shortcuts, s.AppName)
+	updated = entry != nil
+	if entry == nil {
+		entry = newShortcutEntry(nextIndex(shortcuts))
+		shortcuts.Children = append(shortcuts.Children, entry)
+	}
+	quotedExe := strconv.Quote(exe)
+	entry.Set(NewInt("appid", AppID(quotedExe, s.AppName)))
+	entry.Set(NewString("AppName", s.AppName))
+	entry.Set(NewString("Exe", quotedExe))
+	entry.Set(NewString("StartDir", strconv.Quote(startDir)))
+	entry.Set(NewString("icon", s.Icon))
+	entry.Set(NewString("LaunchOptions", options))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return backup, updated, err
+	}
+	var buf bytes.Buffer
+	if err := WriteVDF(&buf, root); err != nil {
+		return backup, updated, err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return backup, updated, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return backup, updated, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return backup, updated, nil
+}
+
+// findShortcut returns the shortcut entry named appName
+func findShortcut(shortcuts *Node, appName string) *Node {
+	for _, entry := range shortcuts.Children {
+		if name := entry.Child("AppName"); name != nil && name.String == appName {
+			return entry
+		}
+	}
+	return nil
+}
+
+// nextIndex is the key for a new shortcut, entries are keyed "0", "1", ...
+func nextIndex(shortcuts *Node) string {
+	next := 0
+	for _, entry := range shortcuts.Children {
+		if i, err := strconv.Atoi(entry.Key); err == nil && i >= next {
+			next = i + 1
+		}
+	}
+	return strconv.Itoa(next)
+}
+
+// newShortcutEntry has the fields Steam writes for a non-Steam game
+func newShortcutEntry(key string) *Node {
+	return NewMap(key,
+		NewInt("appid", 0),
+		NewString("AppName", ""),
+		NewString("Exe", ""),
+		NewString("StartDir", ""),
+		NewString("icon", ""),
+		NewString("ShortcutPath", ""),
+		NewString("LaunchOptions", ""),
+		NewInt("IsHidden", 0),
+		NewInt("AllowDesktopConfig", 1),
+		NewInt("AllowOverlay", 1),
+		NewInt("OpenVR", 0),
+		NewInt("Devkit", 0),
+		NewString("DevkitGameID", ""),
+		NewInt("DevkitOverrideAppID", 0),
+		NewInt("LastPlayTime", 0),
+		NewString("FlatpakAppID", ""),
+		NewMap("tags"),
+	)
+}
+
+// Running reports whether a Steam client process is running, it keeps its
+// own copy of the shortcuts and may write it back over ours
+func Running() bool {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join("/proc", e.Name(), "comm"))
+		if err == nil && strings.TrimSpace(string(comm)) == "steam" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package steam
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVDFRoundTrip(t *testing.T) {
+	root := NewMap("",
+		NewMap("shortcuts",
+			NewMap("0",
+				NewInt("appid", 0x80001234),
+				NewString("AppName", "Other Game"),
+				NewString("UnknownField", "kept"),
+				NewMap("tags", NewString("0", "favorite")),
+			),
+		),
+	)
+
+	var buf bytes.Buffer
+	if err := WriteVDF(&buf, root); err != nil {
+		t.Fatalf("WriteVDF() error: %v", err)
+	}
+	parsed, err := ParseVDF(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ParseVDF() error: %v", err)
+	}
+
+	var again bytes.Buffer
+	if err := WriteVDF(&again, parsed); err != nil {
+		t.Fatalf("WriteVDF() error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Errorf("round trip changed the file:\n%q\n%q", buf.Bytes(), again.Bytes())
+	}
+
+	entry := parsed.Child("shortcuts").Child("0")
+	if entry.Child("appname").String != "Other Game" || entry.Child("appid").Int != 0x80001234 {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if _, err := ParseVDF(bytes.NewReader([]byte{0x00, 's', 'h'})); err == nil {
+		t.Error("expected an error for a truncated file")
+	}
+}
+
+func TestAddShortcut(t *testing.T) {
+	home := t.TempDir()
+	userDir := filepath.Join(home, ".local", "share", "Steam", "userdata", "12345")
+	if err := os.MkdirAll(filepath.Join(userDir, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// ~/.steam/steam links to the same install
+	if err := os.MkdirAll(filepath.Join(home, ".steam"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(home, ".local", "share", "Steam"), filepath.Join(home, ".steam", "steam")); err != nil {
+		t.Fatal(err)
+	}
+
+	users := FindUsers(home)
+	if len(users) != 1 || users[0].ID != "12345" || users[0].Flatpak {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+	user := users[0]
+
+	// Existing shortcuts must survive
+	existing := NewMap("", NewMap("shortcuts", NewMap("0", NewString("AppName", "Other Game"))))
+	var buf bytes.Buffer
+	_ = WriteVDF(&buf, existing)
+	if err := os.WriteFile(user.ShortcutsPath(), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	shortcut := Shortcut{AppName: "Turtle WoW", Exe: "/usr/bin/turtlectl", Args: "launch", Icon: "/icons/turtle-wow.png"}
+	backup, updated, err := AddShortcut(user, shortcut)
+	if err != nil {
+		t.Fatalf("AddShortcut() error: %v", err)
+	}
+	if updated || backup == "" {
+		t.Errorf("expected a new shortcut and a backup, got updated=%v backup=%q", updated, backup)
+	}
+
+	shortcut.Exe = "/opt/turtlectl"
+	if _, updated, err = AddShortcut(user, shortcut); err != nil || !updated {
+		t.Fatalf("expected the shortcut to be updated: %v, %v", updated, err)
+	}
+
+	data, err := os.ReadFile(user.ShortcutsPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := ParseVDF(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseVDF() error: %v", err)
+	}
+	shortcuts := root.Child("shortcuts")
+	if len(shortcuts.Children) != 2 {
+		t.Fatalf("expected 2 shortcuts, got %d", len(shortcuts.Children))
+	}
+	entry := shortcuts.Child("1")
+	if entry.Child("Exe").String != `"/opt/turtlectl"` || entry.Child("StartDir").String != `"/opt"` ||
+		entry.Child("LaunchOptions").String != "launch" || entry.Child("appid").Int != AppID(`"/opt/turtlectl"`, "Turtle WoW") {
+		t.Errorf("unexpected shortcut: %+v", entry.Children)
+	}
+}
+
+func TestShortcutCommandFlatpak(t *testing.T) {
+	s := Shortcut{AppName: "Turtle WoW", Exe: "/usr/bin/turtlectl", Args: "launch"}
+	exe, startDir, options := s.command(User{Flatpak: true})
+	if exe != flatpakSpawn || startDir != "/usr/bin" || options != `--host "/usr/bin/turtlectl" launch` {
+		t.Errorf("unexpected Flatpak command: %s %s %s", exe, startDir, options)
+	}
+}
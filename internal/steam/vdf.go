@@ -0,0 +1,161 @@
+package steam
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Binary VDF value types, as written by Steam to shortcuts.vdf
+const (
+	typeMap    byte = 0x00
+	typeString byte = 0x01
+	typeInt    byte = 0x02
+	typeEnd    byte = 0x08
+)
+
+// ErrInvalidVDF is returned for files that aren't binary VDF
+var ErrInvalidVDF = errors.New("invalid binary VDF")
+
+// Node is a binary VDF entry. Children keep their order so unknown fields
+// written by Steam survive a rewrite
+type Node struct {
+	Key      string
+	Type     byte
+	String   string
+	Int      uint32
+	Children []*Node
+}
+
+// NewMap returns a map node
+func NewMap(key string, children ...*Node) *Node {
+	return &Node{Key: key, Type: typeMap, Children: children}
+}
+
+// NewString returns a string node
+func NewString(key, value string) *Node {
+	return &Node{Key: key, Type: typeString, String: value}
+}
+
+// NewInt returns an int32 node
+func NewInt(key string, value uint32) *Node {
+	return &Node{Key: key, Type: typeInt, Int: value}
+}
+
+// Child returns the direct child named key, matched case-insensitively like
+// Steam does (it writes both "appname" and "AppName")
+func (n *Node) Child(key string) *Node {
+	for _, c := range n.Children {
+		if strings.EqualFold(c.Key, key) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Set replaces the child with the same key, or appends it
+func (n *Node) Set(child *Node) {
+	for i, c := range n.Children {
+		if strings.EqualFold(c.Key, child.Key) {
+			child.Key = c.Key
+			n.Children[i] = child
+			return
+		}
+	}
+	n.Children = append(n.Children, child)
+}
+
+// ParseVDF reads a binary VDF document, returning its top-level entries
+// wrapped in an unnamed map
+func ParseVDF(r io.Reader) (*Node, error) {
+	br := bufio.NewReader(r)
+	root := NewMap("")
+	if err := parseChildren(br, root, true); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// parseChildren reads entries into parent until its end marker, or EOF at
+// the top level
+func parseChildren(r *bufio.Reader, parent *Node, top bool) error {
+	for {
+		t, err := r.ReadByte()
+		if err == io.EOF && top {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unexpected end of file", ErrInvalidVDF)
+		}
+		if t == typeEnd {
+			return nil
+		}
+
+		key, err := readCString(r)
+		if err != nil {
+			return err
+		}
+
+		node := &Node{Key: key, Type: t}
+		switch t {
+		case typeMap:
+			if err := parseChildren(r, node, false); err != nil {
+				return err
+			}
+		case typeString:
+			if node.String, err = readCString(r); err != nil {
+				return err
+			}
+		case typeInt:
+			var buf [4]byte
+			if _, err := io.ReadFull(r, buf[:]); err != nil {
+				return fmt.Errorf("%w: truncated int %q", ErrInvalidVDF, key)
+			}
+			node.Int = binary.LittleEndian.Uint32(buf[:])
+		default:
+			return fmt.Errorf("%w: unknown type 0x%02x for %q", ErrInvalidVDF, t, key)
+		}
+		parent.Children = append(parent.Children, node)
+	}
+}
+
+func readCString(r *bufio.Reader) (string, error) {
+	s, err := r.ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("%w: unterminated string", ErrInvalidVDF)
+	}
+	return s[:len(s)-1], nil
+}
+
+// WriteVDF writes the children of root as a binary VDF document
+func WriteVDF(w io.Writer, root *Node) error {
+	var buf bytes.Buffer
+	for _, c := range root.Children {
+		writeNode(&buf, c)
+	}
+	buf.WriteByte(typeEnd)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeNode(buf *bytes.Buffer, n *Node) {
+	buf.WriteByte(n.Type)
+	buf.WriteString(n.Key)
+	buf.WriteByte(0)
+	switch n.Type {
+	case typeMap:
+		for _, c := range n.Children {
+			writeNode(buf, c)
+		}
+		buf.WriteByte(typeEnd)
+	case typeString:
+		buf.WriteString(n.String)
+		buf.WriteByte(0)
+	case typeInt:
+		_ = binary.Write(buf, binary.LittleEndian, n.Int)
+	}
+}
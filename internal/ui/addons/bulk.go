@@ -0,0 +1,104 @@
+package addons
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkOp runs one operation over the selected addons, one at a time so the
+// progress view can show which addon is being worked on
+type bulkOp struct {
+	verb   string // Progress verb, e.g. "Updating"
+	past   string // Summary verb, e.g. "updated"
+	names  []string
+	done   int
+	ok     []string // Addons the operation succeeded for
+	failed []string // "name: error" for the others
+	run    func(name string) error
+
+	undoable bool // Successful addons can be restored with the Restore key
+}
+
+// bulkStepMsg reports that the current addon of the bulk operation is done
+type bulkStepMsg struct {
+	err error
+}
+
+// progress returns the progress line for the addon being worked on
+func (b *bulkOp) progress() string {
+	return fmt.Sprintf("%s %s (%d/%d)...", b.verb, b.names[b.done], b.done+1, len(b.names))
+}
+
+// step runs the operation on the current addon
+func (b *bulkOp) step() tea.Cmd {
+	name := b.names[b.done]
+	return func() tea.Msg {
+		return bulkStepMsg{err: b.run(name)}
+	}
+}
+
+// summary describes the outcome once every addon is done
+func (b *bulkOp) summary() string {
+	msg := fmt.Sprintf("%d addon(s) %s", len(b.ok), b.past)
+	if len(b.failed) > 0 {
+		msg += fmt.Sprintf(", %d failed: %s", len(b.failed), strings.Join(b.failed, "; "))
+	}
+	return msg
+}
+
+// selectedNames returns the selected addons in name order
+func (m Model) selectedNames() []string {
+	names := make([]string, 0, len(m.selected))
+	for name := range m.selected {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// startBulk switches to the progress view and runs b on its first addon
+func (m Model) startBulk(b *bulkOp) (tea.Model, tea.Cmd) {
+	m.bulk = b
+	m.state = viewProgress
+	m.progressMsg = b.progress()
+	m.errorMsg = ""
+	m.statusMsg = ""
+	return m, b.step()
+}
+
+// bulkStepDone records the outcome for the current addon and moves on to the
+// next one, or wraps up and clears the selection after the last
+func (m Model) bulkStepDone(msg bulkStepMsg) (tea.Model, tea.Cmd) {
+	b := m.bulk
+	if b == nil {
+		return m, nil
+	}
+	name := b.names[b.done]
+	if msg.err != nil {
+		b.failed = append(b.failed, name+": "+msg.err.Error())
+	} else {
+		b.ok = append(b.ok, name)
+	}
+	b.done++
+
+	if b.done < len(b.names) {
+		m.progressMsg = b.progress()
+		return m, b.step()
+	}
+
+	m.bulk = nil
+	m.selected = make(map[string]bool)
+	if b.undoable && len(b.ok) > 0 {
+		m.lastRemoved = b.ok
+	}
+	m.state = viewList
+	if len(b.failed) > 0 {
+		m.setError(b.summary())
+	} else {
+		m.statusMsg = b.summary()
+	}
+	return m, m.loadAddons
+}
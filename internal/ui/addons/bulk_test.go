@@ -0,0 +1,50 @@
+package addons
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBulkOpRunsEachAddonThenClearsSelection(t *testing.T) {
+	var ran []string
+	m := Model{selected: map[string]bool{"pfUI": true, "Bagnon": true, "pfQuest": true}}
+	b := &bulkOp{
+		verb:     "Removing",
+		past:     "removed",
+		names:    m.selectedNames(),
+		undoable: true,
+		run: func(name string) error {
+			ran = append(ran, name)
+			if name == "pfUI" {
+				return errors.New("locked")
+			}
+			return nil
+		},
+	}
+
+	model, cmd := m.startBulk(b)
+	m = model.(Model)
+	if m.state != viewProgress || m.progressMsg != "Removing Bagnon (1/3)..." {
+		t.Fatalf("state %v, progress %q", m.state, m.progressMsg)
+	}
+	for m.bulk != nil {
+		model, cmd = m.bulkStepDone(cmd().(bulkStepMsg))
+		m = model.(Model)
+	}
+
+	if len(ran) != 3 || ran[0] != "Bagnon" || ran[2] != "pfUI" {
+		t.Errorf("ran %v, want name order", ran)
+	}
+	if len(m.selected) != 0 {
+		t.Errorf("selection not cleared: %v", m.selected)
+	}
+	if m.state != viewList {
+		t.Errorf("state = %v, want list", m.state)
+	}
+	if want := "2 addon(s) removed, 1 failed: pfUI: locked"; m.errorMsg != want {
+		t.Errorf("errorMsg = %q, want %q", m.errorMsg, want)
+	}
+	if len(m.lastRemoved) != 2 {
+		t.Errorf("lastRemoved = %v, want the 2 removed addons", m.lastRemoved)
+	}
+}
@@ -0,0 +1,27 @@
+package addons
+
+import (
+	"strings"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+// errorDetailHint follows the one-line error in the list views
+const errorDetailHint = " (e: details)"
+
+// renderErrorDetail renders the full text of the last error, wrapped to
+// width, shared by the addons and explore TUIs
+func renderErrorDetail(text string, width int) string {
+	var s strings.Builder
+	s.WriteString(styles.Title.Render("Last Error") + "\n\n")
+
+	body := styles.ErrorText
+	if width > 4 {
+		// Account for App padding (2 on each side)
+		body = body.Width(width - 4)
+	}
+	s.WriteString(body.Render(text) + "\n\n")
+	s.WriteString(styles.MutedText.Render("See the full log with: turtlectl logs") + "\n\n")
+	s.WriteString(styles.Help.Render("e/esc:close"))
+	return s.String()
+}
@@ -1,7 +1,13 @@
 package addons
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
 	"sort"
 	"strings"
 
@@ -21,9 +27,14 @@ type exploreState int
 const (
 	exploreViewList exploreState = iota
 	exploreViewDetails
+	exploreViewConfirmUninstall
 	exploreViewInstalling
+	exploreViewHelp
+	exploreViewError
 )
 
+var errNoDisplay = errors.New("no graphical session to open a browser in")
+
 // sortOrder represents the current sort mode
 type sortOrder int
 
@@ -31,6 +42,9 @@ const (
 	sortByName sortOrder = iota
 	sortByStars
 	sortByRecent
+	sortByActive
+
+	sortOrderCount = 4
 )
 
 func (s sortOrder) String() string {
@@ -39,6 +53,8 @@ func (s sortOrder) String() string {
 		return "Stars"
 	case sortByRecent:
 		return "Recent"
+	case sortByActive:
+		return "Active"
 	default:
 		return "Name"
 	}
@@ -78,18 +94,35 @@ func (i exploreItem) Description() string {
 		parts = append(parts, styles.FormatStars(i.addon.Stars))
 	}
 
+	if i.addon.Archived {
+		parts = append(parts, styles.FormatArchivedBadge())
+	} else if i.addon.IsAbandoned() {
+		parts = append(parts, styles.FormatAbandonedBadge())
+	}
+
+	if source := sourceLabel(i.addon); source != "" {
+		parts = append(parts, "via "+source)
+	}
+
 	if i.addon.Description != "" {
 		// Truncate description if too long
-		desc := i.addon.Description
-		if len(desc) > 60 {
-			desc = desc[:57] + "..."
-		}
-		parts = append(parts, desc)
+		parts = append(parts, styles.Truncate(i.addon.Description, 60))
 	}
 
 	return strings.Join(parts, " | ")
 }
 
+// sourceLabel returns a short name for a non-default registry source
+func sourceLabel(addon wiki.WikiAddon) string {
+	if addon.Source == "" || addon.Source == wiki.RegistryURL {
+		return ""
+	}
+	if u, err := url.Parse(addon.Source); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return addon.Source
+}
+
 func (i exploreItem) FilterValue() string {
 	return i.addon.Name + " " + i.addon.Author + " " + i.addon.Description
 }
@@ -98,11 +131,16 @@ func (i exploreItem) FilterValue() string {
 type ExploreKeyMap struct {
 	Install   key.Binding
 	Uninstall key.Binding
+	UpdateAll key.Binding
 	Details   key.Binding
 	Order     key.Binding
+	Browser   key.Binding
 	Refresh   key.Binding
 	Quit      key.Binding
 	Back      key.Binding
+	Confirm   key.Binding
+	Help      key.Binding
+	Error     key.Binding
 }
 
 // DefaultExploreKeyMap returns the default key bindings
@@ -116,6 +154,10 @@ func DefaultExploreKeyMap() ExploreKeyMap {
 			key.WithKeys("u"),
 			key.WithHelp("u", "uninstall"),
 		),
+		UpdateAll: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "update all"),
+		),
 		Details: key.NewBinding(
 			key.WithKeys("d"),
 			key.WithHelp("d", "details"),
@@ -124,6 +166,10 @@ func DefaultExploreKeyMap() ExploreKeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "order"),
 		),
+		Browser: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "open in browser"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -136,6 +182,18 @@ func DefaultExploreKeyMap() ExploreKeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back"),
 		),
+		Confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "confirm"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
+		Error: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "last error details"),
+		),
 	}
 }
 
@@ -153,6 +211,7 @@ type ExploreModel struct {
 	// Data
 	wikiAddons    []wiki.WikiAddon
 	selectedAddon *wiki.WikiAddon
+	confirmFrom   exploreState // View to return to when an uninstall is canceled
 	registryInfo  wiki.RegistryInfo
 
 	// Status
@@ -160,14 +219,26 @@ type ExploreModel struct {
 	refreshing  bool
 	statusMsg   string
 	errorMsg    string
+	lastError   string // full text of the last failure, kept after errorMsg is cleared
 	progressMsg string
 
 	// Sorting
-	sortOrder sortOrder
+	sortOrder   sortOrder
+	sortedItems [sortOrderCount][]list.Item // List items per order, built on first use
+
+	// Preferences saved in the cache dir, the filter is restored on first load
+	cacheDir      string
+	restoreFilter string
+
+	// Cancels in-flight git operations on quit
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewExploreModel creates a new explore TUI model
-func NewExploreModel(manager *addons.Manager, registry *wiki.Registry, refresh bool) ExploreModel {
+// Installs run under ctx and are canceled when the user quits
+// The last sort order and filter are restored from cacheDir
+func NewExploreModel(ctx context.Context, manager *addons.Manager, registry *wiki.Registry, cacheDir string, refresh bool) ExploreModel {
 	// Setup list
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
@@ -189,15 +260,23 @@ func NewExploreModel(manager *addons.Manager, registry *wiki.Registry, refresh b
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
 
+	ctx, cancel := context.WithCancel(ctx)
+	state := loadUIState(cacheDir)
+
 	return ExploreModel{
-		addonManager: manager,
-		registry:     registry,
-		list:         l,
-		spinner:      s,
-		keys:         DefaultExploreKeyMap(),
-		state:        exploreViewList,
-		loading:      true,
-		refreshing:   refresh,
+		addonManager:  manager,
+		registry:      registry,
+		list:          l,
+		spinner:       s,
+		keys:          DefaultExploreKeyMap(),
+		state:         exploreViewList,
+		loading:       true,
+		refreshing:    refresh,
+		sortOrder:     parseSortOrder(state.ExploreSort),
+		cacheDir:      cacheDir,
+		restoreFilter: state.ExploreFilter,
+		ctx:           ctx,
+		cancel:        cancel,
 	}
 }
 
@@ -216,16 +295,27 @@ type exploreAddonsLoadedMsg struct {
 	err          error
 }
 
+type exploreBrowserMsg struct {
+	url string
+	err error
+}
+
 type exploreInstallCompleteMsg struct {
-	success bool
-	name    string
-	err     error
+	success  bool
+	name     string
+	archived bool // The repository is read-only, the addon won't get updates
+	err      error
+}
+
+type exploreUpdateAllCompleteMsg struct {
+	result *addons.UpdateAllResult
 }
 
 type exploreUninstallCompleteMsg struct {
-	success bool
-	name    string
-	err     error
+	success    bool
+	name       string
+	backupPath string
+	err        error
 }
 
 // loadAddonsCmd loads addons from the registry
@@ -238,8 +328,7 @@ func (m ExploreModel) loadAddonsCmd() tea.Cmd {
 		}
 
 		// Mark installed addons
-		installedURLs := m.getInstalledURLs()
-		wiki.MarkInstalled(addons, installedURLs)
+		wiki.MarkInstalled(addons, m.addonManager.InstalledURLs())
 
 		// Sort alphabetically
 		wiki.SortAddons(addons)
@@ -251,43 +340,56 @@ func (m ExploreModel) loadAddonsCmd() tea.Cmd {
 	}
 }
 
-// getInstalledURLs returns a map of installed addon URLs
-func (m ExploreModel) getInstalledURLs() map[string]bool {
-	urls := make(map[string]bool)
-	installed, err := m.addonManager.ListInstalled()
-	if err != nil {
-		return urls
-	}
-	for _, addon := range installed {
-		if addon.GitURL != "" {
-			urls[addon.GitURL] = true
-			// Also add normalized version
-			normalized := strings.TrimSuffix(addon.GitURL, ".git")
-			urls[normalized] = true
-		}
-	}
-	return urls
+// setError shows a failure in the footer and keeps its full text for the
+// error detail view
+func (m *ExploreModel) setError(msg string) {
+	m.errorMsg = msg
+	m.lastError = msg
 }
 
 // installAddon installs the selected addon
-func (m ExploreModel) installAddon(url string) tea.Cmd {
+func (m ExploreModel) installAddon(addon *wiki.WikiAddon) tea.Cmd {
+	url, archived := addon.InstallURL(), addon.Archived
 	return func() tea.Msg {
-		result, err := m.addonManager.Install(url, nil)
+		result, err := m.addonManager.Install(m.ctx, url, nil)
 		if err != nil {
 			return exploreInstallCompleteMsg{success: false, err: err}
 		}
-		return exploreInstallCompleteMsg{success: true, name: result.Name}
+		return exploreInstallCompleteMsg{success: true, name: result.Name, archived: archived}
+	}
+}
+
+// openInBrowser opens link with xdg-open
+// Without a graphical session the URL is only reported back so it can be copied
+func openInBrowser(link string) tea.Cmd {
+	return func() tea.Msg {
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return exploreBrowserMsg{url: link, err: errNoDisplay}
+		}
+		cmd := exec.Command("xdg-open", link)
+		if err := cmd.Start(); err != nil {
+			return exploreBrowserMsg{url: link, err: err}
+		}
+		go func() { _ = cmd.Wait() }()
+		return exploreBrowserMsg{url: link}
+	}
+}
+
+// updateAllAddons updates every tracked addon
+func (m ExploreModel) updateAllAddons() tea.Cmd {
+	return func() tea.Msg {
+		return exploreUpdateAllCompleteMsg{result: m.addonManager.UpdateAll(m.ctx, addons.UpdateConcurrency())}
 	}
 }
 
 // uninstallAddon uninstalls the selected addon
 func (m ExploreModel) uninstallAddon(name string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.addonManager.Remove(name, false)
+		result, err := m.addonManager.Remove(name, true) // Always backup
 		if err != nil {
 			return exploreUninstallCompleteMsg{success: false, name: name, err: err}
 		}
-		return exploreUninstallCompleteMsg{success: true, name: name}
+		return exploreUninstallCompleteMsg{success: true, name: name, backupPath: result.BackupPath}
 	}
 }
 
@@ -307,6 +409,8 @@ func (m ExploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle global keys
 		if key.Matches(msg, m.keys.Quit) {
 			if m.state == exploreViewList {
+				m.saveState()
+				m.cancel()
 				return m, tea.Quit
 			}
 			m.state = exploreViewList
@@ -316,6 +420,10 @@ func (m ExploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if key.Matches(msg, m.keys.Back) {
+			if m.state == exploreViewConfirmUninstall {
+				m.state = m.confirmFrom
+				return m, nil
+			}
 			if m.state != exploreViewList {
 				m.state = exploreViewList
 				m.errorMsg = ""
@@ -331,6 +439,18 @@ func (m ExploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.updateList(msg)
 			case exploreViewDetails:
 				return m.updateDetails(msg)
+			case exploreViewConfirmUninstall:
+				return m.updateConfirmUninstall(msg)
+			case exploreViewHelp:
+				if key.Matches(msg, m.keys.Help) {
+					m.state = exploreViewList
+				}
+				return m, nil
+			case exploreViewError:
+				if key.Matches(msg, m.keys.Error) {
+					m.state = exploreViewList
+				}
+				return m, nil
 			}
 		}
 
@@ -338,18 +458,19 @@ func (m ExploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.refreshing = false
 		if msg.err != nil {
-			m.errorMsg = msg.err.Error()
+			m.setError(msg.err.Error())
 			return m, nil
 		}
 		m.wikiAddons = msg.addons
 		m.registryInfo = msg.registryInfo
 
-		// Update list items
-		items := make([]list.Item, len(msg.addons))
-		for i, addon := range msg.addons {
-			items[i] = exploreItem{addon: addon}
+		// Installed state changed or the registry was refreshed, re-sort lazily
+		m.sortedItems = [sortOrderCount][]list.Item{}
+		m.list.SetItems(m.itemsFor(m.sortOrder))
+		if m.restoreFilter != "" {
+			m.list.SetFilterText(m.restoreFilter)
+			m.restoreFilter = ""
 		}
-		m.list.SetItems(items)
 
 		// Update title with counts
 		m.list.Title = fmt.Sprintf("Explore Addons (%d available", len(msg.addons))
@@ -360,26 +481,54 @@ func (m ExploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case exploreBrowserMsg:
+		switch {
+		case errors.Is(msg.err, errNoDisplay):
+			m.statusMsg = "No browser available, visit " + msg.url
+		case msg.err != nil:
+			m.statusMsg = "Failed to open browser, visit " + msg.url
+		default:
+			m.statusMsg = "Opened " + msg.url
+		}
+		return m, nil
+
 	case exploreInstallCompleteMsg:
 		m.state = exploreViewList
 		m.loading = false
 		if msg.err != nil {
-			m.errorMsg = "Install failed: " + msg.err.Error()
+			m.setError("Install failed: " + msg.err.Error())
 		} else {
 			m.statusMsg = fmt.Sprintf("Installed %s successfully", msg.name)
+			if msg.archived {
+				m.statusMsg = fmt.Sprintf("Installed %s, but its repository is archived and won't get updates", msg.name)
+			}
 			// Reload to update installed status
 			m.loading = true
 			return m, m.loadAddonsCmd()
 		}
 		return m, nil
 
+	case exploreUpdateAllCompleteMsg:
+		m.state = exploreViewList
+		result := msg.result
+		if result.Failed > 0 {
+			m.setError(fmt.Sprintf("Updated %d, failed %d: %s", result.Updated, result.Failed, strings.Join(result.Errors, "; ")))
+		} else {
+			m.statusMsg = fmt.Sprintf("Updated %d addons, %d skipped", result.Updated, result.Skipped)
+		}
+		// Reload, updates may have re-cloned or dropped addons
+		return m, m.loadAddonsCmd()
+
 	case exploreUninstallCompleteMsg:
 		m.state = exploreViewList
 		m.loading = false
 		if msg.err != nil {
-			m.errorMsg = "Uninstall failed: " + msg.err.Error()
+			m.setError("Uninstall failed: " + msg.err.Error())
 		} else {
 			m.statusMsg = fmt.Sprintf("Uninstalled %s successfully", msg.name)
+			if msg.backupPath != "" {
+				m.statusMsg = fmt.Sprintf("Uninstalled %s (backup: %s)", msg.name, msg.backupPath)
+			}
 			// Reload to update installed status
 			m.loading = true
 			return m, m.loadAddonsCmd()
@@ -411,6 +560,18 @@ func (m ExploreModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	switch {
+	case key.Matches(msg, m.keys.Help):
+		m.state = exploreViewHelp
+		return m, nil
+
+	case key.Matches(msg, m.keys.Error):
+		if m.lastError == "" {
+			m.statusMsg = "No errors"
+			return m, nil
+		}
+		m.state = exploreViewError
+		return m, nil
+
 	case key.Matches(msg, m.keys.Install):
 		if item, ok := m.list.SelectedItem().(exploreItem); ok {
 			if item.addon.IsInstalled {
@@ -424,7 +585,7 @@ func (m ExploreModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.errorMsg = ""
 			m.statusMsg = ""
 			return m, tea.Batch(
-				m.installAddon(item.addon.URL),
+				m.installAddon(&item.addon),
 				m.spinner.Tick,
 			)
 		}
@@ -437,51 +598,42 @@ func (m ExploreModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.selectedAddon = &item.addon
-			m.state = exploreViewInstalling
-			m.loading = true
-			m.progressMsg = "Uninstalling " + item.addon.Name + "..."
+			m.confirmFrom = exploreViewList
+			m.state = exploreViewConfirmUninstall
 			m.errorMsg = ""
 			m.statusMsg = ""
-			return m, tea.Batch(
-				m.uninstallAddon(item.addon.Name),
-				m.spinner.Tick,
-			)
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.UpdateAll):
+		m.state = exploreViewInstalling
+		m.loading = true
+		m.progressMsg = "Updating all tracked addons..."
+		m.errorMsg = ""
+		m.statusMsg = ""
+		return m, tea.Batch(
+			m.updateAllAddons(),
+			m.spinner.Tick,
+		)
+
 	case key.Matches(msg, m.keys.Details):
 		if item, ok := m.list.SelectedItem().(exploreItem); ok {
 			m.selectedAddon = &item.addon
 			m.state = exploreViewDetails
+			m.statusMsg = ""
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.Order):
-		// Cycle through sort orders: Name -> Stars -> Recent -> Name
-		m.sortOrder = (m.sortOrder + 1) % 3
-
-		// Sort the addons
-		switch m.sortOrder {
-		case sortByStars:
-			sort.Slice(m.wikiAddons, func(i, j int) bool {
-				return m.wikiAddons[i].Stars > m.wikiAddons[j].Stars
-			})
-		case sortByRecent:
-			sort.Slice(m.wikiAddons, func(i, j int) bool {
-				return m.wikiAddons[i].AddedAt.After(m.wikiAddons[j].AddedAt)
-			})
-		default: // sortByName
-			sort.Slice(m.wikiAddons, func(i, j int) bool {
-				return m.wikiAddons[i].Name < m.wikiAddons[j].Name
-			})
+	case key.Matches(msg, m.keys.Browser):
+		if item, ok := m.list.SelectedItem().(exploreItem); ok {
+			return m, openInBrowser(item.addon.URL)
 		}
+		return m, nil
 
-		// Rebuild list items
-		items := make([]list.Item, len(m.wikiAddons))
-		for i, addon := range m.wikiAddons {
-			items[i] = exploreItem{addon: addon}
-		}
-		m.list.SetItems(items)
+	case key.Matches(msg, m.keys.Order):
+		// Cycle through sort orders: Name -> Stars -> Recent -> Active -> Name
+		m.sortOrder = (m.sortOrder + 1) % sortOrderCount
+		m.list.SetItems(m.itemsFor(m.sortOrder))
 
 		m.statusMsg = "Sorted by " + m.sortOrder.String()
 		return m, nil
@@ -503,11 +655,48 @@ func (m ExploreModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// itemsFor returns the list items in the given order
+// Each order is sorted once per load, toggling back to it reuses the slice
+func (m *ExploreModel) itemsFor(order sortOrder) []list.Item {
+	if items := m.sortedItems[order]; items != nil {
+		return items
+	}
+
+	// m.wikiAddons stays sorted by name, ties keep that order
+	addons := slices.Clone(m.wikiAddons)
+	switch order {
+	case sortByStars:
+		sort.SliceStable(addons, func(i, j int) bool {
+			return addons[i].Stars > addons[j].Stars
+		})
+	case sortByRecent:
+		sort.SliceStable(addons, func(i, j int) bool {
+			return addons[i].AddedAt.After(addons[j].AddedAt)
+		})
+	case sortByActive:
+		wiki.SortAddonsByActivity(addons)
+	}
+
+	items := make([]list.Item, len(addons))
+	for i, addon := range addons {
+		items[i] = exploreItem{addon: addon}
+	}
+	m.sortedItems[order] = items
+	return items
+}
+
 func (m ExploreModel) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Details):
 		m.state = exploreViewList
 		m.selectedAddon = nil
+		m.statusMsg = ""
+		return m, nil
+
+	case key.Matches(msg, m.keys.Browser):
+		if m.selectedAddon != nil {
+			return m, openInBrowser(m.selectedAddon.URL)
+		}
 		return m, nil
 
 	case key.Matches(msg, m.keys.Install):
@@ -516,7 +705,7 @@ func (m ExploreModel) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.loading = true
 			m.progressMsg = "Installing " + m.selectedAddon.Name + "..."
 			return m, tea.Batch(
-				m.installAddon(m.selectedAddon.URL),
+				m.installAddon(m.selectedAddon),
 				m.spinner.Tick,
 			)
 		}
@@ -524,13 +713,9 @@ func (m ExploreModel) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, m.keys.Uninstall):
 		if m.selectedAddon != nil && m.selectedAddon.IsInstalled {
-			m.state = exploreViewInstalling
-			m.loading = true
-			m.progressMsg = "Uninstalling " + m.selectedAddon.Name + "..."
-			return m, tea.Batch(
-				m.uninstallAddon(m.selectedAddon.Name),
-				m.spinner.Tick,
-			)
+			m.confirmFrom = exploreViewDetails
+			m.state = exploreViewConfirmUninstall
+			m.statusMsg = ""
 		}
 		return m, nil
 	}
@@ -538,6 +723,29 @@ func (m ExploreModel) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m ExploreModel) updateConfirmUninstall(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		if m.selectedAddon == nil {
+			m.state = exploreViewList
+			return m, nil
+		}
+		m.state = exploreViewInstalling
+		m.loading = true
+		m.progressMsg = "Uninstalling " + m.selectedAddon.Name + "..."
+		return m, tea.Batch(
+			m.uninstallAddon(m.selectedAddon.Name),
+			m.spinner.Tick,
+		)
+
+	case msg.String() == "n":
+		m.state = m.confirmFrom
+		return m, nil
+	}
+
+	return m, nil
+}
+
 // View renders the UI
 func (m ExploreModel) View() string {
 	var content string
@@ -547,13 +755,28 @@ func (m ExploreModel) View() string {
 		content = m.viewList()
 	case exploreViewDetails:
 		content = m.viewDetails()
+	case exploreViewConfirmUninstall:
+		content = m.viewConfirmUninstall()
 	case exploreViewInstalling:
 		content = m.viewInstalling()
+	case exploreViewHelp:
+		content = m.viewHelp()
+	case exploreViewError:
+		content = renderErrorDetail(m.lastError, m.width)
 	}
 
 	return styles.App.Render(content)
 }
 
+func (m ExploreModel) viewHelp() string {
+	k := m.keys
+	return renderHelp("Explore Help", []helpSection{
+		{"Addons", []key.Binding{k.Install, k.Uninstall, k.UpdateAll, k.Details, k.Browser}},
+		{"List", []key.Binding{k.Order, k.Refresh, helpNavigate, helpPage, helpFilter, helpClear}},
+		{"General", []key.Binding{k.Error, k.Help, k.Back, k.Quit}},
+	})
+}
+
 // renderFooter renders a unified status bar with status on left and keybindings on right
 func (m ExploreModel) renderFooter() string {
 	// Left side: compact status info
@@ -561,13 +784,13 @@ func (m ExploreModel) renderFooter() string {
 
 	// Append status/error message if any
 	if m.errorMsg != "" {
-		left += " | " + m.errorMsg
+		left += " | " + m.errorMsg + errorDetailHint
 	} else if m.statusMsg != "" {
 		left += " | " + m.statusMsg
 	}
 
 	// Right side: key bindings
-	right := "/filter i:inst u:uninst d:info o:sort r:sync q:quit"
+	right := "/filter i:inst u:uninst U:upd all d:info b:web o:sort r:sync ?:help q:quit"
 
 	// Account for App padding (2 on each side = 4 total horizontal)
 	availableWidth := m.width - 4
@@ -636,7 +859,15 @@ func (m ExploreModel) viewDetails() string {
 	if a.IsInstalled {
 		nameLine += "  " + styles.FormatInstalledBadge()
 	}
+	if a.Archived {
+		nameLine += "  " + styles.FormatArchivedBadge()
+	} else if a.IsAbandoned() {
+		nameLine += "  " + styles.FormatAbandonedBadge()
+	}
 	s.WriteString(nameLine + "\n\n")
+	if a.Archived {
+		s.WriteString(styles.FormatWarning("The repository is archived (read-only), this addon won't get updates") + "\n\n")
+	}
 
 	// Details
 	if a.Author != "" {
@@ -645,6 +876,9 @@ func (m ExploreModel) viewDetails() string {
 	if a.Version != "" {
 		s.WriteString(fmt.Sprintf("Version:     %s\n", a.Version))
 	}
+	if a.Ref != "" {
+		s.WriteString(fmt.Sprintf("Ref:         %s\n", a.Ref))
+	}
 	if a.Stars > 0 {
 		s.WriteString(fmt.Sprintf("Stars:       %s\n", styles.FormatStars(a.Stars)))
 	}
@@ -652,26 +886,60 @@ func (m ExploreModel) viewDetails() string {
 		s.WriteString(fmt.Sprintf("Category:    %s\n", a.Category))
 	}
 	s.WriteString(fmt.Sprintf("URL:         %s\n", a.URL))
+	if sourceLabel(*a) != "" {
+		s.WriteString(fmt.Sprintf("Registry:    %s\n", a.Source))
+	}
 
 	if a.Description != "" {
-		s.WriteString(fmt.Sprintf("\nDescription:\n%s\n", a.Description))
+		desc := a.Description
+		if m.width > 20 {
+			// Wrap on cell width so long non-ASCII descriptions stay readable
+			desc = lipgloss.NewStyle().Width(m.width - 4).Render(desc)
+		}
+		s.WriteString(fmt.Sprintf("\nDescription:\n%s\n", desc))
 	}
 
+	s.WriteString("\n")
 	if !a.AddedAt.IsZero() {
-		s.WriteString(fmt.Sprintf("\nAdded:       %s\n", a.AddedAt.Format("2006-01-02")))
+		s.WriteString(fmt.Sprintf("Added:       %s\n", a.AddedAt.Format("2006-01-02")))
+	}
+	if a.LastCommit.IsZero() {
+		s.WriteString("Last commit: unknown\n")
+	} else {
+		s.WriteString(fmt.Sprintf("Last commit: %s\n", a.LastCommit.Format("2006-01-02")))
+	}
+
+	if m.statusMsg != "" {
+		s.WriteString("\n" + styles.MutedText.Render(m.statusMsg) + "\n")
 	}
 
 	// Help
 	s.WriteString("\n")
 	if a.IsInstalled {
-		s.WriteString(styles.Help.Render("u:uninstall  esc/d:back  q:quit"))
+		s.WriteString(styles.Help.Render("u:uninstall  b:browser  esc/d:back  q:quit"))
 	} else {
-		s.WriteString(styles.Help.Render("i:install  esc/d:back  q:quit"))
+		s.WriteString(styles.Help.Render("i:install  b:browser  esc/d:back  q:quit"))
 	}
 
 	return s.String()
 }
 
+func (m ExploreModel) viewConfirmUninstall() string {
+	var s strings.Builder
+
+	name := ""
+	if m.selectedAddon != nil {
+		name = m.selectedAddon.Name
+	}
+
+	s.WriteString(styles.Title.Render("Uninstall Addon") + "\n\n")
+	s.WriteString(fmt.Sprintf("Are you sure you want to uninstall %s?\n", styles.Highlighted.Render(name)))
+	s.WriteString("A backup will be created (restore it with turtlectl addons restore).\n\n")
+	s.WriteString(styles.Help.Render("y:confirm  n/esc:cancel"))
+
+	return s.String()
+}
+
 func (m ExploreModel) viewInstalling() string {
 	return m.spinner.View() + " " + m.progressMsg
 }
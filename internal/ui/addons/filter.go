@@ -0,0 +1,100 @@
+package addons
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// filterSep separates the fields of addonItem.FilterValue
+const filterSep = "\t"
+
+// Fields of addonItem.FilterValue, in order. The title comes first so the
+// list highlights fuzzy matches in the right place
+const (
+	filterFieldTitle = iota
+	filterFieldName
+	filterFieldAuthor
+	filterFieldVersion
+	filterFieldCount
+)
+
+// filterAddons is the installed list's list.FilterFunc. Words like
+// author:shagu, version:2 or name:quest only keep addons whose author
+// contains, version starts with, or name/title contains the value (case
+// insensitive, a leading v in versions is ignored). The remaining words are
+// fuzzy matched as usual
+func filterAddons(term string, targets []string) []list.Rank {
+	var fields [filterFieldCount][]string
+	var rest []string
+	for _, word := range strings.Fields(strings.ToLower(term)) {
+		prefix, value, ok := strings.Cut(word, ":")
+		field := -1
+		if ok && value != "" {
+			switch prefix {
+			case "name", "title":
+				field = filterFieldName
+			case "author", "by":
+				field = filterFieldAuthor
+			case "version", "v":
+				field = filterFieldVersion
+			}
+		}
+		if field < 0 {
+			rest = append(rest, word)
+			continue
+		}
+		fields[field] = append(fields[field], value)
+	}
+
+	// Narrow down by field first, keeping the original indexes
+	var kept []int
+	for i, target := range targets {
+		if matchesFields(target, fields) {
+			kept = append(kept, i)
+		}
+	}
+
+	if len(rest) == 0 {
+		ranks := make([]list.Rank, len(kept))
+		for i, index := range kept {
+			ranks[i] = list.Rank{Index: index}
+		}
+		return ranks
+	}
+
+	keptTargets := make([]string, len(kept))
+	for i, index := range kept {
+		keptTargets[i] = targets[index]
+	}
+	ranks := list.DefaultFilter(strings.Join(rest, " "), keptTargets)
+	for i := range ranks {
+		ranks[i].Index = kept[ranks[i].Index]
+	}
+	return ranks
+}
+
+// matchesFields reports whether a FilterValue matches every field:value word
+func matchesFields(target string, fields [filterFieldCount][]string) bool {
+	values := strings.Split(strings.ToLower(target), filterSep)
+	if len(values) != filterFieldCount {
+		return false
+	}
+	for _, want := range fields[filterFieldName] {
+		if !strings.Contains(values[filterFieldName], want) && !strings.Contains(values[filterFieldTitle], want) {
+			return false
+		}
+	}
+	for _, want := range fields[filterFieldAuthor] {
+		if !strings.Contains(values[filterFieldAuthor], want) {
+			return false
+		}
+	}
+	for _, want := range fields[filterFieldVersion] {
+		version := strings.TrimPrefix(values[filterFieldVersion], "v")
+		if !strings.HasPrefix(version, strings.TrimPrefix(want, "v")) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package addons
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bnema/turtlectl/internal/addons"
+)
+
+func TestFilterAddons(t *testing.T) {
+	installed := []*addons.Addon{
+		{Name: "pfQuest", Title: "pfQuest", Author: "Shagu", Version: "7.2.1"},
+		{Name: "pfUI", Title: "pfUI", Author: "shagu", Version: "5.4"},
+		{Name: "Bagnon", Title: "Bagnon", Author: "Tuller", Version: "v2.13"},
+		{Name: "Atlas", Author: "", Version: ""},
+	}
+	targets := make([]string, len(installed))
+	for i, a := range installed {
+		targets[i] = addonItem{addon: a}.FilterValue()
+	}
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"author:shagu", []string{"pfQuest", "pfUI"}},
+		{"by:TULLER", []string{"Bagnon"}},
+		{"version:2", []string{"Bagnon"}},
+		{"version:v7", []string{"pfQuest"}},
+		{"author:shagu quest", []string{"pfQuest"}},
+		{"name:pf version:5", []string{"pfUI"}},
+		{"tuller", []string{"Bagnon"}},
+		{"author:nobody", nil},
+		{"author:", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			var got []string
+			for _, rank := range filterAddons(tt.term, targets) {
+				got = append(got, installed[rank.Index].Name)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("filterAddons(%q) = %v, want %v", tt.term, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package addons
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+// helpSection is a titled group of key bindings in the help screen
+type helpSection struct {
+	title    string
+	bindings []key.Binding
+}
+
+// Bindings handled by bubbles/list, listed in the help screens
+var (
+	helpNavigate = key.NewBinding(key.WithHelp("↑/k ↓/j", "move"))
+	helpPage     = key.NewBinding(key.WithHelp("←/h →/l", "previous/next page"))
+	helpFilter   = key.NewBinding(key.WithHelp("/", "filter"))
+	helpClear    = key.NewBinding(key.WithHelp("esc", "clear filter"))
+)
+
+// renderHelp renders a full key binding reference, shared by the addons and
+// explore TUIs
+func renderHelp(title string, sections []helpSection) string {
+	width := 0
+	for _, section := range sections {
+		for _, b := range section.bindings {
+			width = max(width, lipgloss.Width(b.Help().Key))
+		}
+	}
+
+	var s strings.Builder
+	s.WriteString(styles.Title.Render(title) + "\n")
+	for _, section := range sections {
+		s.WriteString("\n" + styles.Subtitle.Render(section.title) + "\n")
+		for _, b := range section.bindings {
+			help := b.Help()
+			keys := help.Key + strings.Repeat(" ", width-lipgloss.Width(help.Key))
+			s.WriteString("  " + styles.Highlighted.Render(keys) + "  " + styles.NormalText.Render(help.Desc) + "\n")
+		}
+	}
+	s.WriteString("\n" + styles.Help.Render("?/esc:close"))
+	return s.String()
+}
@@ -1,7 +1,10 @@
 package addons
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +33,8 @@ type InstallModel struct {
 	manager     *addons.Manager
 	gitURL      string
 	addonName   string
+	opts        addons.InstallOptions
+	progressCh  chan gitProgressMsg
 
 	steps       []uiprogress.Step
 	currentStep int
@@ -40,10 +45,16 @@ type InstallModel struct {
 	err    error
 	result *addons.InstallResult
 	width  int
+	stall  stallWatch
+
+	// Cancels in-flight git operations on quit
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewInstallModel creates a new addon installation progress model
-func NewInstallModel(manager *addons.Manager, gitURL, addonName string) InstallModel {
+// The clone runs under ctx and is canceled when the user quits
+func NewInstallModel(ctx context.Context, manager *addons.Manager, gitURL, addonName string, opts addons.InstallOptions) InstallModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
@@ -60,6 +71,12 @@ func NewInstallModel(manager *addons.Manager, gitURL, addonName string) InstallM
 		{Name: "Parsing metadata", State: uiprogress.StatePending},
 		{Name: "Finalizing", State: uiprogress.StatePending},
 	}
+	if addons.IsLocalSource(gitURL) {
+		steps[installStepValidate].Name = "Validating source"
+		steps[installStepClone].Name = "Copying files"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
 
 	return InstallModel{
 		spinner:     s,
@@ -67,16 +84,21 @@ func NewInstallModel(manager *addons.Manager, gitURL, addonName string) InstallM
 		manager:     manager,
 		gitURL:      gitURL,
 		addonName:   addonName,
+		opts:        opts,
+		progressCh:  make(chan gitProgressMsg, 16),
 		steps:       steps,
 		currentStep: 0,
 		width:       80,
+		stall:       newStallWatch(),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
 // Messages
 type (
 	installStepDoneMsg struct{ step int }
-	installProgressMsg struct {
+	gitProgressMsg     struct {
 		percent float64
 		detail  string
 	}
@@ -90,6 +112,7 @@ func (m InstallModel) Init() tea.Cmd {
 		m.spinner.Tick,
 		tea.WindowSize(),
 		m.startValidation(),
+		checkStall(),
 	)
 }
 
@@ -102,13 +125,60 @@ func (m InstallModel) startValidation() tea.Cmd {
 }
 
 func (m InstallModel) startClone() tea.Cmd {
-	return func() tea.Msg {
-		result, err := m.manager.Install(m.gitURL, nil)
+	clone := func() tea.Msg {
+		defer close(m.progressCh)
+		result, err := m.manager.InstallWithOptions(m.ctx, m.gitURL, m.opts, progressChanWriter{m.progressCh})
 		if err != nil {
 			return installErrorMsg{err: err}
 		}
 		return installCompleteMsg{result: result}
 	}
+	return tea.Batch(clone, m.waitForProgress())
+}
+
+// waitForProgress delivers the next progress update from the clone
+func (m InstallModel) waitForProgress() tea.Cmd {
+	return waitForGitProgress(m.progressCh)
+}
+
+// waitForGitProgress delivers the next progress update sent on ch
+func waitForGitProgress(ch <-chan gitProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// progressPercentRegex matches the percentage in git progress lines
+var progressPercentRegex = regexp.MustCompile(`(\d+)%`)
+
+// progressChanWriter turns git progress output into gitProgressMsg updates
+type progressChanWriter struct {
+	ch chan<- gitProgressMsg
+}
+
+func (w progressChanWriter) Write(p []byte) (int, error) {
+	// Git redraws progress with \r, keep only the latest line
+	lines := strings.FieldsFunc(string(p), func(r rune) bool { return r == '\r' || r == '\n' })
+	if len(lines) == 0 {
+		return len(p), nil
+	}
+	line := strings.TrimSpace(lines[len(lines)-1])
+
+	msg := gitProgressMsg{detail: line}
+	if match := progressPercentRegex.FindStringSubmatch(line); match != nil {
+		msg.percent, _ = strconv.ParseFloat(match[1], 64)
+	}
+
+	// Never block the clone on a slow UI
+	select {
+	case w.ch <- msg:
+	default:
+	}
+	return len(p), nil
 }
 
 // Update handles messages
@@ -116,6 +186,7 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			m.cancel()
 			return m, tea.Quit
 		}
 
@@ -133,7 +204,14 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.progressBar = progressModel.(progress.Model)
 		return m, cmd
 
+	case stallCheckMsg:
+		if m.done {
+			return m, nil
+		}
+		return m, checkStall()
+
 	case installStepDoneMsg:
+		m.stall.touch()
 		m.steps[msg.step].State = uiprogress.StateComplete
 		m.subProgress = 0
 		m.subDetail = ""
@@ -146,10 +224,14 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case installProgressMsg:
-		m.subProgress = msg.percent
+	case gitProgressMsg:
+		m.stall.touch()
 		m.subDetail = msg.detail
-		return m, m.progressBar.SetPercent(msg.percent / 100)
+		if msg.percent == 0 {
+			return m, m.waitForProgress()
+		}
+		m.subProgress = msg.percent
+		return m, tea.Batch(m.progressBar.SetPercent(msg.percent/100), m.waitForProgress())
 
 	case installCompleteMsg:
 		// Mark all steps as complete
@@ -200,12 +282,20 @@ func (m InstallModel) View() string {
 		b.WriteString("\n")
 
 		// Show sub-progress bar for clone step
-		if i == installStepClone && step.State == uiprogress.StateInProgress && m.subProgress > 0 {
+		if i == installStepClone && step.State == uiprogress.StateInProgress {
 			if m.subDetail != "" {
 				subDetailStyle := lipgloss.NewStyle().Foreground(styles.Muted)
 				b.WriteString(indent + "    " + subDetailStyle.Render(m.subDetail) + "\n")
 			}
-			b.WriteString(indent + "  " + m.progressBar.View() + "\n")
+			if m.subProgress > 0 {
+				b.WriteString(indent + "  " + m.progressBar.View() + "\n")
+			}
+		}
+	}
+
+	if !m.done {
+		if hint := m.stall.view(); hint != "" {
+			b.WriteString("\n" + indent + hint + "\n")
 		}
 	}
 
@@ -215,6 +305,19 @@ func (m InstallModel) View() string {
 			b.WriteString(uiprogress.FormatError(m.err.Error()))
 		} else if m.result != nil {
 			b.WriteString(uiprogress.FormatSuccess(fmt.Sprintf("Installed %s", m.result.Title)))
+			if len(m.result.Folders) > 1 {
+				b.WriteString("\n")
+				b.WriteString("  " + styles.MutedText.Render("Addons: "+strings.Join(m.result.Folders, ", ")))
+			}
+			if len(m.result.MissingDependencies) > 0 {
+				b.WriteString("\n")
+				b.WriteString(uiprogress.FormatWarning(fmt.Sprintf("Missing dependencies: %s",
+					strings.Join(m.result.MissingDependencies, ", "))))
+			}
+			if m.result.InterfaceWarning != "" {
+				b.WriteString("\n")
+				b.WriteString(uiprogress.FormatWarning(m.result.InterfaceWarning))
+			}
 		}
 		b.WriteString("\n")
 	}
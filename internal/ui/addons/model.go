@@ -1,7 +1,9 @@
 package addons
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/bnema/turtlectl/internal/addons"
@@ -22,12 +24,15 @@ const (
 	viewConfirmRemove
 	viewProgress
 	viewInfo
+	viewHelp
+	viewError
 )
 
 // addonItem implements list.Item for bubbles/list
 type addonItem struct {
 	addon     *addons.Addon
 	hasUpdate bool
+	selected  bool // Marked for a bulk remove or update
 }
 
 func (i addonItem) Title() string {
@@ -35,6 +40,9 @@ func (i addonItem) Title() string {
 	if i.addon.Title != "" && i.addon.Title != i.addon.Name {
 		name = i.addon.Title
 	}
+	if i.selected {
+		return "✓ " + name
+	}
 	return name
 }
 
@@ -48,8 +56,10 @@ func (i addonItem) Description() string {
 		parts = append(parts, "by "+i.addon.Author)
 	}
 
-	// Determine status: default > tracked > untracked
-	if addons.IsDefaultAddon(i.addon.Name) {
+	// Determine status: disabled > default > tracked > untracked
+	if i.addon.Disabled {
+		parts = append(parts, styles.FormatAddonStatusEx(styles.AddonStatusDisabled))
+	} else if addons.IsDefaultAddon(i.addon.Name) {
 		parts = append(parts, styles.FormatAddonStatusEx(styles.AddonStatusDefault))
 	} else if i.addon.GitURL != "" {
 		parts = append(parts, styles.FormatAddonStatusEx(styles.AddonStatusTracked))
@@ -57,6 +67,10 @@ func (i addonItem) Description() string {
 		parts = append(parts, styles.FormatAddonStatusEx(styles.AddonStatusUntracked))
 	}
 
+	if i.addon.PinnedCommit != "" {
+		parts = append(parts, styles.FormatPinnedBadge())
+	}
+
 	// Show update indicator
 	if i.hasUpdate {
 		parts = append(parts, styles.FormatUpdateAvailable())
@@ -65,8 +79,14 @@ func (i addonItem) Description() string {
 	return strings.Join(parts, " | ")
 }
 
+// FilterValue holds the title, name, author and version, see filterAddons
 func (i addonItem) FilterValue() string {
-	return i.addon.Name + " " + i.addon.Title
+	values := make([]string, filterFieldCount)
+	values[filterFieldTitle] = i.Title()
+	values[filterFieldName] = i.addon.Name
+	values[filterFieldAuthor] = i.addon.Author
+	values[filterFieldVersion] = i.addon.Version
+	return strings.Join(values, filterSep)
 }
 
 // KeyMap defines keyboard shortcuts
@@ -77,6 +97,11 @@ type KeyMap struct {
 	UpdateAll key.Binding
 	Info      key.Binding
 	Repair    key.Binding
+	Restore   key.Binding
+	Toggle    key.Binding
+	Select    key.Binding
+	Outdated  key.Binding
+	Error     key.Binding
 	Quit      key.Binding
 	Back      key.Binding
 	Confirm   key.Binding
@@ -110,6 +135,26 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "repair"),
 		),
+		Restore: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "undo remove"),
+		),
+		Toggle: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "disable/enable"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		),
+		Outdated: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "only updatable"),
+		),
+		Error: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "last error details"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -145,12 +190,29 @@ type Model struct {
 	statusMsg        string
 	errorMsg         string
 	progressMsg      string
+	installHint      string          // why the install field isn't a valid source, empty when it is
+	lastError        string          // full text of the last failure, kept after errorMsg is cleared
 	updatesAvailable map[string]bool // addon name -> has update
 	checkingUpdates  bool
+	forceCheck       bool // fetch every addon, ignoring the update check cache
+
+	// Cancels in-flight git operations on quit
+	ctx         context.Context
+	cancel      context.CancelFunc
+	lastRemoved []string // addons removed last, restorable with the Restore key
+
+	noGame      bool            // the game directory doesn't exist yet
+	installed   []*addons.Addon // addons from the last load, see setItems
+	onlyUpdates bool            // list only addons with an update available
+
+	selected map[string]bool // addon name -> marked for a bulk operation
+	bulk     *bulkOp         // bulk operation in progress
 }
 
 // NewModel creates a new TUI model
-func NewModel(manager *addons.Manager) Model {
+// With forceCheck every tracked addon is fetched, ignoring the update check cache
+// Git operations run under ctx and are canceled when the user quits
+func NewModel(ctx context.Context, manager *addons.Manager, forceCheck bool) Model {
 	// Setup list
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
@@ -165,6 +227,7 @@ func NewModel(manager *addons.Manager) Model {
 	l.Styles.Title = styles.Title
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
+	l.Filter = filterAddons
 	l.SetShowHelp(true)
 
 	// Setup text input
@@ -178,6 +241,8 @@ func NewModel(manager *addons.Manager) Model {
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
 
+	ctx, cancel := context.WithCancel(ctx)
+
 	return Model{
 		manager:          manager,
 		list:             l,
@@ -186,7 +251,11 @@ func NewModel(manager *addons.Manager) Model {
 		keys:             DefaultKeyMap(),
 		state:            viewList,
 		updatesAvailable: make(map[string]bool),
+		selected:         make(map[string]bool),
 		checkingUpdates:  true,
+		forceCheck:       forceCheck,
+		ctx:              ctx,
+		cancel:           cancel,
 	}
 }
 
@@ -194,6 +263,7 @@ func NewModel(manager *addons.Manager) Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.loadAddons,
+		m.loadCachedUpdates,
 		m.checkUpdates,
 		m.spinner.Tick,
 	)
@@ -201,26 +271,40 @@ func (m Model) Init() tea.Cmd {
 
 // loadAddons loads addons from the manager
 func (m Model) loadAddons() tea.Msg {
+	if !m.manager.GameDirExists() {
+		return addonsLoadedMsg{noGame: true}
+	}
 	addons, err := m.manager.ListInstalled()
 	if err != nil {
 		return errMsg{err}
 	}
-	return addonsLoadedMsg{addons}
+	return addonsLoadedMsg{addons: addons}
 }
 
-// checkUpdates checks all tracked addons for available updates
+// loadCachedUpdates reads the last update check for instant display
+func (m Model) loadCachedUpdates() tea.Msg {
+	if m.forceCheck {
+		return nil
+	}
+	return updatesCheckedMsg{results: m.manager.CachedUpdates(), cached: true}
+}
+
+// checkUpdates checks tracked addons for available updates in the background,
+// fetching only those whose cached check is stale unless forced
 func (m Model) checkUpdates() tea.Msg {
-	results := m.manager.CheckAllUpdates()
-	return updatesCheckedMsg{results}
+	results := m.manager.CheckAllUpdatesCached(m.ctx, m.forceCheck)
+	return updatesCheckedMsg{results: results}
 }
 
 // Messages
 type addonsLoadedMsg struct {
 	addons []*addons.Addon
+	noGame bool // the game isn't installed, there's no AddOns folder to list
 }
 
 type updatesCheckedMsg struct {
 	results []addons.CheckUpdatesResult
+	cached  bool // From the update check cache, a refresh is still running
 }
 
 type errMsg struct {
@@ -234,6 +318,13 @@ type operationCompleteMsg struct {
 	message string
 }
 
+type removeCompleteMsg struct {
+	name       string
+	backupPath string // empty when the backup failed
+	backupErr  error
+	err        error
+}
+
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -246,9 +337,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Typing a filter goes to the list, not to the shortcuts
+		if m.state == viewList && m.list.FilterState() == list.Filtering && msg.Type != tea.KeyCtrlC {
+			var cmd tea.Cmd
+			m.list, cmd = m.list.Update(msg)
+			return m, cmd
+		}
+
 		// Handle global keys
 		if key.Matches(msg, m.keys.Quit) {
 			if m.state == viewList {
+				m.cancel()
 				return m, tea.Quit
 			}
 			m.state = viewList
@@ -276,18 +375,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateConfirmRemove(msg)
 		case viewInfo:
 			return m.updateInfo(msg)
+		case viewHelp:
+			return m.updateHelp(msg)
+		case viewError:
+			if key.Matches(msg, m.keys.Error) {
+				m.state = viewList
+			}
+			return m, nil
 		}
 
 	case addonsLoadedMsg:
-		items := make([]list.Item, len(msg.addons))
-		for i, addon := range msg.addons {
-			items[i] = addonItem{addon: addon, hasUpdate: m.updatesAvailable[addon.Name]}
+		m.noGame = msg.noGame
+		m.installed = msg.addons
+		selected := make(map[string]bool)
+		for _, addon := range msg.addons {
+			if m.selected[addon.Name] {
+				selected[addon.Name] = true
+			}
 		}
-		m.list.SetItems(items)
-		return m, nil
+		// Forget selected addons that are gone
+		m.selected = selected
+		return m, m.setItems()
 
 	case updatesCheckedMsg:
-		m.checkingUpdates = false
+		if msg.cached && !m.checkingUpdates {
+			// The refresh finished first, its results are newer
+			return m, nil
+		}
+		if !msg.cached {
+			m.checkingUpdates = false
+		}
+		hadUpdates := len(m.updatesAvailable) > 0
 		m.updatesAvailable = make(map[string]bool)
 		updateCount := 0
 		for _, result := range msg.results {
@@ -296,15 +414,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				updateCount++
 			}
 		}
-		// Refresh list items to show update indicators
+		// Refresh list items to show (or clear) update indicators
 		if updateCount > 0 {
 			m.statusMsg = fmt.Sprintf("%d update(s) available", updateCount)
 			return m, m.loadAddons
 		}
+		if hadUpdates {
+			return m, m.loadAddons
+		}
 		return m, nil
 
 	case errMsg:
-		m.errorMsg = msg.err.Error()
+		m.setError(msg.err.Error())
 		m.state = viewList
 		return m, nil
 
@@ -312,11 +433,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = string(msg)
 		return m, nil
 
+	case removeCompleteMsg:
+		m.state = viewList
+		if msg.err != nil {
+			m.setError(msg.err.Error())
+			return m, m.loadAddons
+		}
+		if msg.backupPath == "" {
+			// Nothing to undo from
+			m.lastRemoved = nil
+			m.setError(fmt.Sprintf("Addon %s removed, but its backup failed: %v", msg.name, msg.backupErr))
+			return m, m.loadAddons
+		}
+		m.lastRemoved = []string{msg.name}
+		m.statusMsg = fmt.Sprintf("Addon %s removed (backup: %s, z to undo)", msg.name, msg.backupPath)
+		return m, m.loadAddons
+
+	case bulkStepMsg:
+		return m.bulkStepDone(msg)
+
 	case operationCompleteMsg:
 		if msg.success {
 			m.statusMsg = msg.message
 		} else {
-			m.errorMsg = msg.message
+			m.setError(msg.message)
 		}
 		m.state = viewList
 		return m, m.loadAddons
@@ -335,15 +475,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// setError shows a failure in the list view and keeps its full text for the
+// error detail view
+func (m *Model) setError(msg string) {
+	m.errorMsg = msg
+	m.lastError = msg
+}
+
+// setItems fills the list from the loaded addons, only those with an update
+// available when onlyUpdates is set, and shows the update count in the title
+func (m *Model) setItems() tea.Cmd {
+	var items []list.Item
+	updates := 0
+	for _, addon := range m.installed {
+		hasUpdate := m.updatesAvailable[addon.Name]
+		if hasUpdate {
+			updates++
+		}
+		if m.onlyUpdates && !hasUpdate {
+			continue
+		}
+		items = append(items, addonItem{addon: addon, hasUpdate: hasUpdate, selected: m.selected[addon.Name]})
+	}
+
+	m.list.Title = "Addons"
+	if m.onlyUpdates {
+		m.list.Title = "Addons with updates"
+	}
+	if updates > 0 {
+		m.list.Title += fmt.Sprintf(" (%d update(s))", updates)
+	}
+	return m.list.SetItems(items)
+}
+
 func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Nothing to act on until the game is installed
+	if m.noGame && !key.Matches(msg, m.keys.Help) {
+		return m, nil
+	}
+
 	switch {
+	case key.Matches(msg, m.keys.Help):
+		m.state = viewHelp
+		return m, nil
+
+	case key.Matches(msg, m.keys.Error):
+		if m.lastError == "" {
+			m.statusMsg = "No errors"
+			return m, nil
+		}
+		m.state = viewError
+		return m, nil
+
+	case key.Matches(msg, m.keys.Outdated):
+		m.onlyUpdates = !m.onlyUpdates
+		return m, m.setItems()
+
 	case key.Matches(msg, m.keys.Install):
 		m.state = viewInstall
 		m.textInput.Focus()
 		m.textInput.SetValue("")
+		m.installHint = ""
 		return m, textinput.Blink
 
+	case key.Matches(msg, m.keys.Select):
+		if item, ok := m.list.SelectedItem().(addonItem); ok {
+			item.selected = !item.selected
+			if item.selected {
+				m.selected[item.addon.Name] = true
+			} else {
+				delete(m.selected, item.addon.Name)
+			}
+			cmd := m.list.SetItem(m.list.GlobalIndex(), item)
+			m.list.CursorDown()
+			return m, cmd
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Remove):
+		if len(m.selected) > 0 {
+			m.selectedAddon = nil
+			m.state = viewConfirmRemove
+			return m, nil
+		}
 		if item, ok := m.list.SelectedItem().(addonItem); ok {
 			m.selectedAddon = item.addon
 			m.state = viewConfirmRemove
@@ -351,6 +565,17 @@ func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case key.Matches(msg, m.keys.Update):
+		if len(m.selected) > 0 {
+			return m.startBulk(&bulkOp{
+				verb:  "Updating",
+				past:  "updated",
+				names: m.selectedNames(),
+				run: func(name string) error {
+					_, err := m.manager.Update(m.ctx, name, nil)
+					return err
+				},
+			})
+		}
 		if item, ok := m.list.SelectedItem().(addonItem); ok {
 			m.selectedAddon = item.addon
 			m.state = viewProgress
@@ -375,6 +600,41 @@ func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = viewProgress
 		m.progressMsg = "Repairing addon database..."
 		return m, m.repairAddons
+
+	case key.Matches(msg, m.keys.Restore):
+		if len(m.lastRemoved) == 0 {
+			m.statusMsg = "Nothing to undo"
+			return m, nil
+		}
+		names := m.lastRemoved
+		m.lastRemoved = nil
+		if len(names) > 1 {
+			return m.startBulk(&bulkOp{
+				verb:  "Restoring",
+				past:  "restored",
+				names: names,
+				run: func(name string) error {
+					_, err := m.manager.Restore(name, "", false)
+					return err
+				},
+			})
+		}
+		name := names[0]
+		m.state = viewProgress
+		m.progressMsg = "Restoring " + name + "..."
+		return m, m.restoreAddon(name)
+
+	case key.Matches(msg, m.keys.Toggle):
+		if item, ok := m.list.SelectedItem().(addonItem); ok {
+			m.state = viewProgress
+			if item.addon.Disabled {
+				m.progressMsg = "Enabling " + item.addon.Name + "..."
+			} else {
+				m.progressMsg = "Disabling " + item.addon.Name + "..."
+			}
+			return m, m.toggleAddon(item.addon)
+		}
+		return m, nil
 	}
 
 	// Update list
@@ -386,8 +646,8 @@ func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) updateInstall(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
 	case tea.KeyEnter:
-		url := m.textInput.Value()
-		if url == "" {
+		url := strings.TrimSpace(m.textInput.Value())
+		if url == "" || m.installHint != "" {
 			return m, nil
 		}
 		m.state = viewProgress
@@ -401,12 +661,55 @@ func (m Model) updateInstall(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.textInput, cmd = m.textInput.Update(msg)
+	m.installHint = checkInstallSource(strings.TrimSpace(m.textInput.Value()))
 	return m, cmd
 }
 
+// checkInstallSource returns why src can't be installed, or "" if it looks
+// like a git URL (optionally with an @ref suffix) or a local folder or zip
+func checkInstallSource(src string) string {
+	if src == "" || addons.IsLocalSource(src) {
+		return ""
+	}
+	if _, err := addons.NormalizeRepoURL(src); err != nil {
+		return "Not a repository page, copy the URL of the repository itself"
+	}
+	if err := addons.ValidateGitURL(src); err != nil {
+		if !strings.Contains(src, "://") && strings.Count(src, "/") >= 2 {
+			return "Missing https:// in front of the URL"
+		}
+		return "Not a git URL: use https://, git@, git:// or ssh://, or a local folder or zip"
+	}
+
+	repoURL, _ := addons.SplitGitRef(src)
+	if strings.HasPrefix(repoURL, "git@") {
+		return ""
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return "Not a valid URL"
+	}
+	if len(strings.Split(strings.Trim(u.Path, "/"), "/")) < 2 {
+		return "Missing the repository path, e.g. https://" + u.Host + "/user/addon"
+	}
+	return ""
+}
+
 func (m Model) updateConfirmRemove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Confirm):
+		if m.selectedAddon == nil && len(m.selected) > 0 {
+			return m.startBulk(&bulkOp{
+				verb:     "Removing",
+				past:     "removed (backups created, z to undo)",
+				names:    m.selectedNames(),
+				undoable: true,
+				run: func(name string) error {
+					_, err := m.manager.Remove(name, true) // Always backup
+					return err
+				},
+			})
+		}
 		if m.selectedAddon != nil {
 			m.state = viewProgress
 			m.progressMsg = "Removing " + m.selectedAddon.Name + "..."
@@ -432,31 +735,71 @@ func (m Model) updateInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Help) {
+		m.state = viewList
+	}
+	return m, nil
+}
+
 // Commands
 
 func (m Model) installAddon(url string) tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.manager.Install(url, nil)
+		result, err := m.manager.Install(m.ctx, url, nil)
 		if err != nil {
 			return operationCompleteMsg{false, err.Error()}
 		}
-		return operationCompleteMsg{true, fmt.Sprintf("Addon %s installed successfully", result.Name)}
+		name := result.Name
+		if len(result.Folders) > 1 {
+			name = strings.Join(result.Folders, ", ")
+		}
+		if len(result.MissingDependencies) > 0 {
+			return operationCompleteMsg{true, fmt.Sprintf("Addon %s installed (missing dependencies: %s)",
+				name, strings.Join(result.MissingDependencies, ", "))}
+		}
+		return operationCompleteMsg{true, fmt.Sprintf("Addon %s installed successfully", name)}
 	}
 }
 
 func (m Model) removeAddon(name string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.manager.Remove(name, true) // Always backup
+		result, err := m.manager.Remove(name, true) // Always backup
+		if err != nil {
+			return removeCompleteMsg{name: name, err: err}
+		}
+		return removeCompleteMsg{name: name, backupPath: result.BackupPath, backupErr: result.BackupErr}
+	}
+}
+
+func (m Model) restoreAddon(name string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.manager.Restore(name, "", false)
 		if err != nil {
 			return operationCompleteMsg{false, err.Error()}
 		}
-		return operationCompleteMsg{true, "Addon removed (backup created)"}
+		return operationCompleteMsg{true, fmt.Sprintf("Addon %s restored from backup %s", result.Name, result.Timestamp)}
+	}
+}
+
+func (m Model) toggleAddon(addon *addons.Addon) tea.Cmd {
+	return func() tea.Msg {
+		if addon.Disabled {
+			if err := m.manager.Enable(addon.Name); err != nil {
+				return operationCompleteMsg{false, err.Error()}
+			}
+			return operationCompleteMsg{true, fmt.Sprintf("Addon %s enabled", addon.Name)}
+		}
+		if err := m.manager.Disable(addon.Name); err != nil {
+			return operationCompleteMsg{false, err.Error()}
+		}
+		return operationCompleteMsg{true, fmt.Sprintf("Addon %s disabled", addon.Name)}
 	}
 }
 
 func (m Model) updateAddon(name string) tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.manager.Update(name, nil)
+		result, err := m.manager.Update(m.ctx, name, nil)
 		if err != nil {
 			return operationCompleteMsg{false, err.Error()}
 		}
@@ -468,7 +811,7 @@ func (m Model) updateAddon(name string) tea.Cmd {
 }
 
 func (m Model) updateAllAddons() tea.Msg {
-	result := m.manager.UpdateAll()
+	result := m.manager.UpdateAll(m.ctx, addons.UpdateConcurrency())
 	if result.Failed > 0 {
 		return operationCompleteMsg{false, fmt.Sprintf("Updated %d, failed %d: %v", result.Updated, result.Failed, result.Errors)}
 	}
@@ -485,8 +828,9 @@ func (m Model) repairAddons() tea.Msg {
 		return operationCompleteMsg{true, "No issues found"}
 	}
 
-	msg := fmt.Sprintf("Fixed %d issues: %d orphaned, %d untracked, %d corrupted",
-		result.IssuesFound, len(result.OrphanedEntries), len(result.UntrackedAddons), len(result.CorruptedRepos))
+	msg := fmt.Sprintf("Fixed %d issues: %d orphaned, %d untracked, %d corrupted, %d conflicts",
+		result.IssuesFound, len(result.OrphanedEntries), len(result.UntrackedAddons), len(result.CorruptedRepos),
+		len(result.FolderConflicts))
 	return operationCompleteMsg{true, msg}
 }
 
@@ -505,6 +849,10 @@ func (m Model) View() string {
 		content = m.viewProgress()
 	case viewInfo:
 		content = m.viewInfo()
+	case viewHelp:
+		content = m.viewHelp()
+	case viewError:
+		content = renderErrorDetail(m.lastError, m.width)
 	}
 
 	return styles.App.Render(content)
@@ -513,30 +861,75 @@ func (m Model) View() string {
 func (m Model) viewList() string {
 	var s strings.Builder
 
+	if m.noGame {
+		return m.viewNoGame()
+	}
+
 	s.WriteString(m.list.View())
+	if m.onlyUpdates && len(m.list.Items()) == 0 && !m.checkingUpdates {
+		s.WriteString("\n" + styles.FormatSuccess("All up to date"))
+	}
 
 	// Status/error messages
 	if m.checkingUpdates {
 		s.WriteString("\n" + m.spinner.View() + " " + styles.MutedText.Render("Checking for updates..."))
 	} else if m.errorMsg != "" {
-		s.WriteString("\n" + styles.FormatError(m.errorMsg))
+		s.WriteString("\n" + styles.FormatError(m.errorMsg) + styles.MutedText.Render(errorDetailHint))
 	} else if m.statusMsg != "" {
 		s.WriteString("\n" + styles.FormatSuccess(m.statusMsg))
 	}
 
 	// Help
-	help := "\n" + styles.Help.Render("i:install  d:remove  u:update  U:update all  r:repair  ?:help  q:quit")
+	help := "\n" + styles.Help.Render("i:install  space:select  o:only updatable  d:remove  u:update  U:update all  r:repair  z:undo  x:disable  /:filter (author: version:)  ?:help  q:quit")
+	if len(m.selected) > 0 {
+		help = "\n" + styles.Help.Render(fmt.Sprintf("%d selected: d/u act on all, space to unselect", len(m.selected))) + help
+	}
 	s.WriteString(help)
 
 	return s.String()
 }
 
+// viewNoGame is the empty state shown when the game directory doesn't exist
+func (m Model) viewNoGame() string {
+	var s strings.Builder
+
+	s.WriteString(styles.Title.Render("Addons") + "\n\n")
+	s.WriteString(styles.FormatWarning("Turtle WoW isn't installed yet") + "\n\n")
+	s.WriteString(fmt.Sprintf("No game found in %s, install it first:\n\n", styles.Highlighted.Render(m.manager.GetGameDir())))
+	s.WriteString("  " + styles.Bullet.String() + " turtlectl install   " + styles.MutedText.Render("download the launcher") + "\n")
+	s.WriteString("  " + styles.Bullet.String() + " turtlectl launch    " + styles.MutedText.Render("let it download the game files") + "\n\n")
+	s.WriteString(styles.MutedText.Render("Game installed elsewhere? Use --game-dir or the game_dir config setting.") + "\n")
+	s.WriteString(styles.MutedText.Render("Browsing addons works without a game: turtlectl addons explore") + "\n\n")
+	s.WriteString(styles.Help.Render("q:quit"))
+
+	return s.String()
+}
+
+func (m Model) viewHelp() string {
+	k := m.keys
+	filterFields := key.NewBinding(key.WithHelp("author: version: name:", "filter on a field, e.g. author:shagu"))
+	return renderHelp("Addons Help", []helpSection{
+		{"Addons", []key.Binding{k.Install, k.Remove, k.Update, k.UpdateAll, k.Info, k.Toggle, k.Restore, k.Repair}},
+		{"Selection", []key.Binding{k.Select, k.Outdated}},
+		{"Navigation", []key.Binding{helpNavigate, helpPage, helpFilter, filterFields, helpClear}},
+		{"General", []key.Binding{k.Error, k.Help, k.Back, k.Quit}},
+	})
+}
+
 func (m Model) viewInstall() string {
 	var s strings.Builder
 
 	s.WriteString(styles.Title.Render("Install Addon") + "\n\n")
 	s.WriteString("Enter git repository URL:\n\n")
-	s.WriteString(m.textInput.View() + "\n\n")
+	s.WriteString(m.textInput.View() + "\n")
+	value := strings.TrimSpace(m.textInput.Value())
+	if m.installHint != "" {
+		s.WriteString(styles.ErrorText.Render(m.installHint) + "\n\n")
+	} else if repoURL, _ := addons.NormalizeRepoURL(value); repoURL != value {
+		s.WriteString(styles.MutedText.Render("Installs "+repoURL) + "\n\n")
+	} else {
+		s.WriteString(styles.MutedText.Render("Add @ref to pin a branch, tag, or commit") + "\n\n")
+	}
 	s.WriteString(styles.Help.Render("enter:install  esc:cancel"))
 
 	return s.String()
@@ -545,6 +938,17 @@ func (m Model) viewInstall() string {
 func (m Model) viewConfirmRemove() string {
 	var s strings.Builder
 
+	if m.selectedAddon == nil && len(m.selected) > 0 {
+		s.WriteString(styles.Title.Render("Remove Addons") + "\n\n")
+		s.WriteString(fmt.Sprintf("Are you sure you want to remove %d addons?\n", len(m.selected)))
+		for _, name := range m.selectedNames() {
+			s.WriteString("  " + styles.Bullet.String() + " " + styles.Highlighted.Render(name) + "\n")
+		}
+		s.WriteString("Backups will be created (press z afterwards to undo).\n\n")
+		s.WriteString(styles.Help.Render("y:confirm  n/esc:cancel"))
+		return s.String()
+	}
+
 	name := ""
 	if m.selectedAddon != nil {
 		name = m.selectedAddon.Name
@@ -552,7 +956,7 @@ func (m Model) viewConfirmRemove() string {
 
 	s.WriteString(styles.Title.Render("Remove Addon") + "\n\n")
 	s.WriteString(fmt.Sprintf("Are you sure you want to remove %s?\n", styles.Highlighted.Render(name)))
-	s.WriteString("A backup will be created.\n\n")
+	s.WriteString("A backup will be created (press z afterwards to undo).\n\n")
 	s.WriteString(styles.Help.Render("y:confirm  n/esc:cancel"))
 
 	return s.String()
@@ -594,9 +998,27 @@ func (m Model) viewInfo() string {
 	if a.Notes != "" {
 		s.WriteString(fmt.Sprintf("Notes:     %s\n", a.Notes))
 	}
+	if a.Category != "" {
+		s.WriteString(fmt.Sprintf("Category:  %s\n", a.Category))
+	}
+	if a.Website != "" {
+		s.WriteString(fmt.Sprintf("Website:   %s\n", a.Website))
+	}
+	if a.Interface != "" {
+		s.WriteString(fmt.Sprintf("Interface: %s\n", a.Interface))
+	}
+	if a.InterfaceWarning != "" {
+		s.WriteString(styles.FormatWarning(a.InterfaceWarning) + "\n")
+	}
 	if a.GitURL != "" {
 		s.WriteString(fmt.Sprintf("Git URL:   %s\n", a.GitURL))
 	}
+	if a.Ref != "" {
+		s.WriteString(fmt.Sprintf("Ref:       %s\n", a.Ref))
+	}
+	if a.PinnedCommit != "" {
+		s.WriteString(fmt.Sprintf("Pinned:    %s\n", addons.ShortCommit(a.PinnedCommit)))
+	}
 	if !a.InstalledAt.IsZero() {
 		s.WriteString(fmt.Sprintf("Installed: %s\n", a.InstalledAt.Format("2006-01-02 15:04")))
 	}
@@ -0,0 +1,52 @@
+package addons
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckInstallSource(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		src  string
+		want bool // valid
+	}{
+		{"https://github.com/shagu/pfQuest", true},
+		{"https://github.com/shagu/pfQuest.git@v4.0.0", true},
+		{"git@github.com:shagu/pfQuest.git", true},
+		{"ssh://git@gitlab.com/user/addon.git", true},
+		{dir, true},
+		{"", true},
+		{"github.com/shagu/pfQuest", false},
+		{"pfQuest", false},
+		{"https://github.com", false},
+		{"https://github.com/shagu", false},
+		{"https://github.com/shagu/pfQuest/tree/master", true},
+		{"https://github.com/topics/wow-addons", false},
+	}
+
+	for _, tt := range tests {
+		hint := checkInstallSource(tt.src)
+		if (hint == "") != tt.want {
+			t.Errorf("checkInstallSource(%q) = %q, want valid=%v", tt.src, hint, tt.want)
+		}
+	}
+}
+
+func TestRemoveCompleteReportsBackup(t *testing.T) {
+	model, _ := Model{}.Update(removeCompleteMsg{name: "pfUI", backupPath: "/backups/pfUI/20260101-120000"})
+	m := model.(Model)
+	if !strings.Contains(m.statusMsg, "/backups/pfUI/20260101-120000") || len(m.lastRemoved) != 1 {
+		t.Errorf("status %q, lastRemoved %v, want the backup path and an undo", m.statusMsg, m.lastRemoved)
+	}
+
+	model, _ = Model{lastRemoved: []string{"Bagnon"}}.Update(removeCompleteMsg{name: "pfUI", backupErr: errors.New("disk full")})
+	m = model.(Model)
+	if m.statusMsg != "" || !strings.Contains(m.errorMsg, "disk full") {
+		t.Errorf("status %q, error %q, want the backup error", m.statusMsg, m.errorMsg)
+	}
+	if len(m.lastRemoved) != 0 {
+		t.Errorf("lastRemoved = %v, want nothing to undo without a backup", m.lastRemoved)
+	}
+}
@@ -0,0 +1,60 @@
+package addons
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bnema/turtlectl/internal/ui/styles"
+)
+
+const (
+	// stallTimeout is how long an operation can go without progress before
+	// the stall hint is shown
+	stallTimeout = 20 * time.Second
+
+	// stallCheckInterval is how often the last progress time is checked
+	stallCheckInterval = time.Second
+)
+
+// stallCheckMsg triggers a stall check
+type stallCheckMsg struct{}
+
+// checkStall schedules the next stall check
+func checkStall() tea.Cmd {
+	return tea.Tick(stallCheckInterval, func(time.Time) tea.Msg {
+		return stallCheckMsg{}
+	})
+}
+
+// stallWatch tracks when a long operation last reported progress
+type stallWatch struct {
+	lastProgress time.Time
+}
+
+// newStallWatch starts watching from now
+func newStallWatch() stallWatch {
+	return stallWatch{lastProgress: time.Now()}
+}
+
+// touch records progress
+func (w *stallWatch) touch() {
+	w.lastProgress = time.Now()
+}
+
+// stalled reports whether no progress came in for stallTimeout
+func (w stallWatch) stalled() bool {
+	return time.Since(w.lastProgress) >= stallTimeout
+}
+
+// view renders the stall hint, empty while progress keeps coming
+func (w stallWatch) view() string {
+	if !w.stalled() {
+		return ""
+	}
+	idle := time.Since(w.lastProgress).Truncate(time.Second)
+	hint := fmt.Sprintf("Still working, no progress for %s (stalled?) - press q to cancel", idle)
+	return lipgloss.NewStyle().Foreground(styles.Warning).Render(hint)
+}
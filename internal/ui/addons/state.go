@@ -0,0 +1,70 @@
+package addons
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// uiStateFile remembers TUI preferences between sessions, in the cache dir
+const uiStateFile = "ui-state.json"
+
+// uiState holds the TUI preferences restored on the next launch
+type uiState struct {
+	ExploreSort   string `json:"explore_sort,omitempty"`   // sortOrder name, e.g. "Stars"
+	ExploreFilter string `json:"explore_filter,omitempty"` // Filter applied when explore was closed
+}
+
+// loadUIState reads the saved TUI preferences, empty if missing or corrupt
+func loadUIState(cacheDir string) uiState {
+	var state uiState
+	if cacheDir == "" {
+		return state
+	}
+	data, err := os.ReadFile(filepath.Join(cacheDir, uiStateFile))
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return uiState{}
+	}
+	return state
+}
+
+// saveUIState writes the TUI preferences, failures are ignored
+func saveUIState(cacheDir string, state uiState) {
+	if cacheDir == "" {
+		return
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(cacheDir, uiStateFile), data, 0644)
+}
+
+// parseSortOrder returns the sort order with the given name, sortByName if unknown
+func parseSortOrder(name string) sortOrder {
+	for order := sortOrder(0); order < sortOrderCount; order++ {
+		if order.String() == name {
+			return order
+		}
+	}
+	return sortByName
+}
+
+// saveState remembers the explore sort order and applied filter
+func (m ExploreModel) saveState() {
+	state := loadUIState(m.cacheDir)
+	state.ExploreSort = m.sortOrder.String()
+	state.ExploreFilter = ""
+	if m.list.FilterState() == list.FilterApplied {
+		state.ExploreFilter = m.list.FilterValue()
+	}
+	saveUIState(m.cacheDir, state)
+}
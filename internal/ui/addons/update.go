@@ -1,7 +1,11 @@
 package addons
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,17 +23,27 @@ type UpdateSingleModel struct {
 	spinner   spinner.Model
 	manager   *addons.Manager
 	addonName string
+	dryRun    bool
 
 	steps       []uiprogress.Step
 	currentStep int
+	progressCh  chan gitProgressMsg
+	stall       stallWatch
 
-	done   bool
-	err    error
-	result *addons.UpdateResult
+	done    bool
+	err     error
+	result  *addons.UpdateResult
+	preview *addons.UpdatePreview
+
+	// Cancels in-flight git operations on quit
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewUpdateSingleModel creates a new single addon update model
-func NewUpdateSingleModel(manager *addons.Manager, name string) UpdateSingleModel {
+// With dryRun, it only reports what would change
+// The update runs under ctx and is canceled when the user quits
+func NewUpdateSingleModel(ctx context.Context, manager *addons.Manager, name string, dryRun bool) UpdateSingleModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
@@ -39,19 +53,30 @@ func NewUpdateSingleModel(manager *addons.Manager, name string) UpdateSingleMode
 		{Name: "Fetching changes", State: uiprogress.StatePending},
 		{Name: "Applying updates", State: uiprogress.StatePending},
 	}
+	if dryRun {
+		steps = steps[:2]
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
 
 	return UpdateSingleModel{
 		spinner:     s,
 		manager:     manager,
 		addonName:   name,
+		dryRun:      dryRun,
 		steps:       steps,
 		currentStep: 0,
+		progressCh:  make(chan gitProgressMsg, 16),
+		stall:       newStallWatch(),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
 type updateSingleDoneMsg struct {
-	result *addons.UpdateResult
-	err    error
+	result  *addons.UpdateResult
+	preview *addons.UpdatePreview
+	err     error
 }
 
 // Init initializes the model
@@ -59,12 +84,19 @@ func (m UpdateSingleModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
 		m.doUpdate(),
+		waitForGitProgress(m.progressCh),
+		checkStall(),
 	)
 }
 
 func (m UpdateSingleModel) doUpdate() tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.manager.Update(m.addonName, nil)
+		defer close(m.progressCh)
+		if m.dryRun {
+			preview, err := m.manager.UpdateDryRun(m.ctx, m.addonName)
+			return updateSingleDoneMsg{preview: preview, err: err}
+		}
+		result, err := m.manager.Update(m.ctx, m.addonName, progressChanWriter{m.progressCh})
 		return updateSingleDoneMsg{result: result, err: err}
 	}
 }
@@ -74,6 +106,7 @@ func (m UpdateSingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			m.cancel()
 			return m, tea.Quit
 		}
 
@@ -88,10 +121,21 @@ func (m UpdateSingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, cmd
 
+	case gitProgressMsg:
+		m.stall.touch()
+		return m, waitForGitProgress(m.progressCh)
+
+	case stallCheckMsg:
+		if m.done {
+			return m, nil
+		}
+		return m, checkStall()
+
 	case updateSingleDoneMsg:
 		m.done = true
 		m.err = msg.err
 		m.result = msg.result
+		m.preview = msg.preview
 
 		if msg.err != nil {
 			m.steps[m.currentStep].State = uiprogress.StateError
@@ -114,6 +158,9 @@ func (m UpdateSingleModel) View() string {
 	var b strings.Builder
 
 	title := fmt.Sprintf("Updating %s", m.addonName)
+	if m.dryRun {
+		title = fmt.Sprintf("Checking %s (dry run)", m.addonName)
+	}
 	titleStyle := lipgloss.NewStyle().
 		Foreground(styles.Text).
 		Bold(true)
@@ -134,15 +181,29 @@ func (m UpdateSingleModel) View() string {
 		b.WriteString("\n")
 	}
 
+	if !m.done {
+		if hint := m.stall.view(); hint != "" {
+			b.WriteString("\n" + indent + hint + "\n")
+		}
+	}
+
 	if m.done {
 		b.WriteString("\n")
 		if m.err != nil {
 			b.WriteString(uiprogress.FormatError(m.err.Error()))
+		} else if m.preview != nil {
+			b.WriteString(uiprogress.FormatSuccess(DescribePreview(m.addonName, m.preview)))
 		} else if m.result != nil {
 			if m.result.AlreadyUpToDate {
 				b.WriteString(uiprogress.FormatSuccess(fmt.Sprintf("%s is already up to date", m.addonName)))
 			} else {
 				b.WriteString(uiprogress.FormatSuccess(fmt.Sprintf("Updated %s", m.addonName)))
+				b.WriteString(renderChangelog(m.result))
+			}
+			if m.result.SavedVariablesBackup != "" {
+				b.WriteString("\n")
+				b.WriteString(lipgloss.NewStyle().Foreground(styles.Muted).Render(
+					"  SavedVariables backed up to " + m.result.SavedVariablesBackup))
 			}
 		}
 		b.WriteString("\n")
@@ -156,14 +217,55 @@ func (m UpdateSingleModel) GetError() error {
 	return m.err
 }
 
+// maxShownCommits caps the changelog printed after an update
+const maxShownCommits = 5
+
+// renderChangelog lists the newest commits pulled in by an update
+func renderChangelog(result *addons.UpdateResult) string {
+	muted := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	if result.ReCloned {
+		return "\n" + muted.Render("  Re-cloned from scratch, no changelog available")
+	}
+	if len(result.Commits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n" + muted.Render(fmt.Sprintf("  %s → %s", result.OldCommit, result.NewCommit)))
+	for i, c := range result.Commits {
+		if i == maxShownCommits {
+			b.WriteString("\n" + muted.Render(fmt.Sprintf("    ... and %d more", len(result.Commits)-maxShownCommits)))
+			break
+		}
+		b.WriteString(fmt.Sprintf("\n    %s %s", styles.Highlighted.Render(c.Hash), c.Subject))
+	}
+	return b.String()
+}
+
+// DescribePreview renders a dry-run result as a sentence
+func DescribePreview(name string, preview *addons.UpdatePreview) string {
+	switch {
+	case preview.ReClone:
+		return fmt.Sprintf("Would re-clone %s (not a git repository)", name)
+	case preview.HasUpdate:
+		return fmt.Sprintf("Would update %s from %s to %s", name, preview.From, preview.To)
+	default:
+		return fmt.Sprintf("%s is already up to date (%s)", name, preview.From)
+	}
+}
+
 // UpdateAllModel is the bubbletea model for updating all addons
 type UpdateAllModel struct {
 	spinner spinner.Model
 	manager *addons.Manager
 
 	addonsList  []string
-	current     int
-	currentName string
+	concurrency int
+	next        int      // Index of the next addon to dispatch
+	completed   int      // Number of finished updates
+	inFlight    []string // Addons currently being updated
+	dryRun      bool
 
 	done    bool
 	err     error
@@ -171,21 +273,38 @@ type UpdateAllModel struct {
 	errors  []string
 	updated []string
 	skipped []string
+	pending []string // Dry run: descriptions of updates that would be applied
+
+	// Cancels in-flight git operations on quit
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewUpdateAllModel creates a new update all addons model
-func NewUpdateAllModel(manager *addons.Manager) UpdateAllModel {
+// Up to concurrency addons are updated at the same time
+// With dryRun, it only reports what would change
+// Updates run under ctx and are canceled when the user quits
+func NewUpdateAllModel(ctx context.Context, manager *addons.Manager, concurrency int, dryRun bool) UpdateAllModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
 
-	addonList := manager.GetTrackedAddons()
+	addonList := manager.GetUpdatableAddons()
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
 
 	return UpdateAllModel{
-		spinner:    s,
-		manager:    manager,
-		addonsList: addonList,
-		current:    0,
+		spinner:     s,
+		manager:     manager,
+		addonsList:  addonList,
+		concurrency: concurrency,
+		dryRun:      dryRun,
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
@@ -198,6 +317,7 @@ type (
 		name    string
 		updated bool
 		skipped bool
+		preview *addons.UpdatePreview
 		err     error
 	}
 )
@@ -210,21 +330,35 @@ func (m UpdateAllModel) Init() tea.Cmd {
 	)
 }
 
-func (m UpdateAllModel) updateNext() tea.Cmd {
-	if m.current >= len(m.addonsList) {
-		return func() tea.Msg {
-			return updateAllDoneMsg{result: &addons.UpdateAllResult{
-				Updated: len(m.updated),
-				Skipped: len(m.skipped),
-				Failed:  len(m.errors),
-				Errors:  m.errors,
-			}}
-		}
+// dispatch starts updates until the concurrency limit is reached
+func (m *UpdateAllModel) dispatch() tea.Cmd {
+	var cmds []tea.Cmd
+	for len(m.inFlight) < m.concurrency && m.next < len(m.addonsList) {
+		name := m.addonsList[m.next]
+		m.next++
+		m.inFlight = append(m.inFlight, name)
+		cmds = append(cmds, m.updateOne(name))
 	}
+	return tea.Batch(cmds...)
+}
 
-	name := m.addonsList[m.current]
+func (m UpdateAllModel) updateOne(name string) tea.Cmd {
 	return func() tea.Msg {
-		result, err := m.manager.Update(name, nil)
+		if m.dryRun {
+			preview, err := m.manager.UpdateDryRun(m.ctx, name)
+			if errors.Is(err, addons.ErrLocalSource) {
+				return updateOneMsg{name: name, skipped: true}
+			}
+			if err != nil {
+				return updateOneMsg{name: name, err: err}
+			}
+			return updateOneMsg{name: name, preview: preview, skipped: !preview.HasUpdate}
+		}
+
+		result, err := m.manager.Update(m.ctx, name, nil)
+		if errors.Is(err, addons.ErrLocalSource) {
+			return updateOneMsg{name: name, skipped: true}
+		}
 		if err != nil {
 			return updateOneMsg{name: name, err: err}
 		}
@@ -236,11 +370,23 @@ func (m UpdateAllModel) updateNext() tea.Cmd {
 	}
 }
 
+func (m UpdateAllModel) finish() tea.Cmd {
+	return func() tea.Msg {
+		return updateAllDoneMsg{result: &addons.UpdateAllResult{
+			Updated: len(m.updated),
+			Skipped: len(m.skipped),
+			Failed:  len(m.errors),
+			Errors:  m.errors,
+		}}
+	}
+}
+
 // Update handles messages
 func (m UpdateAllModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			m.cancel()
 			return m, tea.Quit
 		}
 
@@ -256,24 +402,29 @@ func (m UpdateAllModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return tea.Quit()
 			})
 		}
-		m.currentName = m.addonsList[0]
-		return m, m.updateNext()
+		return m, m.dispatch()
 
 	case updateOneMsg:
 		if msg.err != nil {
 			m.errors = append(m.errors, fmt.Sprintf("%s: %v", msg.name, msg.err))
 		} else if msg.skipped {
 			m.skipped = append(m.skipped, msg.name)
+		} else if msg.preview != nil {
+			m.pending = append(m.pending, DescribePreview(msg.name, msg.preview))
+			sort.Strings(m.pending)
 		} else if msg.updated {
 			m.updated = append(m.updated, msg.name)
 		}
 
-		m.current++
-		if m.current < len(m.addonsList) {
-			m.currentName = m.addonsList[m.current]
-			return m, m.updateNext()
+		m.completed++
+		m.inFlight = slices.DeleteFunc(m.inFlight, func(name string) bool {
+			return name == msg.name
+		})
+
+		if m.completed >= len(m.addonsList) {
+			return m, m.finish()
 		}
-		return m, m.updateNext() // Will trigger done
+		return m, m.dispatch()
 
 	case updateAllDoneMsg:
 		m.done = true
@@ -293,7 +444,11 @@ func (m UpdateAllModel) View() string {
 	titleStyle := lipgloss.NewStyle().
 		Foreground(styles.Text).
 		Bold(true)
-	b.WriteString(titleStyle.Render("Updating all addons"))
+	title := "Updating all addons"
+	if m.dryRun {
+		title = "Checking all addons (dry run)"
+	}
+	b.WriteString(titleStyle.Render(title))
 	b.WriteString("\n\n")
 
 	if len(m.addonsList) == 0 {
@@ -304,15 +459,17 @@ func (m UpdateAllModel) View() string {
 
 	// Progress indicator
 	if !m.done {
-		progress := fmt.Sprintf("%d/%d", m.current+1, len(m.addonsList))
 		progressStyle := lipgloss.NewStyle().Foreground(styles.Muted)
-		line := fmt.Sprintf("  %s Updating %s %s",
-			m.spinner.View(),
-			progressStyle.Render(progress+":"),
-			styles.NormalText.Bold(true).Render(m.currentName),
-		)
-		b.WriteString(line)
-		b.WriteString("\n")
+		progress := fmt.Sprintf("%d/%d done", m.completed, len(m.addonsList))
+		b.WriteString("  " + progressStyle.Render(progress) + "\n")
+		for _, name := range m.inFlight {
+			line := fmt.Sprintf("  %s Updating %s",
+				m.spinner.View(),
+				styles.NormalText.Bold(true).Render(name),
+			)
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
 	}
 
 	// Results when done
@@ -322,6 +479,11 @@ func (m UpdateAllModel) View() string {
 			b.WriteString("\n")
 		}
 
+		for _, line := range m.pending {
+			b.WriteString(uiprogress.FormatSuccess(line))
+			b.WriteString("\n")
+		}
+
 		if len(m.skipped) > 0 {
 			skipStyle := lipgloss.NewStyle().Foreground(styles.Muted)
 			b.WriteString(skipStyle.Render(fmt.Sprintf("  %d addon(s) already up to date", len(m.skipped))))
@@ -336,6 +498,10 @@ func (m UpdateAllModel) View() string {
 		b.WriteString("\n")
 		summary := fmt.Sprintf("Updated: %d, Skipped: %d, Failed: %d",
 			len(m.updated), len(m.skipped), len(m.errors))
+		if m.dryRun {
+			summary = fmt.Sprintf("Would update: %d, Up to date: %d, Failed: %d (dry run, nothing changed)",
+				len(m.pending), len(m.skipped), len(m.errors))
+		}
 		summaryStyle := lipgloss.NewStyle().Foreground(styles.Muted)
 		b.WriteString(summaryStyle.Render("  " + summary))
 		b.WriteString("\n")
@@ -348,3 +514,8 @@ func (m UpdateAllModel) View() string {
 func (m UpdateAllModel) GetError() error {
 	return m.err
 }
+
+// GetResult returns the final counts, nil until done
+func (m UpdateAllModel) GetResult() *addons.UpdateAllResult {
+	return m.result
+}
@@ -172,6 +172,9 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.startDesktop()
 			}
 			// Need to download
+			if msg.result != nil && msg.result.Mirror != "" {
+				m.steps[stepDownload].Name = fmt.Sprintf("Downloaded from %s", msg.result.Mirror)
+			}
 			m.steps[stepDownload].State = uiprogress.StateInProgress
 			m.currentStep = stepDownload
 			return m, m.startDownload()
@@ -191,7 +194,7 @@ func (m InstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case progressMsg:
 		if msg.total > 0 {
 			m.subProgress = float64(msg.downloaded) / float64(msg.total) * 100
-			m.subDetail = fmt.Sprintf("%s / %s", formatBytes(msg.downloaded), formatBytes(msg.total))
+			m.subDetail = fmt.Sprintf("%s / %s", uiprogress.FormatBytes(msg.downloaded), uiprogress.FormatBytes(msg.total))
 		}
 		return m, m.progressBar.SetPercent(m.subProgress / 100)
 
@@ -264,16 +267,3 @@ func minInt(a, b int) int {
 	}
 	return b
 }
-
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
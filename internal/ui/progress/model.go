@@ -17,6 +17,7 @@ type Model struct {
 	progress    *Progress
 	spinner     spinner.Model
 	progressBar progress.Model
+	subPhase    string
 	done        bool
 	err         error
 	width       int
@@ -28,20 +29,23 @@ func NewModel(title string, stepNames ...string) Model {
 	s.Spinner = spinner.Dot
 	s.Style = styles.Spinner
 
-	p := progress.New(
-		progress.WithDefaultGradient(),
-		progress.WithWidth(30),
-		progress.WithoutPercentage(),
-	)
-
 	return Model{
 		progress:    NewProgress(title, stepNames...),
 		spinner:     s,
-		progressBar: p,
+		progressBar: newProgressBar(30),
 		width:       80,
 	}
 }
 
+// newProgressBar creates an empty sub-progress bar
+func newProgressBar(width int) progress.Model {
+	return progress.New(
+		progress.WithDefaultGradient(),
+		progress.WithWidth(width),
+		progress.WithoutPercentage(),
+	)
+}
+
 // Progress messages for updating state
 type (
 	// StartStepMsg signals to start the current step
@@ -56,6 +60,7 @@ type (
 	// SubProgressMsg updates the sub-progress within current step
 	SubProgressMsg struct {
 		Percent float64
+		Phase   string // Counter the percentage belongs to, the bar restarts when it changes
 		Detail  string
 	}
 
@@ -111,6 +116,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case SubProgressMsg:
 		m.progress.SetSubProgress(msg.Percent, msg.Detail)
+		if msg.Phase != m.subPhase {
+			// A new phase counts from 0 again, start from an empty bar rather
+			// than animating backward
+			m.subPhase = msg.Phase
+			m.progressBar = newProgressBar(m.progressBar.Width)
+		}
 		return m, m.progressBar.SetPercent(msg.Percent / 100)
 
 	case DoneMsg:
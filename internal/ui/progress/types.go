@@ -1,7 +1,11 @@
 package progress
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -58,14 +62,76 @@ var (
 	}
 )
 
-// GetIcons returns the appropriate icon set based on environment
+// Icon modes accepted by SetIconMode
+const (
+	IconModeAuto  = "auto"
+	IconModeNerd  = "nerd"
+	IconModeASCII = "ascii"
+)
+
+// IconModes lists the accepted icon modes
+var IconModes = []string{IconModeAuto, IconModeNerd, IconModeASCII}
+
+var ErrInvalidIconMode = errors.New("invalid icon mode")
+
+// iconMode is set by SetIconMode, empty to follow TURTLECTL_NERD_FONTS
+var iconMode string
+
+// SetIconMode picks the icon set: nerd or ascii, or auto to detect whether
+// the terminal renders Nerd Font glyphs. It wins over TURTLECTL_NERD_FONTS
+func SetIconMode(mode string) error {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if !slices.Contains(IconModes, mode) {
+		return fmt.Errorf("%w: %s (use %s)", ErrInvalidIconMode, mode, strings.Join(IconModes, ", "))
+	}
+	iconMode = mode
+	return nil
+}
+
+// GetIcons returns the icon set picked by SetIconMode, then TURTLECTL_NERD_FONTS
+// (1 or 0), then detected. ASCII unless Nerd Fonts are known to work
 func GetIcons() Icons {
-	if os.Getenv("TURTLECTL_NERD_FONTS") == "1" {
+	if useNerdFonts() {
 		return NerdFontIcons
 	}
 	return ASCIIIcons
 }
 
+// useNerdFonts reports whether to use Nerd Font glyphs, see GetIcons
+func useNerdFonts() bool {
+	switch iconMode {
+	case IconModeNerd:
+		return true
+	case IconModeASCII:
+		return false
+	case IconModeAuto:
+		return detectNerdFonts()
+	}
+
+	switch os.Getenv("TURTLECTL_NERD_FONTS") {
+	case "1":
+		return true
+	case "0":
+		return false
+	}
+	return detectNerdFonts()
+}
+
+// detectNerdFonts reports whether the terminal renders Nerd Font glyphs out of
+// the box: kitty, WezTerm and Ghostty bundle the symbols as a fallback font
+// Prompts like Starship hint at a Nerd Font but don't prove this terminal
+// has one, and a wrong guess shows broken glyphs, so anything else is ASCII
+func detectNerdFonts() bool {
+	if os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("TERM") == "xterm-kitty" {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("TERM_PROGRAM")) {
+	case "wezterm", "ghostty":
+		return true
+	}
+	return false
+}
+
 // Icon styles
 var (
 	IconStyleCheck   = lipgloss.NewStyle().Foreground(styles.Success)
@@ -0,0 +1,49 @@
+package progress
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUseNerdFonts(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		env      string
+		terminal string
+		want     bool
+	}{
+		{"unknown terminal", "", "", "", false},
+		{"detected terminal", "", "", "WezTerm", true},
+		{"env wins over detection", "", "0", "WezTerm", false},
+		{"env enables", "", "1", "", true},
+		{"mode wins over env", IconModeASCII, "1", "ghostty", false},
+		{"forced nerd", IconModeNerd, "0", "", true},
+		{"auto ignores env", IconModeAuto, "1", "", false},
+	}
+
+	t.Cleanup(func() { iconMode = "" })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TURTLECTL_NERD_FONTS", tt.env)
+			t.Setenv("TERM_PROGRAM", tt.terminal)
+			t.Setenv("KITTY_WINDOW_ID", "")
+			t.Setenv("TERM", "xterm-256color")
+			iconMode = tt.mode
+
+			if got := useNerdFonts(); got != tt.want {
+				t.Errorf("useNerdFonts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetIconModeRejectsUnknown(t *testing.T) {
+	t.Cleanup(func() { iconMode = "" })
+	if err := SetIconMode("emoji"); !errors.Is(err, ErrInvalidIconMode) {
+		t.Fatalf("expected ErrInvalidIconMode, got %v", err)
+	}
+	if err := SetIconMode("NERD"); err != nil || iconMode != IconModeNerd {
+		t.Fatalf("SetIconMode(NERD) = %v, mode %q", err, iconMode)
+	}
+}
@@ -1,6 +1,7 @@
 package progress
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -20,66 +21,102 @@ func NewGitProgressWriter(p *tea.Program) *GitProgressWriter {
 
 // Write implements io.Writer, parsing git progress output
 func (w *GitProgressWriter) Write(p []byte) (n int, err error) {
-	line := string(p)
-
-	// Parse git progress patterns
-	percent, detail := parseGitProgress(line)
-	if percent >= 0 {
-		w.program.Send(SubProgressMsg{
-			Percent: percent,
-			Detail:  detail,
-		})
+	for _, line := range splitProgressLines(p) {
+		if percent, phase, detail := parseGitProgress(line); percent >= 0 {
+			w.program.Send(SubProgressMsg{
+				Percent: percent,
+				Phase:   phase,
+				Detail:  detail,
+			})
+		}
 	}
+	return len(p), nil
+}
+
+// splitProgressLines splits git output into lines
+// Git redraws progress with carriage returns, a write can hold several updates
+func splitProgressLines(p []byte) []string {
+	return strings.FieldsFunc(string(p), func(r rune) bool { return r == '\r' || r == '\n' })
+}
+
+// lineProgressStep is the percentage a stage must advance before another line is printed
+const lineProgressStep = 10
+
+// LineProgressWriter prints git progress as plain lines, for output without the TUI
+type LineProgressWriter struct {
+	print       func(detail string)
+	stage       string
+	lastPercent float64
+}
 
+// NewLineProgressWriter creates a writer printing git progress with PrintDetail
+func NewLineProgressWriter() *LineProgressWriter {
+	return &LineProgressWriter{print: PrintDetail}
+}
+
+// Write implements io.Writer, printing a line when a stage starts, advances
+// by lineProgressStep or completes
+func (w *LineProgressWriter) Write(p []byte) (n int, err error) {
+	for _, line := range splitProgressLines(p) {
+		percent, stage, detail := parseGitProgress(line)
+		if percent < 0 {
+			continue
+		}
+		switch {
+		case stage != w.stage:
+			w.stage = stage
+		case percent >= 100 && w.lastPercent < 100:
+		case percent-w.lastPercent >= lineProgressStep:
+		default:
+			continue
+		}
+		w.lastPercent = percent
+		if strings.Contains(line, "%") {
+			w.print(fmt.Sprintf("%s: %.0f%%", stage, percent))
+		} else {
+			w.print(detail)
+		}
+	}
 	return len(p), nil
 }
 
+// gitCounterPattern matches the counters git prints while cloning or fetching,
+// like "Receiving objects:  67% (156/233)"
+// The server side phases arrive over the sideband prefixed with "remote: "
+var gitCounterPattern = regexp.MustCompile(`^(Enumerating objects|Counting objects|Compressing objects|Receiving objects|Resolving deltas):\s+(\d+)%\s+\((\d+)/(\d+)\)`)
+
+// gitEnumeratingPattern matches "Enumerating objects: 233", which has no percentage
+var gitEnumeratingPattern = regexp.MustCompile(`^Enumerating objects:\s+(\d+)`)
+
 // parseGitProgress parses git clone/fetch progress output
-// Returns percent (0-100) and detail string, or -1 if not a progress line
-func parseGitProgress(line string) (float64, string) {
+// Returns percent (0-100), the phase the counter belongs to (e.g. "Receiving
+// objects" or "remote: Counting objects") and a detail string, or -1 if not a
+// progress line
+// Each phase counts from 0 to 100 on its own
+func parseGitProgress(line string) (float64, string, string) {
 	line = strings.TrimSpace(line)
 	if line == "" {
-		return -1, ""
+		return -1, "", ""
 	}
 
-	// Patterns like:
-	// "Receiving objects:  67% (156/233)"
-	// "Resolving deltas:  100% (45/45)"
-	// "Compressing objects:  50% (5/10)"
-	// "Counting objects: 100% (233/233), done."
-	patterns := []struct {
-		re     *regexp.Regexp
-		prefix string
-	}{
-		{regexp.MustCompile(`Receiving objects:\s+(\d+)%\s+\((\d+)/(\d+)\)`), "Receiving objects"},
-		{regexp.MustCompile(`Resolving deltas:\s+(\d+)%\s+\((\d+)/(\d+)\)`), "Resolving deltas"},
-		{regexp.MustCompile(`Compressing objects:\s+(\d+)%\s+\((\d+)/(\d+)\)`), "Compressing objects"},
-		{regexp.MustCompile(`Counting objects:\s+(\d+)%\s+\((\d+)/(\d+)\)`), "Counting objects"},
-		{regexp.MustCompile(`Enumerating objects:\s+(\d+)`), "Enumerating objects"},
+	var remote string
+	if rest, ok := strings.CutPrefix(line, "remote:"); ok {
+		remote = "remote: "
+		line = strings.TrimSpace(rest)
 	}
 
-	for _, p := range patterns {
-		if matches := p.re.FindStringSubmatch(line); matches != nil {
-			if len(matches) >= 2 {
-				percent, _ := strconv.ParseFloat(matches[1], 64)
-				detail := p.prefix
-				if len(matches) >= 4 {
-					detail = p.prefix + ": " + matches[2] + "/" + matches[3]
-				}
-				return percent, detail
-			}
-		}
+	if matches := gitCounterPattern.FindStringSubmatch(line); matches != nil {
+		percent, _ := strconv.ParseFloat(matches[2], 64)
+		phase := remote + matches[1]
+		return percent, phase, phase + ": " + matches[3] + "/" + matches[4]
 	}
 
-	// Handle "Enumerating objects: 233" (no percentage)
-	if strings.HasPrefix(line, "Enumerating objects:") {
-		re := regexp.MustCompile(`Enumerating objects:\s+(\d+)`)
-		if matches := re.FindStringSubmatch(line); matches != nil {
-			return 0, "Enumerating objects: " + matches[1]
-		}
+	if matches := gitEnumeratingPattern.FindStringSubmatch(line); matches != nil {
+		phase := remote + "Enumerating objects"
+		return 0, phase, phase + ": " + matches[1]
 	}
 
-	return -1, ""
+	return -1, "", ""
 }
 
 // ByteProgressWriter tracks bytes written and sends progress messages
@@ -111,7 +148,7 @@ func (w *ByteProgressWriter) Write(p []byte) (n int, err error) {
 			w.lastUpdate = percent
 			w.program.Send(SubProgressMsg{
 				Percent: percent,
-				Detail:  formatBytes(w.written) + " / " + formatBytes(w.total),
+				Detail:  FormatBytes(w.written) + " / " + FormatBytes(w.total),
 			})
 		}
 	}
@@ -119,8 +156,8 @@ func (w *ByteProgressWriter) Write(p []byte) (n int, err error) {
 	return n, nil
 }
 
-// formatBytes formats bytes into human-readable string
-func formatBytes(bytes int64) string {
+// FormatBytes formats bytes into human-readable string
+func FormatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return strconv.FormatInt(bytes, 10) + " B"
@@ -0,0 +1,66 @@
+package progress
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGitProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantPercent float64
+		wantPhase   string
+		wantDetail  string
+	}{
+		{"receiving", "Receiving objects:  67% (156/233)", 67, "Receiving objects", "Receiving objects: 156/233"},
+		{"resolving", "Resolving deltas: 100% (45/45), done.", 100, "Resolving deltas", "Resolving deltas: 45/45"},
+		{"counting", "Counting objects: 100% (233/233), done.", 100, "Counting objects", "Counting objects: 233/233"},
+		{"enumerating", "Enumerating objects: 233, done.", 0, "Enumerating objects", "Enumerating objects: 233"},
+		{"remote compressing", "remote: Compressing objects:  50% (5/10)", 50, "remote: Compressing objects", "remote: Compressing objects: 5/10"},
+		{"remote counting", "remote: Counting objects:  12% (28/233)", 12, "remote: Counting objects", "remote: Counting objects: 28/233"},
+		{"remote enumerating", "remote: Enumerating objects: 233, done.", 0, "remote: Enumerating objects", "remote: Enumerating objects: 233"},
+		{"remote total", "remote: Total 233 (delta 45), reused 200 (delta 30)", -1, "", ""},
+		{"empty", "   ", -1, "", ""},
+		{"unrelated", "Cloning into 'pfQuest'...", -1, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, phase, detail := parseGitProgress(tt.line)
+			if percent != tt.wantPercent || phase != tt.wantPhase || detail != tt.wantDetail {
+				t.Fatalf("parseGitProgress(%q) = (%v, %q, %q), want (%v, %q, %q)",
+					tt.line, percent, phase, detail, tt.wantPercent, tt.wantPhase, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestLineProgressWriter(t *testing.T) {
+	var lines []string
+	w := &LineProgressWriter{print: func(detail string) { lines = append(lines, detail) }}
+
+	writes := []string{
+		"remote: Enumerating objects: 233, done.\n",
+		"remote: Counting objects:   1% (2/233)\rremote: Counting objects:   5% (12/233)\rremote: Counting objects: 100% (233/233), done.\n",
+		"Receiving objects:  67% (156/233)\r",
+		"Receiving objects:  70% (160/233)\r",
+		"Receiving objects: 100% (233/233), done.\n",
+	}
+	for _, s := range writes {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+	}
+
+	want := []string{
+		"remote: Enumerating objects: 233",
+		"remote: Counting objects: 1%",
+		"remote: Counting objects: 100%",
+		"Receiving objects: 67%",
+		"Receiving objects: 100%",
+	}
+	if !slices.Equal(lines, want) {
+		t.Fatalf("printed %q, want %q", lines, want)
+	}
+}
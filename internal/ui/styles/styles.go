@@ -2,10 +2,32 @@ package styles
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
+// colorEnabled is false once DisableColor has been called
+var colorEnabled = true
+
+// DisableColor switches lipgloss to its ASCII profile, so every style renders
+// plain text without colors or escape codes
+func DisableColor() {
+	colorEnabled = false
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// ColorEnabled reports whether styles render with colors
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
+// NoColorEnv reports whether NO_COLOR is set to a non-empty value, see no-color.org
+func NoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
 // Color palette - coherent with charmbracelet style
 var (
 	Primary   = lipgloss.Color("#7D56F4") // Purple (charmbracelet brand)
@@ -129,6 +151,10 @@ var (
 
 	AddonDefault = lipgloss.NewStyle().
 			Foreground(Muted)
+
+	AddonDisabled = lipgloss.NewStyle().
+			Foreground(Muted).
+			Strikethrough(true)
 )
 
 // AddonStatusType represents the tracking status of an addon
@@ -138,8 +164,18 @@ const (
 	AddonStatusTracked AddonStatusType = iota
 	AddonStatusUntracked
 	AddonStatusDefault
+	AddonStatusDisabled
 )
 
+// TableHeader renders a table column header, plain without colors so the
+// Title padding doesn't shift the columns
+func TableHeader(name string) string {
+	if !colorEnabled {
+		return name
+	}
+	return Title.Render(name)
+}
+
 // FormatAddonStatus returns a styled status indicator
 func FormatAddonStatus(tracked bool) string {
 	if tracked {
@@ -155,6 +191,8 @@ func FormatAddonStatusEx(status AddonStatusType) string {
 		return AddonTracked.Render("tracked")
 	case AddonStatusDefault:
 		return AddonDefault.Render("default")
+	case AddonStatusDisabled:
+		return AddonDisabled.Render("disabled")
 	default:
 		return AddonUntracked.Render("untracked")
 	}
@@ -195,6 +233,21 @@ var (
 			Foreground(Muted).
 			Italic(true)
 
+	// AbandonedBadge for addons without recent commits
+	AbandonedBadge = lipgloss.NewStyle().
+			Foreground(Warning).
+			Italic(true)
+
+	// ArchivedBadge for read-only repositories
+	ArchivedBadge = lipgloss.NewStyle().
+			Foreground(Error).
+			Italic(true)
+
+	// PinnedBadge for addons frozen at a commit
+	PinnedBadge = lipgloss.NewStyle().
+			Foreground(Primary).
+			Italic(true)
+
 	// StarCount for GitHub stars
 	StarCount = lipgloss.NewStyle().
 			Foreground(Warning)
@@ -215,6 +268,21 @@ func FormatInstalledBadge() string {
 	return InstalledBadge.Render("installed")
 }
 
+// FormatAbandonedBadge returns a styled "abandoned" indicator
+func FormatAbandonedBadge() string {
+	return AbandonedBadge.Render("abandoned")
+}
+
+// FormatArchivedBadge returns a styled "archived" indicator
+func FormatArchivedBadge() string {
+	return ArchivedBadge.Render("archived")
+}
+
+// FormatPinnedBadge returns a styled "pinned" indicator
+func FormatPinnedBadge() string {
+	return PinnedBadge.Render("pinned")
+}
+
 // FormatStars formats star count with icon
 func FormatStars(count int) string {
 	if count <= 0 {
@@ -0,0 +1,70 @@
+package styles
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tablePadding is the space between columns
+const tablePadding = 2
+
+// Table prints aligned columns like text/tabwriter, but measures cells by
+// their visible width: tabwriter counts the bytes of color escape codes,
+// which pushes styled cells and headers out of line. The zero Table has no header
+type Table struct {
+	rows [][]string
+}
+
+// NewTable starts a table with the given column headers, see TableHeader
+func NewTable(headers ...string) *Table {
+	header := make([]string, len(headers))
+	for i, h := range headers {
+		header[i] = TableHeader(h)
+	}
+	return &Table{rows: [][]string{header}}
+}
+
+// AddRow appends a row, cells may be styled
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Render writes the table to w. The last column isn't padded
+func (t *Table) Render(w io.Writer) error {
+	var widths []int
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range t.rows {
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+tablePadding))
+			}
+		}
+		b.WriteByte('\n')
+	}
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
+
+// Truncate shortens s to at most width terminal cells, ending with "..." when
+// cut. It never splits a rune and counts wide characters (CJK, emoji) as two
+// cells, unlike slicing the string's bytes
+func Truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, "...")
+}
@@ -0,0 +1,71 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTableRenderIgnoresEscapeCodes(t *testing.T) {
+	tbl := &Table{}
+	tbl.AddRow("NAME", "STATUS", "NOTE")
+	tbl.AddRow("pfQuest", "\x1b[38;2;80;250;123mtracked\x1b[0m", "-")
+	tbl.AddRow("Bagnon", "\x1b[38;2;255;184;108muntracked\x1b[0m", "-")
+
+	var b strings.Builder
+	if err := tbl.Render(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"NAME     STATUS     NOTE",
+		"pfQuest  tracked    -",
+		"Bagnon   untracked  -",
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), b.String())
+	}
+	for i, line := range lines {
+		if got := stripEscapes(line); got != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+// stripEscapes drops the SGR sequences used in the test rows
+func stripEscapes(s string) string {
+	for {
+		start := strings.Index(s, "\x1b[")
+		if start < 0 {
+			return s
+		}
+		end := strings.IndexByte(s[start:], 'm')
+		s = s[:start] + s[start+end+1:]
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a longer description", 10, "a longe..."},
+		{"Quêtes améliorées pour tous", 10, "Quêtes ..."},
+		{"日本語のアドオン説明", 9, "日本語..."},
+		{"🐢 turtle addon 🐢", 8, "🐢 tu..."},
+	}
+
+	for _, tt := range tests {
+		got := Truncate(tt.in, tt.width)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Truncate(%q, %d) returned invalid UTF-8 %q", tt.in, tt.width, got)
+		}
+	}
+}
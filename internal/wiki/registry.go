@@ -1,6 +1,8 @@
 package wiki
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,53 +14,159 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+
+	"github.com/bnema/turtlectl/internal/offline"
 )
 
-// Registry fetches and caches the addon registry from GitHub
+// Registry fetches and caches the addon registry from one or more sources
 type Registry struct {
-	cacheDir  string
+	cacheDir string
+	sources  []registrySource
+	logger   *log.Logger
+	client   *http.Client
+}
+
+// registrySource is a registry URL with its own cache and ETag files
+type registrySource struct {
+	url       string
 	cachePath string
 	etagPath  string
-	logger    *log.Logger
-	client    *http.Client
+}
+
+// defaultSources are set from the config file, see SetRegistrySources
+var defaultSources []string
+
+// SetRegistrySources replaces the default source and TURTLECTL_REGISTRY_URL
+// "default" stands for RegistryURL
+func SetRegistrySources(sources []string) {
+	defaultSources = nil
+	for _, source := range sources {
+		if source == "default" {
+			source = RegistryURL
+		}
+		defaultSources = append(defaultSources, source)
+	}
+}
+
+// RegistrySources returns the registry URLs to use
+// SetRegistrySources, then TURTLECTL_REGISTRY_URL (comma-separated), replace
+// the default source
+func RegistrySources() []string {
+	if len(defaultSources) > 0 {
+		return defaultSources
+	}
+	if env := os.Getenv("TURTLECTL_REGISTRY_URL"); env != "" {
+		if sources := splitSources(env); len(sources) > 0 {
+			return sources
+		}
+	}
+	return []string{RegistryURL}
+}
+
+// splitSources splits a comma-separated list of URLs, dropping empty entries
+func splitSources(value string) []string {
+	var sources []string
+	for _, source := range strings.Split(value, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+	return sources
 }
 
 // NewRegistry creates a new registry manager
-func NewRegistry(cacheDir string, logger *log.Logger) *Registry {
-	return &Registry{
-		cacheDir:  cacheDir,
-		cachePath: filepath.Join(cacheDir, "addons-registry.json"),
-		etagPath:  filepath.Join(cacheDir, "addons-registry.etag"),
-		logger:    logger,
+// Without sources, RegistrySources is used. Later sources win when the same
+// addon URL appears in several registries
+func NewRegistry(cacheDir string, logger *log.Logger, sources ...string) *Registry {
+	if len(sources) == 0 {
+		sources = RegistrySources()
+	}
+
+	r := &Registry{
+		cacheDir: cacheDir,
+		logger:   logger,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+	for _, url := range sources {
+		r.sources = append(r.sources, newRegistrySource(cacheDir, url))
+	}
+	return r
 }
 
-// GetAddons returns the addon list, fetching from GitHub if needed
+// newRegistrySource names cache files after the source URL
+// The default source keeps the original file names so existing caches stay valid
+func newRegistrySource(cacheDir, url string) registrySource {
+	base := "addons-registry"
+	if url != RegistryURL {
+		sum := sha256.Sum256([]byte(url))
+		base = "addons-registry-" + hex.EncodeToString(sum[:])[:12]
+	}
+	return registrySource{
+		url:       url,
+		cachePath: filepath.Join(cacheDir, base+".json"),
+		etagPath:  filepath.Join(cacheDir, base+".etag"),
+	}
+}
+
+// GetAddons returns the merged addon list, fetching sources if needed
 // forceRefresh bypasses the cache TTL check
 func (r *Registry) GetAddons(forceRefresh bool) ([]WikiAddon, error) {
+	var lists [][]WikiAddon
+	var lastErr error
+
+	for _, src := range r.sources {
+		addons, err := r.getSourceAddons(src, forceRefresh)
+		if err != nil {
+			// One broken guild list shouldn't hide the others
+			r.logger.Warn("Failed to load registry source", "url", src.url, "error", err)
+			lastErr = err
+			continue
+		}
+		lists = append(lists, tagSource(addons, src.url))
+	}
+
+	if len(lists) == 0 {
+		return nil, lastErr
+	}
+
+	return mergeAddons(lists), nil
+}
+
+// getSourceAddons returns a single source's addons, fetching it if needed
+func (r *Registry) getSourceAddons(src registrySource, forceRefresh bool) ([]WikiAddon, error) {
 	// Try to load from cache first
-	cached, cacheTime, err := r.loadCache()
+	cached, cacheTime, err := loadCache(src.cachePath)
+
+	// Offline: any cache is good enough, however old
+	if offline.Enabled() {
+		if cached == nil {
+			return nil, fmt.Errorf("%w: no cached registry for %s", offline.ErrOffline, src.url)
+		}
+		r.logger.Debug("Offline, using cached registry", "url", src.url, "age", time.Since(cacheTime).Round(time.Hour))
+		return cached.Addons, nil
+	}
+
 	if err == nil && cached != nil {
 		cacheAge := time.Since(cacheTime)
 
 		// If cache is fresh and not forcing refresh, use it
 		if !forceRefresh && cacheAge < RegistryCacheTTL {
-			r.logger.Debug("Using cached registry", "age", cacheAge.Round(time.Minute))
+			r.logger.Debug("Using cached registry", "url", src.url, "age", cacheAge.Round(time.Minute))
 			return cached.Addons, nil
 		}
 
-		r.logger.Debug("Cache is stale", "age", cacheAge.Round(time.Hour))
+		r.logger.Debug("Cache is stale", "url", src.url, "age", cacheAge.Round(time.Hour))
 	}
 
-	// Try to fetch from GitHub
-	fresh, err := r.fetchFromGitHub()
+	// Try to fetch from the source
+	fresh, err := r.fetchSource(src)
 	if err != nil {
 		// Network failed - use stale cache if available
 		if cached != nil {
 			r.logger.Warn("Failed to fetch registry, using stale cache",
+				"url", src.url,
 				"error", err,
 				"cache_age", time.Since(cacheTime).Round(time.Hour))
 			return cached.Addons, nil
@@ -70,24 +178,55 @@ func (r *Registry) GetAddons(forceRefresh bool) ([]WikiAddon, error) {
 	if fresh == nil {
 		if cached != nil {
 			// Update cache timestamp
-			_ = r.touchCache()
+			_ = touchCache(src.cachePath)
 			return cached.Addons, nil
 		}
 		return nil, fmt.Errorf("registry returned not-modified but no cache exists")
 	}
 
 	// Save fresh data to cache
-	if err := r.saveCache(fresh); err != nil {
+	if err := r.saveCache(src.cachePath, fresh); err != nil {
 		r.logger.Warn("Failed to save cache", "error", err)
 	}
 
 	return fresh.Addons, nil
 }
 
-// fetchFromGitHub fetches the registry from GitHub raw URL
+// tagSource records which registry each addon came from
+func tagSource(addons []WikiAddon, source string) []WikiAddon {
+	for i := range addons {
+		addons[i].Source = source
+	}
+	return addons
+}
+
+// mergeAddons merges registry lists, deduplicating by repository URL
+// Later lists win for metadata, the first-seen order is kept
+func mergeAddons(lists [][]WikiAddon) []WikiAddon {
+	if len(lists) == 1 {
+		return lists[0]
+	}
+
+	var merged []WikiAddon
+	index := make(map[string]int)
+	for _, addons := range lists {
+		for _, addon := range addons {
+			key := NormalizeURL(addon.URL)
+			if i, ok := index[key]; ok {
+				merged[i] = addon
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, addon)
+		}
+	}
+	return merged
+}
+
+// fetchSource fetches a registry from its URL
 // Returns nil if 304 Not Modified (cache is still valid)
-func (r *Registry) fetchFromGitHub() (*RegistryData, error) {
-	req, err := http.NewRequest("GET", RegistryURL, nil)
+func (r *Registry) fetchSource(src registrySource) (*RegistryData, error) {
+	req, err := http.NewRequest("GET", src.url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -96,11 +235,11 @@ func (r *Registry) fetchFromGitHub() (*RegistryData, error) {
 	req.Header.Set("Accept", "application/json")
 
 	// Add ETag for conditional request
-	if etag, err := r.loadETag(); err == nil && etag != "" {
+	if etag, err := loadETag(src.etagPath); err == nil && etag != "" {
 		req.Header.Set("If-None-Match", etag)
 	}
 
-	r.logger.Debug("Fetching registry from GitHub", "url", RegistryURL)
+	r.logger.Debug("Fetching registry", "url", src.url)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -110,7 +249,7 @@ func (r *Registry) fetchFromGitHub() (*RegistryData, error) {
 
 	// Handle 304 Not Modified
 	if resp.StatusCode == http.StatusNotModified {
-		r.logger.Debug("Registry not modified (304)")
+		r.logger.Debug("Registry not modified (304)", "url", src.url)
 		return nil, nil
 	}
 
@@ -133,29 +272,30 @@ func (r *Registry) fetchFromGitHub() (*RegistryData, error) {
 	// Validate version
 	if registry.Version != RegistryVersion {
 		r.logger.Warn("Registry version mismatch",
-			"expected", RegistryVersion, "got", registry.Version)
+			"url", src.url, "expected", RegistryVersion, "got", registry.Version)
 	}
 
 	// Save ETag for future requests
 	if etag := resp.Header.Get("ETag"); etag != "" {
-		_ = r.saveETag(etag)
+		_ = r.saveETag(src.etagPath, etag)
 	}
 
-	r.logger.Info("Fetched registry from GitHub",
+	r.logger.Info("Fetched registry",
+		"url", src.url,
 		"addons", len(registry.Addons),
 		"generated_at", registry.GeneratedAt.Format("2006-01-02"))
 
 	return &registry, nil
 }
 
-// loadCache loads the cached registry from disk
-func (r *Registry) loadCache() (*RegistryData, time.Time, error) {
-	info, err := os.Stat(r.cachePath)
+// loadCache loads a cached registry from disk
+func loadCache(path string) (*RegistryData, time.Time, error) {
+	info, err := os.Stat(path)
 	if err != nil {
 		return nil, time.Time{}, err
 	}
 
-	data, err := os.ReadFile(r.cachePath)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, time.Time{}, err
 	}
@@ -168,8 +308,8 @@ func (r *Registry) loadCache() (*RegistryData, time.Time, error) {
 	return &registry, info.ModTime(), nil
 }
 
-// saveCache saves the registry to disk
-func (r *Registry) saveCache(registry *RegistryData) error {
+// saveCache saves a registry to disk
+func (r *Registry) saveCache(path string, registry *RegistryData) error {
 	// Ensure directory exists
 	if err := os.MkdirAll(r.cacheDir, 0755); err != nil {
 		return fmt.Errorf("failed to create cache directory: %w", err)
@@ -180,37 +320,38 @@ func (r *Registry) saveCache(registry *RegistryData) error {
 		return fmt.Errorf("failed to marshal registry: %w", err)
 	}
 
-	if err := os.WriteFile(r.cachePath, data, 0644); err != nil {
+	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write cache: %w", err)
 	}
 
 	return nil
 }
 
-// touchCache updates the cache file's modification time
-func (r *Registry) touchCache() error {
+// touchCache updates a cache file's modification time
+func touchCache(path string) error {
 	now := time.Now()
-	return os.Chtimes(r.cachePath, now, now)
+	return os.Chtimes(path, now, now)
 }
 
-// loadETag loads the cached ETag
-func (r *Registry) loadETag() (string, error) {
-	data, err := os.ReadFile(r.etagPath)
+// loadETag loads a cached ETag
+func loadETag(path string) (string, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(string(data)), nil
 }
 
-// saveETag saves the ETag to disk
-func (r *Registry) saveETag(etag string) error {
+// saveETag saves an ETag to disk
+func (r *Registry) saveETag(path, etag string) error {
 	if err := os.MkdirAll(r.cacheDir, 0755); err != nil {
 		return err
 	}
-	return os.WriteFile(r.etagPath, []byte(etag), 0644)
+	return os.WriteFile(path, []byte(etag), 0644)
 }
 
 // RegistryInfo contains information about the registry cache state
+// With several sources, the age and staleness reflect the oldest cache
 type RegistryInfo struct {
 	HasCache    bool
 	IsStale     bool
@@ -219,53 +360,81 @@ type RegistryInfo struct {
 	Age         time.Duration
 	TotalAddons int
 	NewAddons   int
+	Sources     int // Number of registry sources with a cache
 }
 
 // GetInfo returns information about the registry cache state
 func (r *Registry) GetInfo() RegistryInfo {
-	cached, cacheTime, err := r.loadCache()
-	if err != nil || cached == nil {
-		return RegistryInfo{
-			HasCache: false,
+	var info RegistryInfo
+	var lists [][]WikiAddon
+
+	for _, src := range r.sources {
+		cached, cacheTime, err := loadCache(src.cachePath)
+		if err != nil || cached == nil {
+			continue
 		}
+
+		cacheAge := time.Since(cacheTime)
+		if !info.HasCache || cacheAge > info.Age {
+			info.Age = cacheAge
+			info.LastUpdated = cacheTime
+		}
+		if cached.GeneratedAt.After(info.GeneratedAt) {
+			info.GeneratedAt = cached.GeneratedAt
+		}
+		info.HasCache = true
+		info.Sources++
+		lists = append(lists, cached.Addons)
+	}
+
+	if !info.HasCache {
+		return info
 	}
 
-	cacheAge := time.Since(cacheTime)
-	newCount := 0
-	for _, addon := range cached.Addons {
+	merged := mergeAddons(lists)
+	info.IsStale = info.Age > RegistryCacheTTL
+	info.TotalAddons = len(merged)
+	for _, addon := range merged {
 		if addon.IsNew() {
-			newCount++
+			info.NewAddons++
 		}
 	}
 
-	return RegistryInfo{
-		HasCache:    true,
-		IsStale:     cacheAge > RegistryCacheTTL,
-		LastUpdated: cacheTime,
-		Age:         cacheAge,
-		TotalAddons: len(cached.Addons),
-		NewAddons:   newCount,
-		GeneratedAt: cached.GeneratedAt,
-	}
+	return info
 }
 
 // MarkInstalled marks addons that are already installed
+// URLs are compared with NormalizeURL, so scheme, case, .git and trailing
+// slash differences between the registry and git remotes don't matter
 func MarkInstalled(addons []WikiAddon, installedURLs map[string]bool) {
+	installed := make(map[string]bool, len(installedURLs))
+	for url, ok := range installedURLs {
+		if ok {
+			installed[NormalizeURL(url)] = true
+		}
+	}
 	for i := range addons {
-		url := addons[i].URL
-		// Check both with and without .git suffix
-		addons[i].IsInstalled = installedURLs[url] ||
-			installedURLs[url+".git"] ||
-			installedURLs[trimGitSuffix(url)]
+		addons[i].IsInstalled = installed[NormalizeURL(addons[i].URL)]
 	}
 }
 
-// trimGitSuffix removes .git suffix if present
-func trimGitSuffix(url string) string {
-	if len(url) > 4 && url[len(url)-4:] == ".git" {
-		return url[:len(url)-4]
+// NormalizeURL reduces a repository URL to host/owner/repo in lower case,
+// dropping the scheme, user info, "www.", trailing slash, and .git suffix
+// e.g. "git@GitHub.com:Shagu/pfQuest.git" -> "github.com/shagu/pfquest"
+func NormalizeURL(url string) string {
+	u := strings.ToLower(strings.TrimSpace(url))
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://"} {
+		u = strings.TrimPrefix(u, prefix)
 	}
-	return url
+	// Drop user info (git@host:owner/repo or ssh://git@host/owner/repo)
+	if at := strings.Index(u, "@"); at >= 0 && at < strings.IndexAny(u, "/:") {
+		u = u[at+1:]
+	}
+	u = strings.Replace(u, ":", "/", 1)
+	u = strings.TrimPrefix(u, "www.")
+	u = strings.TrimRight(u, "/")
+	u = strings.TrimSuffix(u, ".git")
+	return strings.TrimRight(u, "/")
 }
 
 // SortAddons sorts addons alphabetically by name
@@ -274,3 +443,85 @@ func SortAddons(addons []WikiAddon) {
 		return addons[i].Name < addons[j].Name
 	})
 }
+
+// SortAddonsByActivity sorts addons by most recent commit, unknown dates last
+func SortAddonsByActivity(addons []WikiAddon) {
+	sort.SliceStable(addons, func(i, j int) bool {
+		return addons[i].LastCommit.After(addons[j].LastCommit)
+	})
+}
+
+// FilterByMaxAge returns addons with a commit within maxAge
+// Addons with an unknown LastCommit are excluded since their age can't be checked
+func FilterByMaxAge(addons []WikiAddon, maxAge time.Duration) []WikiAddon {
+	cutoff := time.Now().Add(-maxAge)
+	var result []WikiAddon
+	for _, addon := range addons {
+		if !addon.LastCommit.IsZero() && addon.LastCommit.After(cutoff) {
+			result = append(result, addon)
+		}
+	}
+	return result
+}
+
+// SearchAddons returns addons matching query in name, author, or description,
+// optionally restricted to a wiki letter category
+// Results are ranked: name prefix, name substring, author/description, fuzzy name
+func SearchAddons(addons []WikiAddon, query, category string) []WikiAddon {
+	query = strings.ToLower(strings.TrimSpace(query))
+	category = strings.ToUpper(strings.TrimSpace(category))
+
+	type match struct {
+		addon WikiAddon
+		rank  int
+	}
+	var matches []match
+
+	for _, addon := range addons {
+		if category != "" && !strings.EqualFold(addon.Category, category) {
+			continue
+		}
+
+		name := strings.ToLower(addon.Name)
+		rank := -1
+		switch {
+		case strings.HasPrefix(name, query):
+			rank = 0
+		case strings.Contains(name, query):
+			rank = 1
+		case strings.Contains(strings.ToLower(addon.Author), query),
+			strings.Contains(strings.ToLower(addon.Description), query):
+			rank = 2
+		case isSubsequence(query, name):
+			rank = 3
+		}
+		if rank >= 0 {
+			matches = append(matches, match{addon: addon, rank: rank})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].addon.Name < matches[j].addon.Name
+	})
+
+	result := make([]WikiAddon, len(matches))
+	for i, m := range matches {
+		result[i] = m.addon
+	}
+	return result
+}
+
+// isSubsequence reports whether all characters of needle appear in order in haystack
+func isSubsequence(needle, haystack string) bool {
+	runes := []rune(needle)
+	i := 0
+	for _, r := range haystack {
+		if i < len(runes) && r == runes[i] {
+			i++
+		}
+	}
+	return i == len(runes)
+}
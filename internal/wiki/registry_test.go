@@ -0,0 +1,73 @@
+package wiki
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"https", "https://github.com/shagu/pfQuest", "github.com/shagu/pfquest"},
+		{"http", "http://github.com/shagu/pfQuest", "github.com/shagu/pfquest"},
+		{"git suffix", "https://github.com/shagu/pfQuest.git", "github.com/shagu/pfquest"},
+		{"trailing slash", "https://github.com/shagu/pfQuest/", "github.com/shagu/pfquest"},
+		{"slash after git suffix", "https://github.com/shagu/pfQuest.git/", "github.com/shagu/pfquest"},
+		{"owner case", "https://github.com/Shagu/pfQuest", "github.com/shagu/pfquest"},
+		{"host case", "https://GitHub.com/shagu/pfQuest", "github.com/shagu/pfquest"},
+		{"www", "https://www.github.com/shagu/pfQuest", "github.com/shagu/pfquest"},
+		{"scp ssh", "git@github.com:shagu/pfQuest.git", "github.com/shagu/pfquest"},
+		{"ssh scheme", "ssh://git@github.com/shagu/pfQuest.git", "github.com/shagu/pfquest"},
+		{"whitespace", "  https://github.com/shagu/pfQuest\n", "github.com/shagu/pfquest"},
+		{"other host", "https://gitlab.com/shagu/pfQuest", "gitlab.com/shagu/pfquest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeURL(tt.url); got != tt.want {
+				t.Fatalf("NormalizeURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkInstalled(t *testing.T) {
+	tests := []struct {
+		name      string
+		registry  string
+		installed string
+		want      bool
+	}{
+		{"exact", "https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest", true},
+		{"installed with git suffix", "https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest.git", true},
+		{"registry with git suffix", "https://github.com/shagu/pfQuest.git", "https://github.com/shagu/pfQuest", true},
+		{"http remote", "https://github.com/shagu/pfQuest", "http://github.com/shagu/pfQuest.git", true},
+		{"registry trailing slash", "https://github.com/shagu/pfQuest/", "https://github.com/shagu/pfQuest.git", true},
+		{"owner case", "https://github.com/Shagu/pfQuest", "https://github.com/shagu/pfQuest.git", true},
+		{"ssh remote", "https://github.com/shagu/pfQuest", "git@github.com:shagu/pfQuest.git", true},
+		{"other repo", "https://github.com/shagu/pfQuest", "https://github.com/shagu/pfUI.git", false},
+		{"other owner", "https://github.com/shagu/pfQuest", "https://github.com/fork/pfQuest.git", false},
+		{"repo name prefix", "https://github.com/shagu/pfQuest", "https://github.com/shagu/pfQuest-turtle.git", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addons := []WikiAddon{{Name: "addon", URL: tt.registry}}
+			MarkInstalled(addons, map[string]bool{tt.installed: true})
+			if addons[0].IsInstalled != tt.want {
+				t.Fatalf("IsInstalled = %v for registry %q and installed %q, want %v",
+					addons[0].IsInstalled, tt.registry, tt.installed, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeAddonsDedupesNormalizedURLs(t *testing.T) {
+	merged := mergeAddons([][]WikiAddon{
+		{{Name: "pfQuest", URL: "https://github.com/shagu/pfQuest"}},
+		{{Name: "pfQuest (turtle)", URL: "http://github.com/Shagu/pfQuest.git/"}},
+	})
+	if len(merged) != 1 || merged[0].Name != "pfQuest (turtle)" {
+		t.Fatalf("unexpected merge result: %+v", merged)
+	}
+}
@@ -11,6 +11,7 @@ type WikiAddon struct {
 	Version     string `json:"version,omitempty"`     // From latest release/tag
 	Stars       int    `json:"stars,omitempty"`       // GitHub stars count
 	Category    string `json:"category,omitempty"`    // Letter section (A-Z) from wiki
+	Ref         string `json:"ref,omitempty"`         // Recommended branch/tag, empty for the default branch
 
 	// LastCommit is when the repository was last updated (pushed_at from GitHub)
 	// Used to determine if addon is still maintained
@@ -20,8 +21,20 @@ type WikiAddon struct {
 	// Used for "new" detection (addons added within NewAddonThreshold are marked new)
 	AddedAt time.Time `json:"added_at,omitempty"`
 
+	// Archived is set for read-only repositories, they won't get updates
+	Archived bool `json:"archived,omitempty"`
+
 	// Runtime state (not persisted in registry)
-	IsInstalled bool `json:"-"`
+	IsInstalled bool   `json:"-"`
+	Source      string `json:"source,omitempty"` // Registry URL the addon was loaded from
+}
+
+// InstallURL returns the URL to install from, with the recommended ref as an @ref suffix
+func (a *WikiAddon) InstallURL() string {
+	if a.Ref == "" {
+		return a.URL
+	}
+	return a.URL + "@" + a.Ref
 }
 
 // IsNew returns true if the addon was added to the registry recently
@@ -32,6 +45,15 @@ func (a *WikiAddon) IsNew() bool {
 	return time.Since(a.AddedAt) < NewAddonThreshold
 }
 
+// IsAbandoned returns true if the repository hasn't been updated in AbandonedThreshold
+// Addons with an unknown LastCommit are never considered abandoned
+func (a *WikiAddon) IsAbandoned() bool {
+	if a.LastCommit.IsZero() {
+		return false
+	}
+	return time.Since(a.LastCommit) > AbandonedThreshold
+}
+
 // RegistryData is the structure of the addon registry (data/addons.json)
 type RegistryData struct {
 	Version     int         `json:"version"`      // Schema version (bump when format changes)
@@ -53,7 +75,10 @@ const (
 	// NewAddonThreshold is how long an addon is considered "new"
 	NewAddonThreshold = 7 * 24 * time.Hour
 
-	// RegistryURL is the URL to fetch the addon registry from GitHub
+	// AbandonedThreshold is how long without commits before an addon is considered abandoned
+	AbandonedThreshold = 2 * 365 * 24 * time.Hour
+
+	// RegistryURL is the default URL to fetch the addon registry from GitHub
 	RegistryURL = "https://raw.githubusercontent.com/bnema/turtlectl/main/data/addons.json"
 
 	// WikiURL is the Turtle WoW addon wiki page (for reference)
@@ -16,20 +16,62 @@ const (
 	// GitHubGraphQLAPI is the GitHub GraphQL API endpoint
 	GitHubGraphQLAPI = "https://api.github.com/graphql"
 
+	// GitLabGraphQLAPI is the GitLab GraphQL API endpoint
+	// Public projects can be queried without a token, at a lower rate limit
+	GitLabGraphQLAPI = "https://gitlab.com/api/graphql"
+
 	// BatchSize is how many repos to fetch per GraphQL query
 	// GitHub has complexity limits, ~100 repos per query is safe
 	BatchSize = 50
+
+	// ProbeBatchSize is how many repos to probe per query in incremental mode
+	// Probes only request scalar fields so larger batches stay cheap
+	ProbeBatchSize = 100
+)
+
+// GraphQL repository fields for a full fetch and for a change probe
+const (
+	githubRepoFields = `name
+      description
+      stargazerCount
+      pushedAt
+      isArchived
+      owner { login }
+      latestRelease { tagName }
+      refs(refPrefix: "refs/tags/", first: 1, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) {
+        nodes { name }
+      }
+      defaultBranchRef { name }
+      turtleBranches: refs(refPrefix: "refs/heads/", first: 10, query: "turtle") {
+        nodes { name }
+      }`
+
+	githubProbeFields = `stargazerCount
+      pushedAt
+      isArchived`
 )
 
-// Enricher fetches metadata from GitHub GraphQL API
+// refOverrides pins the ref to install for repos whose Turtle WoW branch or tag
+// can't be detected by name, keyed by normalized URL (see wiki.NormalizeURL)
+// Overrides take precedence over detected branches
+var refOverrides = map[string]string{}
+
+// Enricher fetches metadata from the GitHub and GitLab GraphQL APIs
 type Enricher struct {
 	client        *http.Client
 	token         string
 	authenticated bool
+
+	gitlabToken string
+	githubURL   string
+	gitlabURL   string
+
+	status func(msg string)
+	sleep  func(time.Duration)
 }
 
-// NewEnricher creates a new GitHub enricher
-// Requires GITHUB_TOKEN for GraphQL API (no unauthenticated access)
+// NewEnricher creates a new repository metadata enricher
+// Requires GITHUB_TOKEN for GitHub (no unauthenticated access), GITLAB_TOKEN is optional
 func NewEnricher() *Enricher {
 	token := os.Getenv("GITHUB_TOKEN")
 	if token == "" {
@@ -42,6 +84,18 @@ func NewEnricher() *Enricher {
 		},
 		token:         token,
 		authenticated: token != "",
+		gitlabToken:   os.Getenv("GITLAB_TOKEN"),
+		githubURL:     GitHubGraphQLAPI,
+		gitlabURL:     GitLabGraphQLAPI,
+		status:        func(msg string) { fmt.Println(msg) },
+		sleep:         time.Sleep,
+	}
+}
+
+// SetStatusFunc sets where status messages such as rate-limit waits are reported
+func (e *Enricher) SetStatusFunc(fn func(msg string)) {
+	if fn != nil {
+		e.status = fn
 	}
 }
 
@@ -59,6 +113,7 @@ func (e *Enricher) ConvertToAddons(rawAddons []RawAddon) []wiki.WikiAddon {
 			URL:      raw.URL,
 			Category: raw.Category,
 			Name:     extractNameFromURL(raw.URL),
+			Ref:      refOverrides[wiki.NormalizeURL(raw.URL)],
 		}
 		addons = append(addons, addon)
 	}
@@ -77,6 +132,7 @@ type repoKey struct {
 type graphQLResponse struct {
 	Data   map[string]json.RawMessage `json:"data"`
 	Errors []struct {
+		Type    string `json:"type"`
 		Message string `json:"message"`
 	} `json:"errors"`
 }
@@ -87,38 +143,167 @@ type repoData struct {
 	Description    string    `json:"description"`
 	StargazerCount int       `json:"stargazerCount"`
 	PushedAt       time.Time `json:"pushedAt"`
+	IsArchived     bool      `json:"isArchived"`
 	Owner          struct {
 		Login string `json:"login"`
 	} `json:"owner"`
+
+	// LatestRelease is nil for repos without releases
+	LatestRelease *releaseTag `json:"latestRelease"`
+
+	// Refs holds the most recent tag, used when there are no releases
+	Refs struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"refs"`
+
+	// DefaultBranchRef and TurtleBranches detect a dedicated Turtle WoW branch
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	TurtleBranches struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"turtleBranches"`
 }
 
-// EnrichAll enriches all addons with GitHub metadata using GraphQL batching
-func (e *Enricher) EnrichAll(addons []wiki.WikiAddon, progressFn func(current, total int, name string)) {
-	if !e.authenticated {
-		fmt.Println("Warning: GITHUB_TOKEN not set, skipping enrichment (GraphQL requires auth)")
-		return
+// releaseTag is the tag of a release
+type releaseTag struct {
+	TagName string `json:"tagName"`
+}
+
+// version returns the latest release tag, falling back to the latest tag
+func (d repoData) version() string {
+	if d.LatestRelease != nil && d.LatestRelease.TagName != "" {
+		return d.LatestRelease.TagName
+	}
+	if len(d.Refs.Nodes) > 0 {
+		return d.Refs.Nodes[0].Name
 	}
+	return ""
+}
 
-	// Build list of GitHub repos to fetch
-	var repos []repoKey
-	for i, addon := range addons {
-		if !IsGitHubURL(addon.URL) {
+// turtleBranch returns the branch dedicated to Turtle WoW, empty when the
+// default branch is the one to install
+// The shortest match wins, so "turtle" is preferred over "turtle-old"
+func (d repoData) turtleBranch() string {
+	var defaultBranch string
+	if d.DefaultBranchRef != nil {
+		defaultBranch = d.DefaultBranchRef.Name
+	}
+	var branch string
+	for _, node := range d.TurtleBranches.Nodes {
+		if node.Name == defaultBranch || !strings.Contains(strings.ToLower(node.Name), "turtle") {
 			continue
 		}
+		if branch == "" || len(node.Name) < len(branch) {
+			branch = node.Name
+		}
+	}
+	return branch
+}
+
+// EnrichAll enriches all addons with GitHub and GitLab metadata using GraphQL batching
+func (e *Enricher) EnrichAll(addons []wiki.WikiAddon, progressFn func(current, total int, name string)) {
+	e.EnrichIncremental(addons, nil, progressFn)
+}
+
+// EnrichIncremental enriches addons like EnrichAll, but reuses metadata from
+// previous for GitHub repos whose pushedAt hasn't advanced. Changes are detected
+// with a cheap pushedAt probe, then only changed repos get a full fetch
+// Returns how many repos were skipped as unchanged
+func (e *Enricher) EnrichIncremental(addons []wiki.WikiAddon, previous map[string]wiki.WikiAddon, progressFn func(current, total int, name string)) int {
+	githubRepos, gitlabRepos := e.collectRepos(addons)
+
+	total := len(githubRepos) + len(gitlabRepos)
+	if total == 0 {
+		return 0
+	}
+
+	// Only repos with known previous metadata can be probed
+	var probe, full []repoKey
+	for _, repo := range githubRepos {
+		if prev, ok := previous[addons[repo.Index].URL]; ok && !prev.LastCommit.IsZero() {
+			probe = append(probe, repo)
+		} else {
+			full = append(full, repo)
+		}
+	}
+
+	processed := 0
+	skipped := 0
+	for i := 0; i < len(probe); i += ProbeBatchSize {
+		end := i + ProbeBatchSize
+		if end > len(probe) {
+			end = len(probe)
+		}
+		batch := probe[i:end]
+
+		results, err := e.fetchWithRateLimit(e.probeBatch, batch)
+		if err != nil {
+			fmt.Printf("\nError probing batch: %v\n", err)
+		}
+
+		for _, repo := range batch {
+			addon := &addons[repo.Index]
+			prev := previous[addon.URL]
+
+			data, ok := results[repoAlias(repo)]
+			if ok && data.PushedAt.After(prev.LastCommit) {
+				full = append(full, repo)
+				continue
+			}
+
+			// Unchanged, or the probe failed: keep what we had
+			copyMetadata(addon, prev)
+			if ok {
+				// Archiving doesn't push, the probe catches it
+				addon.Stars = data.StargazerCount
+				addon.Archived = data.IsArchived
+			}
+			skipped++
+			processed++
+			if progressFn != nil {
+				progressFn(processed, total, addon.Name)
+			}
+		}
+	}
+
+	e.enrichBatches(addons, full, e.fetchBatch, &processed, total, progressFn)
+	e.enrichBatches(addons, gitlabRepos, e.fetchGitLabBatch, &processed, total, progressFn)
+
+	return skipped
+}
+
+// collectRepos splits addons into GitHub and GitLab repos to fetch
+// GitHub repos are dropped without a token since GraphQL requires auth
+func (e *Enricher) collectRepos(addons []wiki.WikiAddon) (githubRepos, gitlabRepos []repoKey) {
+	for i, addon := range addons {
 		owner, name, ok := ExtractRepoInfo(addon.URL)
 		if !ok {
 			continue
 		}
-		repos = append(repos, repoKey{Owner: owner, Name: name, Index: i})
+		key := repoKey{Owner: owner, Name: name, Index: i}
+		switch {
+		case IsGitHubURL(addon.URL):
+			githubRepos = append(githubRepos, key)
+		case IsGitLabURL(addon.URL):
+			gitlabRepos = append(gitlabRepos, key)
+		}
 	}
 
-	total := len(repos)
-	if total == 0 {
-		return
+	if !e.authenticated && len(githubRepos) > 0 {
+		fmt.Println("Warning: GITHUB_TOKEN not set, skipping GitHub enrichment (GraphQL requires auth)")
+		githubRepos = nil
 	}
 
-	// Process in batches
-	processed := 0
+	return githubRepos, gitlabRepos
+}
+
+// enrichBatches fetches repos in batches and applies the results to addons
+func (e *Enricher) enrichBatches(addons []wiki.WikiAddon, repos []repoKey, fetch func([]repoKey) (map[string]repoData, error), processed *int, total int, progressFn func(current, total int, name string)) {
 	for i := 0; i < len(repos); i += BatchSize {
 		end := i + BatchSize
 		if end > len(repos) {
@@ -127,7 +312,7 @@ func (e *Enricher) EnrichAll(addons []wiki.WikiAddon, progressFn func(current, t
 		batch := repos[i:end]
 
 		// Fetch batch
-		results, err := e.fetchBatch(batch)
+		results, err := e.fetchWithRateLimit(fetch, batch)
 		if err != nil {
 			fmt.Printf("\nError fetching batch: %v\n", err)
 			continue
@@ -135,40 +320,75 @@ func (e *Enricher) EnrichAll(addons []wiki.WikiAddon, progressFn func(current, t
 
 		// Apply results to addons
 		for _, repo := range batch {
-			processed++
-			alias := fmt.Sprintf("repo%d", repo.Index)
-			if data, ok := results[alias]; ok {
-				addons[repo.Index].Description = data.Description
-				addons[repo.Index].Stars = data.StargazerCount
-				addons[repo.Index].LastCommit = data.PushedAt
-				if data.Owner.Login != "" {
-					addons[repo.Index].Author = data.Owner.Login
-				}
+			*processed++
+			if data, ok := results[repoAlias(repo)]; ok {
+				applyRepoData(&addons[repo.Index], data)
 			}
 
 			if progressFn != nil {
-				progressFn(processed, total, addons[repo.Index].Name)
+				progressFn(*processed, total, addons[repo.Index].Name)
 			}
 		}
 	}
 }
 
+// repoAlias is the GraphQL alias and result key for a repo
+func repoAlias(repo repoKey) string {
+	return fmt.Sprintf("repo%d", repo.Index)
+}
+
+// copyMetadata copies enrichment fields from a previous registry entry
+func copyMetadata(addon *wiki.WikiAddon, prev wiki.WikiAddon) {
+	addon.Description = prev.Description
+	addon.Stars = prev.Stars
+	addon.LastCommit = prev.LastCommit
+	addon.Archived = prev.Archived
+	addon.Version = prev.Version
+	if addon.Ref == "" {
+		addon.Ref = prev.Ref
+	}
+	if prev.Author != "" {
+		addon.Author = prev.Author
+	}
+}
+
+// applyRepoData copies fetched metadata into an addon
+func applyRepoData(addon *wiki.WikiAddon, data repoData) {
+	addon.Description = data.Description
+	addon.Stars = data.StargazerCount
+	addon.LastCommit = data.PushedAt
+	addon.Archived = data.IsArchived
+	addon.Version = data.version()
+	if addon.Ref == "" {
+		addon.Ref = data.turtleBranch()
+	}
+	if data.Owner.Login != "" {
+		addon.Author = data.Owner.Login
+	}
+}
+
 // fetchBatch fetches multiple repos in a single GraphQL query
 func (e *Enricher) fetchBatch(repos []repoKey) (map[string]repoData, error) {
+	return e.queryGitHub(repos, githubRepoFields)
+}
+
+// probeBatch fetches only pushedAt and stars, to detect changed repos
+func (e *Enricher) probeBatch(repos []repoKey) (map[string]repoData, error) {
+	return e.queryGitHub(repos, githubProbeFields)
+}
+
+// queryGitHub fetches the given fields for multiple repos in a single GraphQL query
+func (e *Enricher) queryGitHub(repos []repoKey, fields string) (map[string]repoData, error) {
 	// Build GraphQL query with aliases
 	var queryParts []string
 	for _, repo := range repos {
-		alias := fmt.Sprintf("repo%d", repo.Index)
+		alias := repoAlias(repo)
 		// Escape any special characters in owner/name
 		owner := strings.ReplaceAll(repo.Owner, `"`, `\"`)
 		name := strings.ReplaceAll(repo.Name, `"`, `\"`)
 		queryParts = append(queryParts, fmt.Sprintf(`%s: repository(owner: "%s", name: "%s") {
-      name
-      description
-      stargazerCount
-      pushedAt
-      owner { login }
-    }`, alias, owner, name))
+      %s
+    }`, alias, owner, name, fields))
 	}
 
 	query := fmt.Sprintf("query { %s }", strings.Join(queryParts, "\n"))
@@ -179,7 +399,7 @@ func (e *Enricher) fetchBatch(repos []repoKey) (map[string]repoData, error) {
 		return nil, fmt.Errorf("failed to marshal query: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", GitHubGraphQLAPI, bytes.NewReader(reqBody))
+	req, err := http.NewRequest("POST", e.githubURL, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -194,6 +414,9 @@ func (e *Enricher) fetchBatch(repos []repoKey) (map[string]repoData, error) {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	if wait, limited := rateLimitWait(resp); limited {
+		return nil, &rateLimitError{wait: wait}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
@@ -204,6 +427,13 @@ func (e *Enricher) fetchBatch(repos []repoKey) (map[string]repoData, error) {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	// GitHub reports rate limits as a 200 with a RATE_LIMITED error
+	for _, gqlErr := range gqlResp.Errors {
+		if gqlErr.Type == "RATE_LIMITED" {
+			return nil, &rateLimitError{wait: waitFromHeaders(resp.Header, time.Now())}
+		}
+	}
+
 	// Check for errors (but don't fail - some repos may not exist)
 	if len(gqlResp.Errors) > 0 {
 		// Log but continue - partial data is fine
@@ -230,6 +460,129 @@ func (e *Enricher) fetchBatch(repos []repoKey) (map[string]repoData, error) {
 	return results, nil
 }
 
+// gitlabProjectsResponse represents the GitLab GraphQL projects response
+type gitlabProjectsResponse struct {
+	Data struct {
+		Projects struct {
+			Nodes []gitlabProject `json:"nodes"`
+		} `json:"projects"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// gitlabProject represents project data from the GitLab GraphQL API
+type gitlabProject struct {
+	FullPath       string    `json:"fullPath"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	StarCount      int       `json:"starCount"`
+	LastActivityAt time.Time `json:"lastActivityAt"`
+	Archived       bool      `json:"archived"`
+	Namespace      struct {
+		Path string `json:"path"`
+	} `json:"namespace"`
+	Releases struct {
+		Nodes []releaseTag `json:"nodes"`
+	} `json:"releases"`
+}
+
+// fetchGitLabBatch fetches multiple GitLab projects in a single GraphQL query
+// Results are keyed by the same aliases as fetchBatch
+func (e *Enricher) fetchGitLabBatch(repos []repoKey) (map[string]repoData, error) {
+	// GitLab may return paths in a different case than the wiki links
+	byPath := make(map[string][]repoKey, len(repos))
+	fullPaths := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		path := repo.Owner + "/" + repo.Name
+		key := strings.ToLower(path)
+		if _, seen := byPath[key]; !seen {
+			fullPaths = append(fullPaths, path)
+		}
+		byPath[key] = append(byPath[key], repo)
+	}
+
+	query := `query($paths: [String!]) {
+  projects(fullPaths: $paths, first: 100) {
+    nodes {
+      fullPath
+      name
+      description
+      starCount
+      lastActivityAt
+      archived
+      namespace { path }
+      releases(first: 1, sort: RELEASED_AT_DESC) {
+        nodes { tagName }
+      }
+    }
+  }
+}`
+
+	reqBody, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": map[string]any{"paths": fullPaths},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", e.gitlabURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if e.gitlabToken != "" {
+		req.Header.Set("Authorization", "Bearer "+e.gitlabToken)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "turtlectl/1.0 (Turtle WoW addon manager)")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if wait, limited := rateLimitWait(resp); limited {
+		return nil, &rateLimitError{wait: wait}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var glResp gitlabProjectsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&glResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, gqlErr := range glResp.Errors {
+		fmt.Printf("\nGitLab GraphQL error: %s\n", gqlErr.Message)
+	}
+
+	results := make(map[string]repoData)
+	for _, project := range glResp.Data.Projects.Nodes {
+		data := repoData{
+			Name:           project.Name,
+			Description:    project.Description,
+			StargazerCount: project.StarCount,
+			PushedAt:       project.LastActivityAt,
+			IsArchived:     project.Archived,
+		}
+		data.Owner.Login = project.Namespace.Path
+		if len(project.Releases.Nodes) > 0 {
+			data.LatestRelease = &project.Releases.Nodes[0]
+		}
+
+		for _, repo := range byPath[strings.ToLower(project.FullPath)] {
+			results[repoAlias(repo)] = data
+		}
+	}
+
+	return results, nil
+}
+
 // extractNameFromURL extracts a reasonable name from a URL
 func extractNameFromURL(url string) string {
 	_, repo, ok := ExtractRepoInfo(url)
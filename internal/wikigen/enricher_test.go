@@ -0,0 +1,251 @@
+package wikigen
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bnema/turtlectl/internal/wiki"
+)
+
+func TestEnrichAllFetchesGitLabProjects(t *testing.T) {
+	e := NewEnricher()
+	e.authenticated = false
+	e.gitlabToken = "glpat-test"
+	e.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.String() != GitLabGraphQLAPI {
+				t.Fatalf("unexpected request: %s", req.URL.String())
+			}
+			if got := req.Header.Get("Authorization"); got != "Bearer glpat-test" {
+				t.Fatalf("unexpected Authorization header: %q", got)
+			}
+
+			var body struct {
+				Variables struct {
+					Paths []string `json:"paths"`
+				} `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if len(body.Variables.Paths) != 2 {
+				t.Fatalf("expected 2 paths in one batch, got %v", body.Variables.Paths)
+			}
+
+			return jsonResponse(`{"data":{"projects":{"nodes":[
+				{"fullPath":"woblight/pwscounter","name":"pwscounter","description":"Counts things","starCount":3,"lastActivityAt":"2024-05-01T10:00:00Z","namespace":{"path":"woblight"},"releases":{"nodes":[{"tagName":"v1.2.0"}]}}
+			]}}}`, ""), nil
+		}),
+	}
+
+	addons := []wiki.WikiAddon{
+		{Name: "bar", URL: "https://github.com/foo/bar"},
+		{Name: "pwscounter", URL: "https://gitlab.com/WobLight/pwscounter"},
+		{Name: "missing", URL: "https://gitlab.com/acme/missing"},
+	}
+
+	var calls int
+	e.EnrichAll(addons, func(current, total int, name string) {
+		calls++
+		if total != 2 {
+			t.Fatalf("unexpected total: %d", total)
+		}
+	})
+
+	if calls != 2 {
+		t.Fatalf("expected progress for 2 GitLab repos, got %d", calls)
+	}
+
+	got := addons[1]
+	if got.Description != "Counts things" || got.Stars != 3 || got.Author != "woblight" || got.LastCommit.IsZero() {
+		t.Fatalf("GitLab addon not enriched: %+v", got)
+	}
+	if got.Version != "v1.2.0" {
+		t.Fatalf("unexpected version: %q", got.Version)
+	}
+	if addons[2].Description != "" {
+		t.Fatalf("missing project should be left untouched: %+v", addons[2])
+	}
+}
+
+func TestRepoDataVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"release", `{"latestRelease":{"tagName":"v2.0"},"refs":{"nodes":[{"name":"v2.1-beta"}]}}`, "v2.0"},
+		{"tag only", `{"latestRelease":null,"refs":{"nodes":[{"name":"1.3"}]}}`, "1.3"},
+		{"none", `{"latestRelease":null,"refs":{"nodes":[]}}`, ""},
+	}
+
+	for _, tt := range tests {
+		var data repoData
+		if err := json.Unmarshal([]byte(tt.json), &data); err != nil {
+			t.Fatalf("%s: Unmarshal() returned error: %v", tt.name, err)
+		}
+		if got := data.version(); got != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRepoDataTurtleBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"turtle branch", `{"defaultBranchRef":{"name":"master"},"turtleBranches":{"nodes":[{"name":"turtle-old"},{"name":"Turtle"}]}}`, "Turtle"},
+		{"default is turtle", `{"defaultBranchRef":{"name":"turtle"},"turtleBranches":{"nodes":[{"name":"turtle"}]}}`, ""},
+		{"none", `{"defaultBranchRef":{"name":"main"},"turtleBranches":{"nodes":[]}}`, ""},
+		{"empty repo", `{"defaultBranchRef":null,"turtleBranches":{"nodes":[{"name":"turtlewow"}]}}`, "turtlewow"},
+	}
+
+	for _, tt := range tests {
+		var data repoData
+		if err := json.Unmarshal([]byte(tt.json), &data); err != nil {
+			t.Fatalf("%s: Unmarshal() returned error: %v", tt.name, err)
+		}
+		if got := data.turtleBranch(); got != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyRepoDataKeepsRefOverride(t *testing.T) {
+	data := repoData{}
+	data.TurtleBranches.Nodes = append(data.TurtleBranches.Nodes, struct {
+		Name string `json:"name"`
+	}{Name: "turtle"})
+
+	addon := wiki.WikiAddon{Ref: "v1.12"}
+	applyRepoData(&addon, data)
+	if addon.Ref != "v1.12" {
+		t.Fatalf("override should win over detected branch, got %q", addon.Ref)
+	}
+
+	addon = wiki.WikiAddon{}
+	applyRepoData(&addon, data)
+	if addon.Ref != "turtle" {
+		t.Fatalf("expected detected branch, got %q", addon.Ref)
+	}
+}
+
+func TestEnrichAllRetriesRateLimitedBatch(t *testing.T) {
+	responses := []*http.Response{
+		{StatusCode: http.StatusForbidden, Header: http.Header{"Retry-After": {"45"}}, Body: http.NoBody},
+		jsonResponse(`{"data":{"repo0":null},"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`, ""),
+		jsonResponse(`{"data":{"repo0":{"name":"bar","description":"Bars","stargazerCount":7,"pushedAt":"2024-05-01T10:00:00Z","owner":{"login":"foo"}}}}`, ""),
+	}
+
+	e := NewEnricher()
+	e.authenticated = true
+	e.token = "ghp-test"
+	e.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if len(responses) == 0 {
+				t.Fatal("unexpected extra request")
+			}
+			resp := responses[0]
+			responses = responses[1:]
+			return resp, nil
+		}),
+	}
+
+	var waits []time.Duration
+	e.sleep = func(d time.Duration) { waits = append(waits, d) }
+	var messages []string
+	e.SetStatusFunc(func(msg string) { messages = append(messages, msg) })
+
+	addons := []wiki.WikiAddon{{Name: "bar", URL: "https://github.com/foo/bar"}}
+	e.EnrichAll(addons, nil)
+
+	if addons[0].Stars != 7 {
+		t.Fatalf("addon not enriched after retries: %+v", addons[0])
+	}
+	if len(waits) != 2 || waits[0] != 45*time.Second || waits[1] != defaultRateLimitWait {
+		t.Fatalf("unexpected waits: %v", waits)
+	}
+	if len(messages) != 2 || !strings.HasPrefix(messages[0], "rate limited, waiting 45s") {
+		t.Fatalf("unexpected status messages: %q", messages)
+	}
+}
+
+func TestRateLimitWaitIgnoresPermissionErrors(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{"X-Ratelimit-Remaining": {"12"}}}
+	if _, limited := rateLimitWait(resp); limited {
+		t.Fatal("403 with remaining quota should not be treated as a rate limit")
+	}
+
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	if _, limited := rateLimitWait(resp); !limited {
+		t.Fatal("403 with no remaining quota should be treated as a rate limit")
+	}
+}
+
+func TestEnrichIncrementalOnlyFetchesChangedRepos(t *testing.T) {
+	pushed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var queries []string
+	e := NewEnricher()
+	e.authenticated = true
+	e.token = "ghp-test"
+	e.client = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Query string `json:"query"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			queries = append(queries, body.Query)
+
+			if !strings.Contains(body.Query, "description") {
+				// Probe: repo0 unchanged but archived since, repo1 pushed since
+				return jsonResponse(`{"data":{
+					"repo0":{"stargazerCount":11,"pushedAt":"2024-05-01T10:00:00Z","isArchived":true},
+					"repo1":{"stargazerCount":2,"pushedAt":"2025-01-01T00:00:00Z"}}}`, ""), nil
+			}
+
+			if strings.Contains(body.Query, "repo0:") {
+				t.Fatal("unchanged repo should not be fully fetched")
+			}
+			return jsonResponse(`{"data":{
+				"repo1":{"name":"changed","description":"New","stargazerCount":2,"pushedAt":"2025-01-01T00:00:00Z","owner":{"login":"foo"}},
+				"repo2":{"name":"fresh","description":"Fresh","stargazerCount":1,"pushedAt":"2025-01-01T00:00:00Z","isArchived":true,"owner":{"login":"foo"}}}}`, ""), nil
+		}),
+	}
+
+	addons := []wiki.WikiAddon{
+		{Name: "same", URL: "https://github.com/foo/same"},
+		{Name: "changed", URL: "https://github.com/foo/changed"},
+		{Name: "fresh", URL: "https://github.com/foo/fresh"},
+	}
+	previous := map[string]wiki.WikiAddon{
+		"https://github.com/foo/same":    {Description: "Old", Stars: 10, Version: "v1", LastCommit: pushed},
+		"https://github.com/foo/changed": {Description: "Old", Stars: 1, LastCommit: pushed},
+	}
+
+	skipped := e.EnrichIncremental(addons, previous, nil)
+
+	if skipped != 1 {
+		t.Fatalf("expected 1 skipped repo, got %d", skipped)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("expected a probe and a full query, got %d", len(queries))
+	}
+	if addons[0].Description != "Old" || addons[0].Version != "v1" || addons[0].Stars != 11 {
+		t.Fatalf("unchanged repo should keep metadata with fresh stars: %+v", addons[0])
+	}
+	if addons[1].Description != "New" || addons[2].Description != "Fresh" {
+		t.Fatalf("changed and new repos should be enriched: %+v %+v", addons[1], addons[2])
+	}
+	if !addons[0].Archived || addons[1].Archived || !addons[2].Archived {
+		t.Fatalf("archived state should come from the probe and the full fetch: %v %v %v",
+			addons[0].Archived, addons[1].Archived, addons[2].Archived)
+	}
+}
@@ -0,0 +1,95 @@
+package wikigen
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// MaxRateLimitRetries is how many times a rate-limited batch is retried
+	MaxRateLimitRetries = 3
+
+	// defaultRateLimitWait is used when the response doesn't say when to retry
+	defaultRateLimitWait = 60 * time.Second
+
+	// maxRateLimitWait caps a single wait so a bad reset header can't stall CI
+	maxRateLimitWait = 15 * time.Minute
+)
+
+// rateLimitError is returned by a fetch that was rate limited
+type rateLimitError struct {
+	wait time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("rate limited, retry in %s", e.wait)
+}
+
+// rateLimitWait reports whether resp is a rate-limit response and how long to wait
+// Handles both GitHub (X-RateLimit-*) and GitLab (RateLimit-*) headers
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	remaining := firstHeader(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+
+	// A 403 is only a rate limit if the headers say so, otherwise it's a permission error
+	if resp.StatusCode == http.StatusForbidden && retryAfter == "" && remaining != "0" {
+		return 0, false
+	}
+
+	return waitFromHeaders(resp.Header, time.Now()), true
+}
+
+// waitFromHeaders computes the wait from Retry-After or the rate-limit reset time
+func waitFromHeaders(header http.Header, now time.Time) time.Duration {
+	if secs, err := strconv.Atoi(header.Get("Retry-After")); err == nil && secs >= 0 {
+		return clampWait(time.Duration(secs) * time.Second)
+	}
+
+	if reset, err := strconv.ParseInt(firstHeader(header, "X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+		// Add a second so we don't wake up just before the window resets
+		return clampWait(time.Unix(reset, 0).Sub(now) + time.Second)
+	}
+
+	return defaultRateLimitWait
+}
+
+func clampWait(d time.Duration) time.Duration {
+	switch {
+	case d < time.Second:
+		return time.Second
+	case d > maxRateLimitWait:
+		return maxRateLimitWait
+	}
+	return d
+}
+
+func firstHeader(header http.Header, keys ...string) string {
+	for _, key := range keys {
+		if v := header.Get(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// fetchWithRateLimit runs fetch, waiting and retrying when it's rate limited
+func (e *Enricher) fetchWithRateLimit(fetch func([]repoKey) (map[string]repoData, error), batch []repoKey) (map[string]repoData, error) {
+	for attempt := 0; ; attempt++ {
+		results, err := fetch(batch)
+
+		var rateErr *rateLimitError
+		if !errors.As(err, &rateErr) || attempt >= MaxRateLimitRetries {
+			return results, err
+		}
+
+		e.status(fmt.Sprintf("rate limited, waiting %s (retry %d/%d)", rateErr.wait.Round(time.Second), attempt+1, MaxRateLimitRetries))
+		e.sleep(rateErr.wait)
+	}
+}